@@ -0,0 +1,128 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chk
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"testing"
+)
+
+// ImageDiff compares two PNG images pixel by pixel
+//  Input:
+//   fnameA, fnameB -- filenames of PNG images
+//   tol            -- tolerance on the difference of each RGBA channel, normalised to [0,1]
+//   fnameDiff      -- filename of PNG image highlighting the differences (in red); "" => none
+//  Output:
+//   diffFrac -- fraction of pixels with at least one channel differing by more than tol
+//  Note: images with different dimensions give an error
+func ImageDiff(fnameA, fnameB string, tol float64, fnameDiff string) (diffFrac float64, err error) {
+
+	// load images
+	a, err := readPng(fnameA)
+	if err != nil {
+		return
+	}
+	b, err := readPng(fnameB)
+	if err != nil {
+		return
+	}
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Dx() != rb.Dx() || ra.Dy() != rb.Dy() {
+		return 1, Err("images have different dimensions: %dx%d (%s) != %dx%d (%s)", ra.Dx(), ra.Dy(), fnameA, rb.Dx(), rb.Dy(), fnameB)
+	}
+
+	// diff image
+	var d *image.RGBA
+	if fnameDiff != "" {
+		d = image.NewRGBA(image.Rect(0, 0, ra.Dx(), ra.Dy()))
+	}
+
+	// compare pixels
+	ndiff := 0
+	for j := 0; j < ra.Dy(); j++ {
+		for i := 0; i < ra.Dx(); i++ {
+			ca := a.At(ra.Min.X+i, ra.Min.Y+j)
+			cb := b.At(rb.Min.X+i, rb.Min.Y+j)
+			differ := pixelDiff(ca, cb) > tol
+			if differ {
+				ndiff++
+			}
+			if d != nil {
+				if differ {
+					d.Set(i, j, color.RGBA{255, 0, 0, 255})
+				} else {
+					g := color.GrayModel.Convert(ca).(color.Gray)
+					g.Y = 128 + g.Y/2 // faded background
+					d.Set(i, j, g)
+				}
+			}
+		}
+	}
+	npix := ra.Dx() * ra.Dy()
+	if npix > 0 {
+		diffFrac = float64(ndiff) / float64(npix)
+	}
+
+	// save diff image
+	if d != nil {
+		f, e := os.Create(fnameDiff)
+		if e != nil {
+			return diffFrac, Err("cannot create file <%s>:\n%v", fnameDiff, e)
+		}
+		defer f.Close()
+		e = png.Encode(f, d)
+		if e != nil {
+			return diffFrac, Err("cannot encode diff image <%s>:\n%v", fnameDiff, e)
+		}
+	}
+	return
+}
+
+// Image compares a generated PNG image against a golden one. Channels differing by less than tol
+// (normalised to [0,1]) are ignored; e.g. to disregard anti-aliasing noise
+func Image(tst *testing.T, msg, generated, golden string, tol float64) {
+	diffFrac, err := ImageDiff(generated, golden, tol, "")
+	if err != nil {
+		tst.Errorf("[1;31m%s failed:\n%v[0m", msg, err)
+		return
+	}
+	if diffFrac > 0 {
+		if Verbose {
+			fmt.Printf("%s [1;31merror: %g of pixels differ[0m\n", msg, diffFrac)
+		}
+		tst.Errorf("[1;31m%s failed: %g of pixels differ[0m", msg, diffFrac)
+		return
+	}
+	PrintOk(msg)
+}
+
+// readPng reads PNG file
+func readPng(fname string) (img image.Image, err error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, Err("cannot open file <%s>:\n%v", fname, err)
+	}
+	defer f.Close()
+	img, err = png.Decode(f)
+	if err != nil {
+		return nil, Err("cannot decode PNG file <%s>:\n%v", fname, err)
+	}
+	return
+}
+
+// pixelDiff returns the maximum difference between the RGBA channels of two colors, normalised to [0,1]
+func pixelDiff(a, b color.Color) (diff float64) {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	for _, v := range [][2]uint32{{r1, r2}, {g1, g2}, {b1, b2}, {a1, a2}} {
+		diff = math.Max(diff, math.Abs(float64(v[0])-float64(v[1]))/0xffff)
+	}
+	return
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chk
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+)
+
+// writeTestPng writes a w×h PNG file with a gradient; pixels in mods are replaced by the given colors
+func writeTestPng(tst *testing.T, fname string, w, h int, mods map[image.Point]color.RGBA) {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			img.Set(i, j, color.RGBA{uint8(10 * i), uint8(10 * j), 100, 255})
+		}
+	}
+	for p, c := range mods {
+		img.Set(p.X, p.Y, c)
+	}
+	os.MkdirAll("/tmp/gosl/chk", 0777)
+	f, err := os.Create(fname)
+	if err != nil {
+		tst.Fatalf("cannot create %s: %v", fname, err)
+	}
+	defer f.Close()
+	png.Encode(f, img)
+}
+
+func Test_image01(tst *testing.T) {
+
+	//Verbose = true
+	PrintTitle("image01")
+
+	a := "/tmp/gosl/chk/t_image01_a.png"
+	b := "/tmp/gosl/chk/t_image01_b.png"
+	c := "/tmp/gosl/chk/t_image01_c.png"
+	d := "/tmp/gosl/chk/t_image01_d.png"
+	e := "/tmp/gosl/chk/t_image01_e.png"
+	writeTestPng(tst, a, 10, 10, nil)
+	writeTestPng(tst, b, 10, 10, nil)
+	writeTestPng(tst, c, 10, 10, map[image.Point]color.RGBA{{3, 4}: {255, 255, 255, 255}})
+	writeTestPng(tst, d, 10, 10, map[image.Point]color.RGBA{{3, 4}: {31, 40, 100, 255}, {5, 5}: {50, 51, 100, 255}})
+	writeTestPng(tst, e, 10, 12, nil)
+
+	// identical
+	Image(tst, "identical", a, b, 0)
+
+	// one pixel changed
+	frac, err := ImageDiff(a, c, 0.01, "/tmp/gosl/chk/t_image01_diff.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	Scalar(tst, "one pixel", 1e-15, frac, 0.01)
+
+	// noise below tolerance
+	frac, _ = ImageDiff(a, d, 0.001, "")
+	Scalar(tst, "noise detected", 1e-15, frac, 0.02)
+	Image(tst, "noise ignored", a, d, 0.01)
+
+	// different dimensions
+	_, err = ImageDiff(a, e, 0.01, "")
+	if err == nil {
+		tst.Errorf("different dimensions should fail\n")
+		return
+	}
+	if Verbose {
+		PrintOk("caught error: %v", err)
+	}
+}
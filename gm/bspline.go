@@ -201,6 +201,68 @@ func (o *Bspline) Elements() (spans [][]int) {
 	return
 }
 
+// FindSpan returns the index of the knot span where t falls in; i.e. T[span] <= t < T[span+1]
+func (o *Bspline) FindSpan(t float64) int {
+	// check
+	if t < o.tmin || t > o.tmax {
+		chk.Panic("t must be within [%g, %g]. t=%g is incorrect", o.tmin, o.tmax, t)
+	}
+	return o.find_span(t)
+}
+
+// NonZeroBasis computes only the p+1 non-zero basis functions @ t. It does not change the
+// results of CalcBasis or CalcBasisAndDerivs (see GetBasis)
+//  Output:
+//   first -- index of the first non-zero basis function; i.e. vals[k] == N[first+k]
+//   vals  -- [p+1] values of non-zero basis functions (new slice)
+func (o *Bspline) NonZeroBasis(t float64) (first int, vals []float64) {
+	// using local arrays (Piegl & Tiller, algorithm A2.2)
+	span := o.FindSpan(t)
+	first = span - o.p
+	vals = make([]float64, o.p+1)
+	le := make([]float64, o.p+1)
+	ri := make([]float64, o.p+1)
+	vals[0] = 1
+	for j := 1; j <= o.p; j++ {
+		le[j] = t - o.T[span+1-j]
+		ri[j] = o.T[span+j] - t
+		saved := 0.0
+		for r := 0; r < j; r++ {
+			temp := vals[r] / (ri[r+1] + le[j-r])
+			vals[r] = saved + ri[r+1]*temp
+			saved = le[j-r] * temp
+		}
+		vals[j] = saved
+	}
+	return
+}
+
+// SupportOfBasis returns the interval [tmin, tmax] where the basis function N[i] is non-zero
+func (o *Bspline) SupportOfBasis(i int) (tmin, tmax float64) {
+	if i < 0 || i >= o.NumBasis() {
+		chk.Panic("index of basis function must be within [0, %d]. i=%d is incorrect", o.NumBasis()-1, i)
+	}
+	return o.T[i], o.T[i+o.p+1]
+}
+
+// GrevilleAbscissae returns the Greville abscissae; i.e. the averages of p consecutive knots
+// associated with each basis function
+func (o *Bspline) GrevilleAbscissae() (ξ []float64) {
+	n := o.NumBasis()
+	ξ = make([]float64, n)
+	for i := 0; i < n; i++ {
+		if o.p == 0 {
+			ξ[i] = (o.T[i] + o.T[i+1]) / 2.0
+			continue
+		}
+		for k := 1; k <= o.p; k++ {
+			ξ[i] += o.T[i+k]
+		}
+		ξ[i] /= float64(o.p)
+	}
+	return
+}
+
 // auxiliary methods /////////////////////////////////////////////////////////////////////////////////
 
 // find_span returns the span where t falls in
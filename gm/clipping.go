@@ -0,0 +1,91 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+// ClipSegmentBox clips segment xi -> xf against the axis-aligned box [lo, hi] (2D or 3D)
+// using the Liang-Barsky algorithm
+//  Output:
+//   ci, cf -- initial and final points of clipped segment (new slices)
+//   ok     -- false if the segment is completely outside the box
+func ClipSegmentBox(xi, xf, lo, hi []float64) (ci, cf []float64, ok bool) {
+	ndim := len(xi)
+	t0, t1 := 0.0, 1.0
+	for k := 0; k < ndim; k++ {
+		d := xf[k] - xi[k]
+		if !clipTest(-d, xi[k]-lo[k], &t0, &t1) {
+			return
+		}
+		if !clipTest(d, hi[k]-xi[k], &t0, &t1) {
+			return
+		}
+	}
+	ci = make([]float64, ndim)
+	cf = make([]float64, ndim)
+	for k := 0; k < ndim; k++ {
+		d := xf[k] - xi[k]
+		ci[k] = xi[k] + t0*d
+		cf[k] = xi[k] + t1*d
+	}
+	ok = true
+	return
+}
+
+// ClipPolygonBox clips a 2D polygon against the axis-aligned box [lo, hi] using the
+// Sutherland-Hodgman algorithm. The polygon is given by its vertices (without repeating the
+// first one). It returns nil if the polygon is completely outside the box
+func ClipPolygonBox(poly [][]float64, lo, hi []float64) [][]float64 {
+	res := poly
+	for k := 0; k < 2; k++ {
+		res = clipPolygonPlane(res, k, lo[k], 1)
+		res = clipPolygonPlane(res, k, hi[k], -1)
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// clipTest updates the parametric limits t0 and t1 of Liang-Barsky's algorithm
+// returns false if the segment is outside
+func clipTest(p, q float64, t0, t1 *float64) bool {
+	if p == 0 {
+		return q >= 0 // parallel: inside if q >= 0
+	}
+	r := q / p
+	if p < 0 {
+		if r > *t1 {
+			return false
+		}
+		if r > *t0 {
+			*t0 = r
+		}
+		return true
+	}
+	if r < *t0 {
+		return false
+	}
+	if r < *t1 {
+		*t1 = r
+	}
+	return true
+}
+
+// clipPolygonPlane clips polygon against the plane x[k] == val, keeping the side where
+// sgn * (x[k] - val) >= 0
+func clipPolygonPlane(poly [][]float64, k int, val, sgn float64) (res [][]float64) {
+	n := len(poly)
+	for i := 0; i < n; i++ {
+		a, b := poly[i], poly[(i+1)%n]
+		da, db := sgn*(a[k]-val), sgn*(b[k]-val)
+		if da >= 0 {
+			res = append(res, []float64{a[0], a[1]})
+		}
+		if (da > 0 && db < 0) || (da < 0 && db > 0) {
+			t := da / (da - db)
+			res = append(res, []float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])})
+		}
+	}
+	return
+}
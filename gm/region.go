@@ -0,0 +1,242 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/plt"
+)
+
+// mitreLimit is the maximum ratio between the displacement of a vertex and the offset distance.
+// Sharper corners are bevelled
+const mitreLimit = 4.0
+
+// Region2d represents a 2D region bounded by an outer polygon and, optionally, by holes.
+// Polygons are given by their vertices without repeating the first one
+type Region2d struct {
+	Outer [][]float64   // outer boundary
+	Holes [][][]float64 // holes
+}
+
+// NewRegion2d creates a new 2D region
+func NewRegion2d(outer [][]float64, holes ...[][]float64) (o *Region2d, err error) {
+	if len(outer) < 3 {
+		return nil, chk.Err("outer polygon must have at least 3 vertices. %d is invalid", len(outer))
+	}
+	for i, h := range holes {
+		if len(h) < 3 {
+			return nil, chk.Err("hole %d must have at least 3 vertices. %d is invalid", i, len(h))
+		}
+	}
+	o = &Region2d{outer, holes}
+	return
+}
+
+// Contains returns whether p is inside the region; i.e. inside the outer polygon and outside all holes
+func (o *Region2d) Contains(p []float64) bool {
+	if !PointInPolygon(p, o.Outer) {
+		return false
+	}
+	for _, h := range o.Holes {
+		if PointInPolygon(p, h) {
+			return false
+		}
+	}
+	return true
+}
+
+// Area computes the area of the region
+func (o *Region2d) Area() (area float64) {
+	area = math.Abs(PolygonArea(o.Outer))
+	for _, h := range o.Holes {
+		area -= math.Abs(PolygonArea(h))
+	}
+	return
+}
+
+// BBox returns the bounding box of the region
+func (o *Region2d) BBox() (lo, hi []float64) {
+	lo = []float64{o.Outer[0][0], o.Outer[0][1]}
+	hi = []float64{o.Outer[0][0], o.Outer[0][1]}
+	for _, p := range o.Outer {
+		for k := 0; k < 2; k++ {
+			lo[k] = math.Min(lo[k], p[k])
+			hi[k] = math.Max(hi[k], p[k])
+		}
+	}
+	return
+}
+
+// Offset returns a new region with boundaries displaced by d along their normals. d > 0 enlarges
+// the region (the holes shrink) whereas d < 0 shrinks the region. Corners are mitred; corners
+// sharper than the mitre limit are bevelled. An error is returned if any polygon collapses, if
+// any hole leaves the outer boundary, or if the resulting boundaries intersect each other
+func (o *Region2d) Offset(d float64) (res *Region2d, err error) {
+	res = new(Region2d)
+	res.Outer, err = offsetPolygon(o.Outer, d)
+	if err != nil {
+		return nil, chk.Err("cannot offset outer polygon:\n%v", err)
+	}
+	res.Holes = make([][][]float64, len(o.Holes))
+	for i, h := range o.Holes {
+		res.Holes[i], err = offsetPolygon(h, -d)
+		if err != nil {
+			return nil, chk.Err("cannot offset hole %d:\n%v", i, err)
+		}
+	}
+	for i, h := range res.Holes {
+		for _, p := range h {
+			if !PointInPolygon(p, res.Outer) {
+				return nil, chk.Err("offset hole %d is not inside the outer boundary (d=%g)", i, d)
+			}
+		}
+	}
+	rings := append([][][]float64{res.Outer}, res.Holes...)
+	for i := 0; i < len(rings); i++ {
+		for j := i; j < len(rings); j++ {
+			if polygonsIntersect(rings[i], rings[j]) {
+				if i == j {
+					return nil, chk.Err("offset polygon %d self-intersects (d=%g)", i, d)
+				}
+				return nil, chk.Err("offset polygons %d and %d intersect (d=%g)", i, j, d)
+			}
+		}
+	}
+	return
+}
+
+// SamplePoints returns the centres of the cells of a grid with the given spacing that fall
+// inside the region; e.g. to seed Bins
+func (o *Region2d) SamplePoints(spacing float64) (P [][]float64) {
+	if spacing <= 0 {
+		chk.Panic("spacing must be positive. spacing=%g is invalid", spacing)
+	}
+	lo, hi := o.BBox()
+	nx := int(math.Ceil((hi[0] - lo[0]) / spacing))
+	ny := int(math.Ceil((hi[1] - lo[1]) / spacing))
+	for j := 0; j < ny; j++ {
+		for i := 0; i < nx; i++ {
+			p := []float64{lo[0] + (float64(i)+0.5)*spacing, lo[1] + (float64(j)+0.5)*spacing}
+			if o.Contains(p) {
+				P = append(P, p)
+			}
+		}
+	}
+	return
+}
+
+// Draw draws the outer boundary and holes. Holes are drawn with dashed lines
+func (o *Region2d) Draw(args *plt.A) {
+	outer := &plt.A{Ec: "k", Fc: "none", Closed: true}
+	if args != nil {
+		*outer = *args
+		outer.Closed = true
+	}
+	hole := new(plt.A)
+	*hole = *outer
+	hole.Ls = "--"
+	if hole.Fc != "" && hole.Fc != "none" {
+		hole.Fc = "white"
+	}
+	plt.Polyline(o.Outer, outer)
+	for _, h := range o.Holes {
+		plt.Polyline(h, hole)
+	}
+}
+
+// polygons //////////////////////////////////////////////////////////////////////////////////////////
+
+// PointInPolygon returns whether p is inside the 2D polygon (given by its vertices without
+// repeating the first one) using the crossing number (ray casting) algorithm
+func PointInPolygon(p []float64, poly [][]float64) (inside bool) {
+	n := len(poly)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := poly[j], poly[i]
+		if (a[1] > p[1]) != (b[1] > p[1]) {
+			x := a[0] + (p[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+			if p[0] < x {
+				inside = !inside
+			}
+		}
+	}
+	return
+}
+
+// PolygonArea computes the signed area of a 2D polygon; positive if the vertices are counter-clockwise
+func PolygonArea(poly [][]float64) (area float64) {
+	n := len(poly)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		area += poly[j][0]*poly[i][1] - poly[i][0]*poly[j][1]
+	}
+	return area / 2.0
+}
+
+// offsetPolygon displaces the edges of polygon by d along their outward normals
+func offsetPolygon(poly [][]float64, d float64) (res [][]float64, err error) {
+	area := PolygonArea(poly)
+	if area == 0 {
+		return nil, chk.Err("polygon has zero area")
+	}
+	sgn := 1.0 // outward normal of edge (dx,dy) is sgn*(dy,-dx)/len
+	if area < 0 {
+		sgn = -1.0
+	}
+	n := len(poly)
+	normal := func(i int) []float64 { // of edge i -> i+1
+		a, b := poly[i], poly[(i+1)%n]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		l := math.Sqrt(dx*dx + dy*dy)
+		return []float64{sgn * dy / l, -sgn * dx / l}
+	}
+	for i := 0; i < n; i++ {
+		n1, n2 := normal((i+n-1)%n), normal(i)
+		v := poly[i]
+		c := 1.0 + n1[0]*n2[0] + n1[1]*n2[1]
+		if c < 2.0/(mitreLimit*mitreLimit) { // |n1 + n2| / c > mitreLimit => bevel
+			res = append(res, []float64{v[0] + d*n1[0], v[1] + d*n1[1]})
+			res = append(res, []float64{v[0] + d*n2[0], v[1] + d*n2[1]})
+			continue
+		}
+		res = append(res, []float64{v[0] + d*(n1[0]+n2[0])/c, v[1] + d*(n1[1]+n2[1])/c})
+	}
+	if PolygonArea(res)*area <= 0 {
+		return nil, chk.Err("polygon collapses with offset distance d=%g", d)
+	}
+	return
+}
+
+// polygonsIntersect returns whether any two (non-adjacent) edges of polygons a and b intersect.
+// If a and b are the same polygon, self-intersections are checked
+func polygonsIntersect(a, b [][]float64) bool {
+	same := &a[0] == &b[0]
+	na, nb := len(a), len(b)
+	for i := 0; i < na; i++ {
+		j0 := 0
+		if same {
+			j0 = i + 1
+		}
+		for j := j0; j < nb; j++ {
+			if same && (j == i || j == (i+1)%na || i == (j+1)%nb) {
+				continue
+			}
+			if segmentsIntersect(a[i], a[(i+1)%na], b[j], b[(j+1)%nb]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsIntersect returns whether the 2D segments p1->p2 and q1->q2 properly intersect
+func segmentsIntersect(p1, p2, q1, q2 []float64) bool {
+	orient := func(a, b, c []float64) float64 {
+		return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+	}
+	d1, d2 := orient(q1, q2, p1), orient(q1, q2, p2)
+	d3, d4 := orient(p1, p2, q1), orient(p1, p2, q2)
+	return d1*d2 < 0 && d3*d4 < 0
+}
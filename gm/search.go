@@ -155,8 +155,15 @@ func (o Bins) CalcIdx(x []float64) int {
 
 // FindAlongSegment gets the ids of entries that lie close to a segment
 //  Note: the initial (xi) and final (xf) points on segment defined a bounding box of valid points
+//        the segment is first clipped against the bins' box [Xi, Xf]
 func (o Bins) FindAlongSegment(xi, xf []float64, tol float64) []int {
 
+	// clip segment
+	xi, xf, ok := ClipSegmentBox(xi[:o.Ndim], xf[:o.Ndim], o.Xi, o.Xf)
+	if !ok {
+		return nil
+	}
+
 	// auxiliary variables
 	var sbins []*Bin // selected bins
 	lmax := utl.Max(o.S[0], o.S[1])
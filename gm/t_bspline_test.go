@@ -183,3 +183,81 @@ func Test_bspline03(tst *testing.T) {
 		plt.SaveD("/tmp/gosl", "bspline03.png")
 	}
 }
+
+func Test_bspline04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bspline04. non-zero basis and support")
+
+	var b Bspline
+	T := []float64{0, 0, 0, 0, 0.25, 0.5, 0.5, 0.75, 1, 1, 1, 1}
+	b.Init(T, 3)
+	chk.Int(tst, "span(0)", b.FindSpan(0), 3)
+	chk.Int(tst, "span(0.3)", b.FindSpan(0.3), 4)
+	chk.Int(tst, "span(0.5)", b.FindSpan(0.5), 6)
+	chk.Int(tst, "span(1)", b.FindSpan(1), 7)
+
+	// compare with CalcBasis
+	nb := b.NumBasis()
+	for _, t := range utl.LinSpace(0, 1, 11) {
+		first, vals := b.NonZeroBasis(t)
+		chk.Int(tst, "len(vals)", len(vals), 4)
+		sum := 0.0
+		for _, v := range vals {
+			sum += v
+		}
+		chk.Scalar(tst, io.Sf("sum @ %g", t), 1e-15, sum, 1)
+		b.CalcBasis(t)
+		for i := 0; i < nb; i++ {
+			k := i - first
+			v := 0.0
+			if k >= 0 && k < len(vals) {
+				v = vals[k]
+			}
+			chk.Scalar(tst, io.Sf("N%d @ %g", i, t), 1e-15, v, b.GetBasis(i))
+			tmin, tmax := b.SupportOfBasis(i)
+			if b.GetBasis(i) > 0 && (t < tmin || t > tmax) {
+				tst.Errorf("N%d(%g) is non-zero outside its support [%g, %g]\n", i, t, tmin, tmax)
+				return
+			}
+		}
+	}
+
+	// NonZeroBasis does not change the results of CalcBasis
+	b.CalcBasis(0.3)
+	N := make([]float64, nb)
+	for i := 0; i < nb; i++ {
+		N[i] = b.GetBasis(i)
+	}
+	b.NonZeroBasis(0.9)
+	for i := 0; i < nb; i++ {
+		chk.Scalar(tst, io.Sf("N%d @ 0.3 after NonZeroBasis(0.9)", i), 1e-17, b.GetBasis(i), N[i])
+	}
+
+	tmin, tmax := b.SupportOfBasis(4)
+	chk.Scalar(tst, "tmin", 1e-17, tmin, 0.25)
+	chk.Scalar(tst, "tmax", 1e-17, tmax, 1)
+}
+
+func Test_bspline05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bspline05. Greville abscissae")
+
+	var b Bspline
+	T := []float64{0, 0, 0, 0.2, 0.7, 1, 1, 1}
+	b.Init(T, 2)
+	ξ := b.GrevilleAbscissae()
+	chk.Vector(tst, "ξ", 1e-15, ξ, []float64{0, 0.1, 0.45, 0.85, 1})
+
+	// straight line: control points at Greville abscissae reproduce x(t) = t
+	Q := make([][]float64, len(ξ))
+	for i, x := range ξ {
+		Q[i] = []float64{x, 2 * x}
+	}
+	b.SetControl(Q)
+	for _, t := range utl.LinSpace(0, 1, 11) {
+		C := b.Point(t, 1)
+		chk.Vector(tst, io.Sf("C(%g)", t), 1e-15, C, []float64{t, 2 * t})
+	}
+}
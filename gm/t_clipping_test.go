@@ -0,0 +1,117 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_clip01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("clip01. clip segments against box (2D)")
+
+	lo := []float64{0, 0}
+	hi := []float64{2, 1}
+
+	// fully inside
+	ci, cf, ok := ClipSegmentBox([]float64{0.5, 0.5}, []float64{1.5, 0.8}, lo, hi)
+	if !ok {
+		tst.Errorf("segment inside should be ok\n")
+		return
+	}
+	chk.Vector(tst, "inside: ci", 1e-15, ci, []float64{0.5, 0.5})
+	chk.Vector(tst, "inside: cf", 1e-15, cf, []float64{1.5, 0.8})
+
+	// fully outside
+	_, _, ok = ClipSegmentBox([]float64{-1, 2}, []float64{3, 2}, lo, hi)
+	if ok {
+		tst.Errorf("segment outside should not be ok\n")
+		return
+	}
+	_, _, ok = ClipSegmentBox([]float64{-1, 0.5}, []float64{-0.5, 2}, lo, hi)
+	if ok {
+		tst.Errorf("segment outside should not be ok\n")
+		return
+	}
+
+	// crossing one face
+	ci, cf, ok = ClipSegmentBox([]float64{1, 0.5}, []float64{3, 0.5}, lo, hi)
+	if !ok {
+		tst.Errorf("segment crossing one face should be ok\n")
+		return
+	}
+	chk.Vector(tst, "one face: ci", 1e-15, ci, []float64{1, 0.5})
+	chk.Vector(tst, "one face: cf", 1e-15, cf, []float64{2, 0.5})
+
+	// crossing two faces
+	ci, cf, ok = ClipSegmentBox([]float64{-1, -0.5}, []float64{3, 1.5}, lo, hi)
+	if !ok {
+		tst.Errorf("segment crossing two faces should be ok\n")
+		return
+	}
+	io.Pforan("ci = %v\n", ci)
+	io.Pforan("cf = %v\n", cf)
+	chk.Vector(tst, "two faces: ci", 1e-15, ci, []float64{0, 0})
+	chk.Vector(tst, "two faces: cf", 1e-15, cf, []float64{2, 1})
+}
+
+func Test_clip02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("clip02. clip segments against box (3D)")
+
+	lo := []float64{0, 0, 0}
+	hi := []float64{1, 1, 1}
+
+	// crossing two faces
+	ci, cf, ok := ClipSegmentBox([]float64{-1, 0.5, 0.5}, []float64{2, 0.5, 0.5}, lo, hi)
+	if !ok {
+		tst.Errorf("segment crossing two faces should be ok\n")
+		return
+	}
+	chk.Vector(tst, "ci", 1e-15, ci, []float64{0, 0.5, 0.5})
+	chk.Vector(tst, "cf", 1e-15, cf, []float64{1, 0.5, 0.5})
+
+	// fully outside
+	_, _, ok = ClipSegmentBox([]float64{0.5, 0.5, 1.1}, []float64{0.6, 0.6, 2}, lo, hi)
+	if ok {
+		tst.Errorf("segment outside should not be ok\n")
+	}
+}
+
+func Test_clip03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("clip03. clip polygons against box")
+
+	lo := []float64{0, 0}
+	hi := []float64{1, 1}
+
+	// fully inside
+	poly := [][]float64{{0.2, 0.2}, {0.8, 0.2}, {0.5, 0.8}}
+	res := ClipPolygonBox(poly, lo, hi)
+	chk.Matrix(tst, "inside", 1e-15, res, poly)
+
+	// fully outside
+	res = ClipPolygonBox([][]float64{{2, 2}, {3, 2}, {3, 3}}, lo, hi)
+	if res != nil {
+		tst.Errorf("polygon outside should give nil\n")
+		return
+	}
+
+	// straddling one corner
+	res = ClipPolygonBox([][]float64{{0.5, 0.5}, {1.5, 0.5}, {1.5, 1.5}, {0.5, 1.5}}, lo, hi)
+	io.Pforan("res = %v\n", res)
+	chk.Matrix(tst, "one corner", 1e-15, res, [][]float64{{0.5, 0.5}, {1, 0.5}, {1, 1}, {0.5, 1}})
+
+	// straddling all corners
+	res = ClipPolygonBox([][]float64{{-1, -1}, {2, -1}, {2, 2}, {-1, 2}}, lo, hi)
+	io.Pforan("res = %v\n", res)
+	chk.Matrix(tst, "all corners", 1e-15, res, [][]float64{{1, 0}, {1, 1}, {0, 1}, {0, 0}})
+}
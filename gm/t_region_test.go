@@ -0,0 +1,146 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/plt"
+)
+
+func Test_region01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("region01. containment and area with holes")
+
+	outer := [][]float64{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	hole1 := [][]float64{{1, 1}, {1, 2}, {2, 2}, {2, 1}} // clockwise
+	hole2 := [][]float64{{3, 3.25}, {3.5, 2.75}, {3.5, 3.75}}
+	r, err := NewRegion2d(outer, hole1, hole2)
+	if err != nil {
+		tst.Errorf("NewRegion2d failed:\n%v", err)
+		return
+	}
+
+	chk.Scalar(tst, "area", 1e-15, r.Area(), 16-1-0.25)
+	lo, hi := r.BBox()
+	chk.Vector(tst, "lo", 1e-15, lo, []float64{0, 0})
+	chk.Vector(tst, "hi", 1e-15, hi, []float64{4, 4})
+
+	for _, c := range []struct {
+		p      []float64
+		inside bool
+	}{
+		{[]float64{0.5, 0.5}, true},
+		{[]float64{1.5, 1.5}, false}, // in hole 1
+		{[]float64{3.4, 3.0}, false}, // in hole 2
+		{[]float64{3.1, 2.6}, true},
+		{[]float64{2.5, 1.5}, true},
+		{[]float64{-1, 2}, false},
+		{[]float64{5, 2}, false},
+	} {
+		if r.Contains(c.p) != c.inside {
+			tst.Errorf("Contains(%v) should be %v\n", c.p, c.inside)
+			return
+		}
+	}
+
+	P := r.SamplePoints(0.5)
+	io.Pforan("number of sample points = %d\n", len(P))
+	chk.Int(tst, "number of sample points", len(P), 64-4-1) // the centre (3.25,3.25) is in hole 2
+	for _, p := range P {
+		if !r.Contains(p) {
+			tst.Errorf("sample point %v should be inside\n", p)
+			return
+		}
+	}
+
+	_, err = NewRegion2d([][]float64{{0, 0}, {1, 0}})
+	if err == nil {
+		tst.Errorf("NewRegion2d should have failed with 2 vertices\n")
+		return
+	}
+
+	if chk.Verbose {
+		plt.SetForPng(1, 400, 150, nil)
+		r.Draw(&plt.A{Fc: "#dedede", Ec: "k"})
+		for _, p := range P {
+			plt.PlotOne(p[0], p[1], &plt.A{C: "r", M: "."})
+		}
+		plt.Equal()
+		plt.SaveD("/tmp/gosl/gm", "region01.png")
+	}
+}
+
+func Test_region02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("region02. offset")
+
+	// rectangle
+	r, _ := NewRegion2d([][]float64{{0, 0}, {2, 0}, {2, 1}, {0, 1}})
+	s, err := r.Offset(0.5)
+	if err != nil {
+		tst.Errorf("Offset failed:\n%v", err)
+		return
+	}
+	chk.Matrix(tst, "rectangle: d=0.5", 1e-15, s.Outer, [][]float64{{-0.5, -0.5}, {2.5, -0.5}, {2.5, 1.5}, {-0.5, 1.5}})
+	s, err = r.Offset(-0.25)
+	if err != nil {
+		tst.Errorf("Offset failed:\n%v", err)
+		return
+	}
+	chk.Matrix(tst, "rectangle: d=-0.25", 1e-15, s.Outer, [][]float64{{0.25, 0.25}, {1.75, 0.25}, {1.75, 0.75}, {0.25, 0.75}})
+	_, err = r.Offset(-0.6)
+	if err == nil {
+		tst.Errorf("Offset should have failed because the rectangle collapses\n")
+		return
+	}
+	io.Pforan("%v\n", err)
+
+	// L-shape
+	r, _ = NewRegion2d([][]float64{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}})
+	s, err = r.Offset(0.5)
+	if err != nil {
+		tst.Errorf("Offset failed:\n%v", err)
+		return
+	}
+	chk.Matrix(tst, "L-shape: d=0.5", 1e-15, s.Outer, [][]float64{{-0.5, -0.5}, {2.5, -0.5}, {2.5, 1.5}, {1.5, 1.5}, {1.5, 2.5}, {-0.5, 2.5}})
+	s, err = r.Offset(-0.25)
+	if err != nil {
+		tst.Errorf("Offset failed:\n%v", err)
+		return
+	}
+	chk.Matrix(tst, "L-shape: d=-0.25", 1e-15, s.Outer, [][]float64{{0.25, 0.25}, {1.75, 0.25}, {1.75, 0.75}, {0.75, 0.75}, {0.75, 1.75}, {0.25, 1.75}})
+	chk.Scalar(tst, "L-shape: d=-0.25: area", 1e-15, s.Area(), 1.5*0.5*2-0.25)
+
+	// hole growing beyond outer boundary
+	r, _ = NewRegion2d([][]float64{{0, 0}, {4, 0}, {4, 4}, {0, 4}}, [][]float64{{1, 1}, {3, 1}, {3, 3}, {1, 3}})
+	s, err = r.Offset(-0.2)
+	if err != nil {
+		tst.Errorf("Offset failed:\n%v", err)
+		return
+	}
+	chk.Matrix(tst, "hole: d=-0.2", 1e-15, s.Holes[0], [][]float64{{0.8, 0.8}, {3.2, 0.8}, {3.2, 3.2}, {0.8, 3.2}})
+	_, err = r.Offset(-0.8)
+	if err == nil {
+		tst.Errorf("Offset should have failed because the hole overlaps the outer boundary\n")
+		return
+	}
+	io.Pforan("%v\n", err)
+
+	// self-intersection
+	bow := [][]float64{{0, 0}, {1, 1}, {1, 0}, {0, 1}}
+	if !polygonsIntersect(bow, bow) {
+		tst.Errorf("bow-tie polygon should self-intersect\n")
+		return
+	}
+	if polygonsIntersect(s.Outer, s.Outer) {
+		tst.Errorf("square should not self-intersect\n")
+		return
+	}
+}
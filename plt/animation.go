@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Animate creates an animation with nframes frames and saves it to fname. The frame callback
+// issues the plotting commands (e.g. Plot or ContourF) of frame i; the figure is cleared before
+// each frame. The writer is selected by the extension: ".gif" => pillow; ".mp4" => ffmpeg
+func (o *Plotter) Animate(nframes int, fps int, fname string, frame func(i int)) (err error) {
+	if nframes < 1 || fps < 1 {
+		return chk.Err("number of frames and frames per second must be at least 1. nframes=%d and fps=%d are invalid", nframes, fps)
+	}
+	var writer string
+	switch strings.ToLower(filepath.Ext(fname)) {
+	case ".gif":
+		writer = io.Sf("ani.PillowWriter(fps=%d)", fps)
+	case ".mp4":
+		writer = io.Sf("ani.FFMpegWriter(fps=%d)", fps)
+	default:
+		return chk.Err("extension of animation file %q must be \".gif\" or \".mp4\"", fname)
+	}
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "import matplotlib.animation as ani\n")
+	for i := 0; i < nframes; i++ {
+		o.genFrame(io.Sf("frame%d_%d", n, i), i, frame)
+	}
+	io.Ff(o.pyBuf(), "frames%d = [", n)
+	for i := 0; i < nframes; i++ {
+		io.Ff(o.pyBuf(), "frame%d_%d,", n, i)
+	}
+	io.Ff(o.pyBuf(), "]\n")
+	io.Ff(o.pyBuf(), "def animate%d(i):\n", n)
+	io.Ff(o.pyBuf(), "    plt.clf()\n")
+	io.Ff(o.pyBuf(), "    frames%d[i]()\n", n)
+	if strings.HasPrefix(writer, "ani.FFMpegWriter") {
+		io.Ff(o.pyBuf(), "if not ani.writers.is_available('ffmpeg'): raise RuntimeError('cannot find ffmpeg to save MP4 animation; install ffmpeg or save GIF instead')\n")
+	}
+	io.Ff(o.pyBuf(), "anim%d = ani.FuncAnimation(plt.gcf(), animate%d, frames=%d, interval=%g)\n", n, n, nframes, 1000.0/float64(fps))
+	io.Ff(o.pyBuf(), "anim%d.save(r'%s', writer=%s)\n", n, fname, writer)
+	return o.run(fname)
+}
+
+// genFrame generates a Python function holding the commands issued by frame(i)
+func (o *Plotter) genFrame(name string, i int, frame func(i int)) {
+	nbuf := o.bufferPy.Len()
+	frame(i)
+	cmds := strings.TrimSuffix(o.bufferPy.String()[nbuf:], "\n")
+	o.bufferPy.Truncate(nbuf)
+	io.Ff(o.pyBuf(), "def %s():\n", name)
+	io.Ff(o.pyBuf(), "    pass\n")
+	if cmds != "" {
+		io.Ff(o.pyBuf(), "    %s\n", strings.Replace(cmds, "\n", "\n    ", -1))
+	}
+}
@@ -6,8 +6,11 @@ package plt
 
 import (
 	"bytes"
+	"math"
 
+	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
 )
 
 // 'A' holds "arguments" to configure plots, including "style" data for shapes (e.g. polygons)
@@ -26,6 +29,14 @@ type A struct {
 	Mew    float64 // marker edge width
 	Void   bool    // void marker => markeredgecolor='C', markerfacecolor='none'
 	NoClip bool    // turn clipping off
+	Alpha  float64 // transparency in (0,1]; 0 => not set (opaque)
+
+	// custom dashes
+	Dashes []float64 // lines: on-off lengths (points) of dashes; e.g. {5, 2, 1, 2}; ignored if Ls is a named style other than "-"
+
+	// large series
+	MaxPoints  int  // lines: maximum number of points; longer series are decimated keeping the min/max of buckets; 0 => all
+	Rasterized bool // draw as an image in vector output (EPS, PDF or SVG); e.g. dense scatters or filled contours
 
 	// shapes
 	Fc     string  // shapes: face color
@@ -33,11 +44,14 @@ type A struct {
 	Scale  float64 // shapes: scale information
 	Style  string  // shapes: style information
 	Closed bool    // shapes: closed shape
+	Rin    float64 // shapes: inner radius of wedges; i.e. annular sectors
+	Hatch  string  // shapes: hatch pattern of patches, bands and bars; e.g. "///", "xx" or ".."
 
 	// text and extra arguments
 	Ha      string  // horizontal alignment; e.g. 'center'
 	Va      string  // vertical alignment; e.g. 'center'
-	Rot     float64 // rotation
+	Rot     float64 // rotation of text (degrees)
+	Zdir    string  // direction of 3D text; e.g. 'x', 'y' or 'z'; "" => parallel to the screen
 	Fsz     float64 // font size
 	FszLbl  float64 // font size of labels
 	FszLeg  float64 // font size of legend
@@ -48,36 +62,112 @@ type A struct {
 	HideB   bool    // hide bottom frame border
 	HideT   bool    // hide top frame border
 
+	// frame borders
+	HideAll   bool    // borders: hide all frame borders, except the ones with ShowL, ShowR, ShowB or ShowT
+	ShowL     bool    // borders: keep left frame border if HideAll
+	ShowR     bool    // borders: keep right frame border if HideAll
+	ShowB     bool    // borders: keep bottom frame border if HideAll
+	ShowT     bool    // borders: keep top frame border if HideAll
+	BorderCL  string  // borders: color of left frame border; "" => default
+	BorderCR  string  // borders: color of right frame border; "" => default
+	BorderCB  string  // borders: color of bottom frame border; "" => default
+	BorderCT  string  // borders: color of top frame border; "" => default
+	BorderLwL float64 // borders: linewidth of left frame border; 0 => default
+	BorderLwR float64 // borders: linewidth of right frame border; 0 => default
+	BorderLwB float64 // borders: linewidth of bottom frame border; 0 => default
+	BorderLwT float64 // borders: linewidth of top frame border; 0 => default
+
+	// text bounding box
+	TextBbox    bool    // text: draw bounding box (background) of text
+	TextBboxFc  string  // text: face color of bounding box; "" => "white"
+	TextBboxEc  string  // text: edge color of bounding box; "" => "black"
+	TextBboxPad float64 // text: padding of bounding box (points); 0 => 4
+
+	// LaTeX
+	TexSystem string // LaTeX: executable used by SetForPdfTex; "pdflatex", "xelatex" or "lualatex"; "" => "pdflatex"
+	TexFamily string // LaTeX: font family used by SetForPdfTex; e.g. "serif" or "sans-serif"; "" => "serif"
+
+	// grid
+	GridC     string  // grid: color of grid drawn by Gll; "" => "grey"
+	GridLs    string  // grid: line style of grid drawn by Gll; "" => default
+	GridLw    float64 // grid: linewidth of grid drawn by Gll; 0 => default
+	GridAlpha float64 // grid: transparency of grid drawn by Gll in (0,1]; 0 => default
+	NoGrid    bool    // grid: Gll does not draw grid
+
 	// legend
-	LegLoc   string    // legend: location
-	LegNcol  int       // legend: number of columns
-	LegHlen  float64   // legend: handle length
-	LegFrame bool      // legend: frame on
-	LegOut   bool      // legend: outside
-	LegOutX  []float64 // legend: normalised coordinates to put legend outside frame
+	LegLoc    string    // legend: location
+	LegNcol   int       // legend: number of columns
+	LegHlen   float64   // legend: handle length
+	LegFrame  bool      // legend: frame on
+	LegOut    bool      // legend: outside
+	LegOutX   []float64 // legend: normalised coordinates to put legend outside frame
+	LegLevels []float64 // legend: LegendX adds entries with the colors of these levels of the last contour
 
 	// colors for contours or histograms
 	Colors []string // contour or histogram: colors
 
 	// contours
-	Ulevels   []float64 // contour: levels
-	UcmapIdx  int       // contour: colormap index
-	UnumFmt   string    // contour: number format; e.g. "%g" or "%.2f"
-	UnoLines  bool      // contour: do not add lines on top of filled contour
-	UnoLabels bool      // contour: do not add labels
-	UnoInline bool      // contour: do not draw labels 'inline'
-	UnoCbar   bool      // contour: do not add colorbar
-	UcbarLbl  string    // contour: colorbar label
-	UselectV  float64   // contour: selected value
-	UselectC  string    // contour: color to mark selected level. empty means no selected line
-	UselectLw float64   // contour: zero level linewidth
+	Ulevels           []float64 // contour: levels
+	UcmapIdx          int       // contour: colormap index
+	UnumFmt           string    // contour: number format; e.g. "%g" or "%.2f"
+	UnoLines          bool      // contour: do not add lines on top of filled contour
+	UnoLabels         bool      // contour: do not add labels
+	UnoInline         bool      // contour: do not draw labels 'inline'
+	UnoCbar           bool      // contour: do not add colorbar
+	UcbarLbl          string    // contour: colorbar label
+	UcbarOrient       string    // contour: colorbar orientation; "vertical" or "horizontal"; "" => vertical
+	UcbarShrink       float64   // contour: colorbar size as a fraction of the axes; 0 => default
+	UcbarAspect       float64   // contour: colorbar ratio of long to short dimensions; 0 => default
+	UcbarPad          float64   // contour: colorbar distance to the axes as a fraction of the axes; 0 => default
+	UcbarTicks        []float64 // contour: colorbar tick values; nil => automatic (or Ubounds)
+	UcbarExtend       string    // contour: colorbar pointed ends for out-of-range values; "both", "min" or "max"; "" => none
+	UselectV          float64   // contour: selected value
+	UselectC          string    // contour: color to mark selected level. empty means no selected line
+	UselectLw         float64   // contour: zero level linewidth
+	Unlevels          int       // contour: number of levels (if Ulevels is empty)
+	UlevelsPercentile bool      // contour: levels at Unlevels (default 10) equally spaced percentiles of z (if Ulevels is empty)
+	UnormLog          bool      // contour: logarithmic color normalisation; all values must be positive
+	UnormSymLog       bool      // contour: symmetric logarithmic color normalisation; linear within 1% of max(|vmin|,|vmax|) around zero
+	Uvmin             float64   // contour: min value of color normalisation; Uvmin == Uvmax => min of z
+	Uvmax             float64   // contour: max value of color normalisation; Uvmin == Uvmax => max of z
+	Ubounds           []float64 // contour: boundaries of classes with one color (from Colors) per interval; len(Colors) == len(Ubounds)-1
 
 	// Histograms
-	Htype    string // histogram: type; e.g. "bar"
-	Hstacked bool   // histogram: stacked
-	Hvoid    bool   // histogram: not filled
-	Hnbins   int    // histogram: number of bins
-	Hnormed  bool   // histogram: normed
+	Htype    string   // histogram: type; e.g. "bar"
+	Hstacked bool     // histogram: stacked
+	Hvoid    bool     // histogram: not filled
+	Hnbins   int      // histogram: number of bins
+	Hnormed  bool     // histogram: normed
+	Hstrict  bool     // histogram: HistLog returns an error if there are non-positive samples instead of dropping them
+	Hhatches []string // histogram: hatch patterns of series; cycled if there are more series than patterns
+
+	// quiver
+	Qlength    float64 // quiver: length of arrows (3D)
+	Qnormalize bool    // quiver: normalize arrows such that all have the same length (3D)
+	Qscale     float64 // quiver: number of data units per arrow length unit; 0 => automatic
+	Qwidth     float64 // quiver: width of shaft in fraction of plot width; 0 => automatic
+	QbyMag     bool    // quiver: color arrows by magnitude using colormap UcmapIdx and add colorbar (if UnoCbar=false)
+
+	// 3D surfaces
+	SprojX  bool    // surface: also project filled contour onto the x pane
+	SprojY  bool    // surface: also project filled contour onto the y pane
+	SnoAa   bool    // surface: turn antialiasing off
+	SbyZ    bool    // surface: ribbons are colored by z-value instead of series index
+	Swire   bool    // surface: draw primitive shapes (Sphere, Cylinder, Cone) as wireframes
+	Smargin float64 // surface: distance between projected contours and data as a fraction of the range of data; 0 => 0.1
+
+	// colormaps
+	VminVmax []float64 // colormap: [vmin, vmax] limits of the mapped values
+
+	// log-safe plots
+	LogFloor float64 // log plots: non-positive values are replaced by this floor if > 0; otherwise they are dropped
+
+	// tables
+	TcolWidths  []float64  // table: widths of columns in axes coordinates
+	TcellColors [][]string // table: colors of cells
+
+	// voxels
+	VoxColors [][][]string // voxels: color of each voxel; same shape as filled
 }
 
 // String returns a string representation of arguments
@@ -97,15 +187,33 @@ func (o A) String(forHistogram bool) (l string) {
 	addToCmd(&l, o.Void, "markerfacecolor='none'")
 	addToCmd(&l, o.Void && o.Mec == "", io.Sf("markeredgecolor='%s'", o.C))
 	addToCmd(&l, o.NoClip, "clip_on=0")
+	addToCmd(&l, o.Alpha > 0 && o.Alpha <= 1, io.Sf("alpha=%g", o.Alpha))
+	addToCmd(&l, o.useDashes(), io.Sf("dashes=%s", floats2list(o.Dashes)))
+	addToCmd(&l, o.Rasterized, "rasterized=True")
 
 	// shapes
 	addToCmd(&l, o.Fc != "", io.Sf("facecolor='%s'", o.Fc))
 	addToCmd(&l, o.Ec != "", io.Sf("edgecolor='%s'", o.Ec))
+	addToCmd(&l, o.Hatch != "", io.Sf("hatch='%s'", o.Hatch))
 
 	// text and extra arguments
 	addToCmd(&l, o.Ha != "", io.Sf("ha='%s'", o.Ha))
 	addToCmd(&l, o.Va != "", io.Sf("va='%s'", o.Va))
 	addToCmd(&l, o.Fsz > 0, io.Sf("fontsize=%g", o.Fsz))
+	addToCmd(&l, o.Rot != 0, io.Sf("rotation=%g", o.Rot))
+	if o.TextBbox {
+		fc, ec, pad := "white", "black", 4.0
+		if o.TextBboxFc != "" {
+			fc = o.TextBboxFc
+		}
+		if o.TextBboxEc != "" {
+			ec = o.TextBboxEc
+		}
+		if o.TextBboxPad > 0 {
+			pad = o.TextBboxPad
+		}
+		addToCmd(&l, true, io.Sf("bbox=dict(facecolor='%s',edgecolor='%s',pad=%g)", fc, ec, pad))
+	}
 
 	// histograms
 	if forHistogram {
@@ -119,6 +227,11 @@ func (o A) String(forHistogram bool) (l string) {
 	return
 }
 
+// useDashes tells whether the custom dashes are used; i.e. Dashes is given and Ls is "" or "-"
+func (o A) useDashes() bool {
+	return len(o.Dashes) > 0 && (o.Ls == "" || o.Ls == "-")
+}
+
 // addToCmd adds new option to list of commands separated with commas
 func addToCmd(line *string, condition bool, delta string) {
 	if condition {
@@ -153,7 +266,7 @@ func floats2list(vals []float64) (l string) {
 		if i > 0 {
 			l += ","
 		}
-		l += io.Sf("%g", v)
+		l += num(v)
 	}
 	l += "]"
 	return
@@ -177,17 +290,40 @@ func getHideList(args *A) (l string) {
 	if args == nil {
 		return
 	}
-	if args.HideL || args.HideR || args.HideB || args.HideT {
+	hideL := args.HideL || (args.HideAll && !args.ShowL)
+	hideR := args.HideR || (args.HideAll && !args.ShowR)
+	hideB := args.HideB || (args.HideAll && !args.ShowB)
+	hideT := args.HideT || (args.HideAll && !args.ShowT)
+	if hideL || hideR || hideB || hideT {
 		c := ""
-		addToCmd(&c, args.HideL, "'left'")
-		addToCmd(&c, args.HideR, "'right'")
-		addToCmd(&c, args.HideB, "'bottom'")
-		addToCmd(&c, args.HideT, "'top'")
+		addToCmd(&c, hideL, "'left'")
+		addToCmd(&c, hideR, "'right'")
+		addToCmd(&c, hideB, "'bottom'")
+		addToCmd(&c, hideT, "'top'")
 		l = "[" + c + "]"
 	}
 	return
 }
 
+// getBorderStyles returns the Python commands setting the colors and linewidths of frame borders
+func getBorderStyles(args *A) (l string) {
+	if args == nil {
+		return
+	}
+	sides := []string{"left", "right", "bottom", "top"}
+	colors := []string{args.BorderCL, args.BorderCR, args.BorderCB, args.BorderCT}
+	lws := []float64{args.BorderLwL, args.BorderLwR, args.BorderLwB, args.BorderLwT}
+	for i, side := range sides {
+		if colors[i] != "" {
+			l += io.Sf("plt.gca().spines['%s'].set_color('%s')\n", side, colors[i])
+		}
+		if lws[i] > 0 {
+			l += io.Sf("plt.gca().spines['%s'].set_linewidth(%g)\n", side, lws[i])
+		}
+	}
+	return
+}
+
 // argsLeg returns legend arguments
 func argsLeg(args *A) (loc string, ncol int, hlen, fsz float64, frame int, out int, outX string) {
 	loc = "'best'"
@@ -248,8 +384,14 @@ func argsFsz(args *A) (txt, lbl, leg, xtck, ytck float64) {
 	return
 }
 
+// fontSizes returns the font sizes of args (see argsFsz) as an array
+func fontSizes(args *A) [5]float64 {
+	txt, lbl, leg, xtck, ytck := argsFsz(args)
+	return [5]float64{txt, lbl, leg, xtck, ytck}
+}
+
 // argsContour allocates args if nil, sets default parameters, and return formatted arguments
-func argsContour(in *A) (out *A, colors, levels string) {
+func argsContour(in *A, z [][]float64) (out *A, colors, levels string, err error) {
 	out = in
 	if out == nil {
 		out = new(A)
@@ -266,13 +408,123 @@ func argsContour(in *A) (out *A, colors, levels string) {
 	if out.Fsz < 0.01 {
 		out.Fsz = 10.0
 	}
+	if len(out.Ubounds) > 0 {
+		if len(out.Colors) != len(out.Ubounds)-1 {
+			return out, "", "", chk.Err("number of colors must be equal to the number of intervals between boundaries. %d != %d", len(out.Colors), len(out.Ubounds)-1)
+		}
+		sb := floats2list(out.Ubounds)
+		colors = io.Sf(",cmap=mcl.ListedColormap(%s),norm=mcl.BoundaryNorm(%s,%d)", strings2list(out.Colors), sb, len(out.Colors))
+		levels = io.Sf(",levels=%s", sb)
+		return
+	}
 	if len(out.Colors) > 0 {
 		colors = io.Sf(",colors=%s", strings2list(out.Colors))
 	} else {
 		colors = io.Sf(",cmap=getCmap(%d)", out.UcmapIdx)
+		var norm string
+		norm, err = argsNorm(out, z)
+		if err != nil {
+			return
+		}
+		colors += norm
 	}
 	if len(out.Ulevels) > 0 {
 		levels = io.Sf(",levels=%s", floats2list(out.Ulevels))
+	} else if out.UlevelsPercentile {
+		nlev := out.Unlevels
+		if nlev < 2 {
+			nlev = 10
+		}
+		l := LevelsFromPercentiles(z, utl.LinSpace(0, 100, nlev))
+		if len(l) > 0 {
+			u := l[:1] // levels must be increasing
+			for _, v := range l {
+				if v > u[len(u)-1] {
+					u = append(u, v)
+				}
+			}
+			levels = io.Sf(",levels=%s", floats2list(u))
+		}
+	} else if out.Unlevels > 0 {
+		levels = io.Sf(",levels=%d", out.Unlevels)
+	}
+	return
+}
+
+// argsCbar returns the keyword arguments of colorbars for the orientation, size, padding, ticks
+// and extensions given by args (UcbarOrient, UcbarShrink, ...). The ticks are args.UcbarTicks or,
+// if not given, the boundaries of classes args.Ubounds
+func argsCbar(args *A) (l string) {
+	if args == nil {
+		return
+	}
+	if args.UcbarOrient != "" {
+		l += io.Sf(", orientation='%s'", args.UcbarOrient)
+	}
+	if args.UcbarShrink > 0 {
+		l += io.Sf(", shrink=%g", args.UcbarShrink)
+	}
+	if args.UcbarAspect > 0 {
+		l += io.Sf(", aspect=%g", args.UcbarAspect)
+	}
+	if args.UcbarPad > 0 {
+		l += io.Sf(", pad=%g", args.UcbarPad)
+	}
+	if len(args.UcbarTicks) > 0 {
+		l += io.Sf(", ticks=%s", floats2list(args.UcbarTicks))
+	} else if len(args.Ubounds) > 0 {
+		l += io.Sf(", ticks=%s", floats2list(args.Ubounds))
+	}
+	if args.UcbarExtend != "" {
+		l += io.Sf(", extend='%s'", args.UcbarExtend)
+	}
+	return
+}
+
+// argsNorm returns the color normalisation argument (and the locator of levels) for logarithmic
+// or symmetric logarithmic scales; or "" if args.UnormLog and args.UnormSymLog are false
+func argsNorm(args *A, z [][]float64) (l string, err error) {
+	if !args.UnormLog && !args.UnormSymLog {
+		return
+	}
+	if args.UnormLog && args.UnormSymLog {
+		return "", chk.Err("UnormLog and UnormSymLog cannot be used together")
+	}
+	vmin, vmax := args.Uvmin, args.Uvmax
+	if vmin == vmax {
+		vmin, vmax = matMinMax(z)
+	}
+	if vmin >= vmax {
+		return "", chk.Err("limits of color normalisation are invalid: vmin=%g must be smaller than vmax=%g", vmin, vmax)
+	}
+	if args.UnormLog {
+		zmin, _ := matMinMax(z)
+		if zmin <= 0 || vmin <= 0 {
+			return "", chk.Err("logarithmic color normalisation requires positive values. min(z)=%g and vmin=%g are invalid", zmin, vmin)
+		}
+		l = io.Sf(",norm=mcl.LogNorm(vmin=%g,vmax=%g)", vmin, vmax)
+		if len(args.Ulevels) == 0 && !args.UlevelsPercentile {
+			l += ",locator=tck.LogLocator()"
+		}
+		return
+	}
+	linthresh := 0.01 * math.Max(math.Abs(vmin), math.Abs(vmax))
+	l = io.Sf(",norm=mcl.SymLogNorm(linthresh=%g,vmin=%g,vmax=%g,base=10)", linthresh, vmin, vmax)
+	return
+}
+
+// argsSurfCmap returns the colormap arguments for surfaces and wireframes; or "" if the colormap
+// is not requested, i.e. args.UcmapIdx == 0 and args.VminVmax is empty
+func argsSurfCmap(args *A) (l string) {
+	if args == nil {
+		return
+	}
+	if args.UcmapIdx == 0 && len(args.VminVmax) != 2 {
+		return
+	}
+	l = io.Sf(",cmap=getCmap(%d)", args.UcmapIdx)
+	if len(args.VminVmax) == 2 {
+		l += io.Sf(",vmin=%g,vmax=%g", args.VminVmax[0], args.VminVmax[1])
 	}
 	return
 }
@@ -0,0 +1,55 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/utl"
+)
+
+// matMinMax returns the minimum and maximum values in matrix
+func matMinMax(a [][]float64) (min, max float64) {
+	first := true
+	for i := 0; i < len(a); i++ {
+		for j := 0; j < len(a[i]); j++ {
+			if first {
+				min, max = a[i][j], a[i][j]
+				first = false
+				continue
+			}
+			if a[i][j] < min {
+				min = a[i][j]
+			}
+			if a[i][j] > max {
+				max = a[i][j]
+			}
+		}
+	}
+	return
+}
+
+// LevelsFromPercentiles computes contour levels at the given percentiles (0 to 100) of the values
+// in z; e.g. to reveal the structure of fields with a few extreme values. NaN entries are ignored
+func LevelsFromPercentiles(z [][]float64, percents []float64) (levels []float64) {
+	var vals []float64
+	for i := 0; i < len(z); i++ {
+		for j := 0; j < len(z[i]); j++ {
+			if !math.IsNaN(z[i][j]) {
+				vals = append(vals, z[i][j])
+			}
+		}
+	}
+	if len(vals) == 0 {
+		return
+	}
+	sort.Float64s(vals)
+	levels = make([]float64, len(percents))
+	for k, p := range percents {
+		levels[k] = utl.DblQuantile(vals, p/100.0)
+	}
+	return
+}
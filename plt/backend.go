@@ -0,0 +1,193 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// pythonCmd is the Python executable
+var pythonCmd = "python"
+
+// pythonEnv holds extra environment variables for Python; e.g. "PYTHONPATH=/my/lib"
+var pythonEnv []string
+
+// backendInfo holds the cached results of CheckBackend
+var backendInfo *BackendInfo
+
+// backendMu guards pythonCmd, pythonEnv and backendInfo, which are shared by all Plotters
+var backendMu sync.Mutex
+
+// SetPythonCmd sets the Python executable; e.g. "python3" or the path to the Python of a virtual
+// environment. The default is "python". The results of CheckBackend are discarded
+func SetPythonCmd(path string) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	pythonCmd = path
+	backendInfo = nil
+}
+
+// SetPythonEnv sets extra environment variables for Python; e.g. []string{"MPLBACKEND=Agg"}.
+// They are added to (or replace) the environment of the current process. The results of
+// CheckBackend are discarded
+func SetPythonEnv(env []string) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	pythonEnv = env
+	backendInfo = nil
+}
+
+// SetTempDir sets the directory where the temporary Python scripts (and data sent back by Python)
+// are written. Each script is written to a file with a unique name. "" => os.TempDir()
+func (o *Plotter) SetTempDir(dir string) {
+	o.tempDir = dir
+}
+
+// SetKeepTempFiles sets whether the temporary Python scripts are kept after running Python; e.g.
+// to debug them. By default, they are removed
+func (o *Plotter) SetKeepTempFiles(keep bool) {
+	o.keepTempFiles = keep
+}
+
+// newTempFile creates an empty temporary file with a unique name (see ioutil.TempFile)
+func (o *Plotter) newTempFile(pattern string) (fn string, err error) {
+	f, err := ioutil.TempFile(o.tempDir, pattern)
+	if err != nil {
+		return "", chk.Err("cannot create temporary file:\n%v", err)
+	}
+	fn = f.Name()
+	f.Close()
+	return
+}
+
+// removeTempFile removes temporary file, unless SetKeepTempFiles(true) was called
+func (o *Plotter) removeTempFile(fn string) {
+	if !o.keepTempFiles {
+		os.Remove(fn)
+	}
+}
+
+// pythonEnviron returns the environment for Python
+func pythonEnviron() []string {
+	return append(os.Environ(), pythonEnv...)
+}
+
+// pythonSettings returns the Python executable, its environment and the cached results of
+// CheckBackend (nil if not called yet)
+func pythonSettings() (cmd string, env []string, info *BackendInfo) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	return pythonCmd, pythonEnviron(), backendInfo
+}
+
+// pythonGetenv returns the value of an environment variable for Python
+func pythonGetenv(key string) string {
+	for i := len(pythonEnv) - 1; i >= 0; i-- {
+		if strings.HasPrefix(pythonEnv[i], key+"=") {
+			return pythonEnv[i][len(key)+1:]
+		}
+	}
+	return os.Getenv(key)
+}
+
+// BackendInfo holds information about the Python installation used for plotting
+type BackendInfo struct {
+	Python     string // path to Python executable
+	PyVersion  string // Python version
+	Numpy      string // NumPy version; "" => not found
+	Matplotlib string // matplotlib version; "" => not found
+	Backend    string // backend selected by matplotlib
+	UseAgg     bool   // no display was detected, thus matplotlib.use('Agg') is added to scripts
+}
+
+// diagnostic script for CheckBackend
+const pythonDiagnostic = `import sys
+print('python=' + sys.version.split()[0])
+try:
+    import numpy
+    print('numpy=' + numpy.__version__)
+except ImportError:
+    print('numpy=')
+try:
+    import matplotlib
+    print('matplotlib=' + matplotlib.__version__)
+    print('backend=' + matplotlib.get_backend())
+except ImportError:
+    print('matplotlib=')
+`
+
+// CheckBackend runs a small diagnostic script to find the versions of Python, NumPy and
+// matplotlib, and the selected backend. The results are cached. If no display is detected,
+// matplotlib.use('Agg') is added to the generated scripts, unless the MPLBACKEND environment
+// variable is set. Save calls this function before calling Python
+func CheckBackend() (info BackendInfo, err error) {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	if backendInfo != nil {
+		return *backendInfo, nil
+	}
+	path, err := exec.LookPath(pythonCmd)
+	if err != nil {
+		return info, chk.Err("cannot find Python executable %q; install Python or make sure it is in the PATH:\n%v", pythonCmd, err)
+	}
+	cmd := exec.Command(path, "-c", pythonDiagnostic)
+	cmd.Env = pythonEnviron()
+	var out, serr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &serr
+	err = cmd.Run()
+	if err != nil {
+		return info, chk.Err("cannot run diagnostic script with %s:\n%v\n%v", path, err, serr.String())
+	}
+	info = parseBackendInfo(out.String())
+	info.Python = path
+	if info.Numpy == "" {
+		return info, chk.Err("numpy not found for %s; install with:\n    %s -m pip install numpy", path, path)
+	}
+	if info.Matplotlib == "" {
+		return info, chk.Err("matplotlib not found for %s; install with:\n    %s -m pip install matplotlib", path, path)
+	}
+	info.UseAgg = pythonGetenv("MPLBACKEND") == "" && !hasDisplay()
+	backendInfo = &info
+	return
+}
+
+// parseBackendInfo parses the output of the diagnostic script
+func parseBackendInfo(out string) (info BackendInfo) {
+	for _, line := range strings.Split(out, "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "python":
+			info.PyVersion = kv[1]
+		case "numpy":
+			info.Numpy = kv[1]
+		case "matplotlib":
+			info.Matplotlib = kv[1]
+		case "backend":
+			info.Backend = kv[1]
+		}
+	}
+	return
+}
+
+// hasDisplay returns whether a display is (probably) available
+func hasDisplay() bool {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		return true
+	}
+	return pythonGetenv("DISPLAY") != "" || pythonGetenv("WAYLAND_DISPLAY") != ""
+}
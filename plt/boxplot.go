@@ -0,0 +1,59 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// BoxStats holds the statistics of one box of a boxplot
+type BoxStats struct {
+	Med    float64   // median
+	Q1     float64   // first quartile
+	Q3     float64   // third quartile
+	WhisLo float64   // end of lower whisker
+	WhisHi float64   // end of upper whisker
+	Fliers []float64 // outliers [optional]
+}
+
+// BoxplotStats draws a boxplot from precomputed statistics; e.g. to avoid passing large samples
+// to Python. The boxes are filled with args.Fc if given
+func (o *Plotter) BoxplotStats(stats []BoxStats, labels []string, args *A) (err error) {
+	if len(stats) < 1 {
+		return chk.Err("at least one box must be given")
+	}
+	if labels != nil && len(labels) != len(stats) {
+		return chk.Err("number of labels must be equal to the number of boxes. %d != %d", len(labels), len(stats))
+	}
+	for i, s := range stats {
+		if !(s.WhisLo <= s.Q1 && s.Q1 <= s.Med && s.Med <= s.Q3 && s.Q3 <= s.WhisHi) {
+			return chk.Err("statistics of box %d are inconsistent; whislo <= q1 <= med <= q3 <= whishi is required: %+v", i, s)
+		}
+	}
+	n := o.bufferPy.Len()
+	genBoxStats(o.pyBuf(), io.Sf("st%d", n), stats, labels)
+	io.Ff(o.pyBuf(), "plt.gca().bxp(st%d", n)
+	if args != nil && args.Fc != "" {
+		io.Ff(o.pyBuf(), ",patch_artist=True,boxprops={'facecolor':'%s'}", args.Fc)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	return
+}
+
+// genBoxStats generates list of dictionaries with the statistics of boxes
+func genBoxStats(buf *bytes.Buffer, name string, stats []BoxStats, labels []string) {
+	io.Ff(buf, "%s=[", name)
+	for i, s := range stats {
+		io.Ff(buf, "{'med':%g,'q1':%g,'q3':%g,'whislo':%g,'whishi':%g,'fliers':%s", s.Med, s.Q1, s.Q3, s.WhisLo, s.WhisHi, floats2list(s.Fliers))
+		if labels != nil {
+			io.Ff(buf, ",'label':'%s'", labels[i])
+		}
+		io.Ff(buf, "},")
+	}
+	io.Ff(buf, "]\n")
+}
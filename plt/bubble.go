@@ -0,0 +1,90 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Bubble draws a scatter plot with a marker size per point; sizes are areas in points², as in
+// matplotlib. The colors of points are given by args.Colors (one per point) or by args.C.
+// Call BubbleLegend to explain the sizes
+func (o *Plotter) Bubble(x, y, sizes []float64, args *A) (err error) {
+	if len(y) != len(x) || len(sizes) != len(x) {
+		return chk.Err("the lengths of x, y and sizes must be the same. %d, %d, %d", len(x), len(y), len(sizes))
+	}
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	if len(a.Colors) > 0 && len(a.Colors) != len(x) {
+		return chk.Err("the number of colors must be equal to the number of points. %d != %d", len(a.Colors), len(x))
+	}
+	n := o.bufferPy.Len()
+	sx, sy, ss := io.Sf("x%d", n), io.Sf("y%d", n), io.Sf("s%d", n)
+	gen2Arrays(o.pyBuf(), sx, sy, x, y)
+	genArray(o.pyBuf(), ss, sizes)
+	io.Ff(o.pyBuf(), "plt.scatter(%s,%s,s=%s", sx, sy, ss)
+	if len(a.Colors) > 0 {
+		io.Ff(o.pyBuf(), ",c=%s", strings2list(a.Colors))
+	} else if a.C != "" {
+		io.Ff(o.pyBuf(), ",c='%s'", a.C)
+	}
+	if a.Mec != "" {
+		io.Ff(o.pyBuf(), ",edgecolors='%s'", a.Mec)
+	}
+	a.C, a.Ls, a.Ms, a.Me, a.Mec, a.Mew, a.Void, a.Dashes = "", "", 0, 0, "", 0, false, nil // not applicable to scatter
+	updateBufferAndClose(o.pyBuf(), a, false)
+	return
+}
+
+// BubbleLegend draws a legend with three reference bubbles corresponding to the minimum, middle
+// and maximum of sizes. The legend is added to the axes; thus it does not replace other legends
+//  numFmt -- format of labels; e.g. "%.1f"; "" => "%g"
+//  args   -- color (C; default "gray"), transparency (Alpha), location (LegLoc) and font size (FszLeg)
+func (o *Plotter) BubbleLegend(sizes []float64, numFmt string, args *A) {
+	if len(sizes) < 1 {
+		return
+	}
+	smin, smax := sizes[0], sizes[0]
+	for _, s := range sizes {
+		smin, smax = math.Min(smin, s), math.Max(smax, s)
+	}
+	a := &A{C: "gray"}
+	if args != nil {
+		*a = *args
+		if a.C == "" {
+			a.C = "gray"
+		}
+	}
+	if numFmt == "" {
+		numFmt = "%g"
+	}
+	fs, loc := 9.0, "best"
+	if a.FszLeg > 0 {
+		fs = a.FszLeg
+	}
+	if a.LegLoc != "" {
+		loc = a.LegLoc
+	}
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "handles%d = [", n)
+	for i, s := range []float64{smin, (smin + smax) / 2.0, smax} {
+		if i > 0 {
+			io.Ff(o.pyBuf(), ",\n")
+		}
+		io.Ff(o.pyBuf(), "lns.Line2D([], [], ls='none', marker='o', ms=%g, color='%s'", math.Sqrt(s), a.C)
+		if a.Alpha > 0 {
+			io.Ff(o.pyBuf(), ", alpha=%g", a.Alpha)
+		}
+		io.Ff(o.pyBuf(), ", label='%s')", io.Sf(numFmt, s))
+	}
+	io.Ff(o.pyBuf(), "]\nl%d=plt.legend(handles=handles%d, fontsize=%g, loc='%s', labelspacing=1.5, borderpad=1)\n", n, n, fs, loc)
+	io.Ff(o.pyBuf(), "plt.gca().add_artist(l%d)\n", n)
+	io.Ff(o.pyBuf(), "addToEA(l%d)\n", n)
+}
@@ -0,0 +1,20 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import "github.com/cpmech/gosl/chk"
+
+// CompareImages compares two PNG figures pixel by pixel and returns the fraction of differing
+// pixels. Channels differing by less than tol (normalised to [0,1]) are ignored. Figures with
+// different dimensions give an error. See chk.Image to use these comparisons in tests
+func CompareImages(fnameA, fnameB string, tol float64) (diffFrac float64, err error) {
+	return chk.ImageDiff(fnameA, fnameB, tol, "")
+}
+
+// CompareImagesD compares two PNG figures (see CompareImages) and writes an image highlighting
+// the differing pixels to fnameDiff
+func CompareImagesD(fnameA, fnameB, fnameDiff string, tol float64) (diffFrac float64, err error) {
+	return chk.ImageDiff(fnameA, fnameB, tol, fnameDiff)
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import "math"
+
+// decimateMinMax selects at most maxPts points of a series to be plotted without losing its peaks:
+// the first and last points are kept and the other ones are grouped into buckets where only the
+// points with the minimum and maximum values are kept. If x is monotone, the buckets have the
+// same width along x; otherwise (or if x is nil), they have the same number of points. Points
+// with NaN values (gaps in lines) are kept as well; they split their buckets; thus, each NaN may
+// add up to three points to the maxPts points
+//  Input:
+//   x      -- abscissae; may be nil. len(x) != len(v) => all points are kept
+//   v      -- values; e.g. the ordinates
+//   maxPts -- maximum number of points (at least 4)
+//  Output:
+//   idx -- sorted indices of the selected points; nil => all points must be kept
+func decimateMinMax(x, v []float64, maxPts int) (idx []int) {
+
+	// check
+	n := len(v)
+	if maxPts < 4 {
+		maxPts = 4
+	}
+	if n <= maxPts {
+		return nil
+	}
+	if x != nil && len(x) != n {
+		return nil
+	}
+
+	// buckets
+	nb := (maxPts - 2) / 2
+	x0, xrange := 0.0, 0.0
+	if x != nil && isMonotone(x) {
+		x0, xrange = x[0], x[n-1]-x[0]
+	}
+	bucket := func(i int) int {
+		if xrange != 0 {
+			b := int(float64(nb) * (x[i] - x0) / xrange)
+			if b < 0 {
+				return 0
+			}
+			if b > nb-1 {
+				return nb - 1
+			}
+			return b
+		}
+		return (i - 1) * nb / (n - 2)
+	}
+
+	// select points
+	idx = append(idx, 0)
+	imin, imax, cur := -1, -1, -1
+	flush := func() {
+		switch {
+		case imin < 0:
+		case imin == imax:
+			idx = append(idx, imin)
+		case imin < imax:
+			idx = append(idx, imin, imax)
+		default:
+			idx = append(idx, imax, imin)
+		}
+		imin, imax = -1, -1
+	}
+	for i := 1; i < n-1; i++ {
+		if math.IsNaN(v[i]) {
+			flush()
+			idx = append(idx, i)
+			continue
+		}
+		if b := bucket(i); b != cur {
+			flush()
+			cur = b
+		}
+		if imin < 0 || v[i] < v[imin] {
+			imin = i
+		}
+		if imax < 0 || v[i] > v[imax] {
+			imax = i
+		}
+	}
+	flush()
+	idx = append(idx, n-1)
+	return
+}
+
+// isMonotone returns whether x is non-decreasing or non-increasing
+func isMonotone(x []float64) bool {
+	inc, dec := true, true
+	for i := 1; i < len(x); i++ {
+		if x[i] < x[i-1] {
+			inc = false
+		}
+		if x[i] > x[i-1] {
+			dec = false
+		}
+	}
+	return inc || dec
+}
+
+// pickPoints returns the values at the indices idx; idx == nil => u
+func pickPoints(u []float64, idx []int) []float64 {
+	if idx == nil {
+		return u
+	}
+	res := make([]float64, len(idx))
+	for k, i := range idx {
+		res[k] = u[i]
+	}
+	return res
+}
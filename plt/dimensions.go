@@ -0,0 +1,114 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/io"
+)
+
+// AngleDim draws an angular dimension; i.e. an arc of radius r between the directions alphaDeg
+// and betaDeg (in degrees; anti-clockwise), arrowheads at both ends and the label at mid-angle
+//  args -- color (Ec; default "k"), line width (Lw), arrow scale (Scale; default 10) and font size (Fsz)
+func (o *Plotter) AngleDim(xc, yc, r, alphaDeg, betaDeg float64, label string, args *A) {
+	a := &A{Ec: "k"}
+	if args != nil {
+		*a = *args
+		if a.Ec == "" {
+			a.Ec = "k"
+		}
+	}
+	if a.Scale <= 0 {
+		a.Scale = 10
+	}
+	ends, dirs, lbl := angleDimGeometry(xc, yc, r, alphaDeg, betaDeg)
+	o.Arc(xc, yc, r, alphaDeg*math.Pi/180.0, betaDeg*math.Pi/180.0, &A{Ec: a.Ec, Fc: "none", Lw: a.Lw, Z: a.Z})
+	l := 0.01 * r // length of tails of arrows
+	for k := 0; k < 2; k++ {
+		p, d := ends[k], dirs[k]
+		o.Arrow(p[0]-l*d[0], p[1]-l*d[1], p[0], p[1], &A{Style: "-|>", Scale: a.Scale, Fc: a.Ec, Ec: a.Ec, Z: a.Z})
+	}
+	if label != "" {
+		o.Text(lbl[0], lbl[1], label, &A{C: a.Ec, Ha: "center", Va: "center", Fsz: a.Fsz})
+	}
+}
+
+// angleDimGeometry computes the geometry of angular dimensions: the ends of the arc, the unit
+// directions of arrows at the ends (tangent to the arc and pointing outwards), and the position
+// of the label on the bisector at 1.2 r from the centre
+func angleDimGeometry(xc, yc, r, alphaDeg, betaDeg float64) (ends, dirs [][]float64, lbl []float64) {
+	α := alphaDeg * math.Pi / 180.0
+	β := betaDeg * math.Pi / 180.0
+	γ := (α + β) / 2.0
+	ends = [][]float64{
+		{xc + r*math.Cos(α), yc + r*math.Sin(α)},
+		{xc + r*math.Cos(β), yc + r*math.Sin(β)},
+	}
+	dirs = [][]float64{
+		{math.Sin(α), -math.Cos(α)}, // clockwise tangent at the start
+		{-math.Sin(β), math.Cos(β)}, // anti-clockwise tangent at the end
+	}
+	lbl = []float64{xc + 1.2*r*math.Cos(γ), yc + 1.2*r*math.Sin(γ)}
+	return
+}
+
+// LinearDim draws a linear dimension of the segment (x1,y1)-(x2,y2), like in CAD drawings; i.e.
+// extension lines perpendicular to the segment, a dimension line with arrowheads at both ends
+// displaced by offset, and the label at the centre of the dimension line. offset > 0 places the
+// dimension on the left-hand side of the segment (looking from 1 to 2)
+//  label -- text; "" => the distance formatted with args.UnumFmt (default "%g")
+//  args  -- color (Ec; default "k"), line width (Lw) and font size (Fsz)
+func (o *Plotter) LinearDim(x1, y1, x2, y2, offset float64, label string, args *A) {
+	a := &A{Ec: "k"}
+	if args != nil {
+		*a = *args
+		if a.Ec == "" {
+			a.Ec = "k"
+		}
+	}
+	lw := a.Lw
+	if lw <= 0 {
+		lw = 1
+	}
+	ext1, ext2, dim, mid, angle := linearDimGeometry(x1, y1, x2, y2, offset)
+	if label == "" {
+		fmt := a.UnumFmt
+		if fmt == "" {
+			fmt = "%g"
+		}
+		label = io.Sf(fmt, math.Hypot(x2-x1, y2-y1))
+	}
+	for _, e := range [][][]float64{ext1, ext2} {
+		io.Ff(o.pyBuf(), "plt.plot([%g,%g],[%g,%g],color='%s',lw=%g)\n", e[0][0], e[1][0], e[0][1], e[1][1], a.Ec, lw)
+	}
+	io.Ff(o.pyBuf(), "plt.annotate('',xy=(%g,%g),xytext=(%g,%g),arrowprops=dict(arrowstyle='<->',color='%s',lw=%g,shrinkA=0,shrinkB=0))\n", dim[1][0], dim[1][1], dim[0][0], dim[0][1], a.Ec, lw)
+	io.Ff(o.pyBuf(), "plt.text(%g,%g,%q,ha='center',va='center',rotation=%g,rotation_mode='anchor',color='%s',bbox=dict(fc='white',ec='none',pad=1)", mid[0], mid[1], label, angle, a.Ec)
+	if a.Fsz > 0 {
+		io.Ff(o.pyBuf(), ",fontsize=%g", a.Fsz)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+}
+
+// linearDimGeometry computes the geometry of linear dimensions: the extension lines (from a gap
+// of 0.1|offset| near the segment to 0.1|offset| beyond the dimension line), the dimension line,
+// its mid point, and the angle of the text in degrees, within (-90, 90]
+func linearDimGeometry(x1, y1, x2, y2, offset float64) (ext1, ext2, dim [][]float64, mid []float64, angleDeg float64) {
+	l := math.Hypot(x2-x1, y2-y1)
+	tx, ty := (x2-x1)/l, (y2-y1)/l
+	nx, ny := -ty, tx // left-hand normal
+	gap := 0.1 * offset
+	ext1 = [][]float64{{x1 + gap*nx, y1 + gap*ny}, {x1 + (offset+gap)*nx, y1 + (offset+gap)*ny}}
+	ext2 = [][]float64{{x2 + gap*nx, y2 + gap*ny}, {x2 + (offset+gap)*nx, y2 + (offset+gap)*ny}}
+	dim = [][]float64{{x1 + offset*nx, y1 + offset*ny}, {x2 + offset*nx, y2 + offset*ny}}
+	mid = []float64{(dim[0][0] + dim[1][0]) / 2.0, (dim[0][1] + dim[1][1]) / 2.0}
+	angleDeg = math.Atan2(ty, tx) * 180.0 / math.Pi
+	if angleDeg > 90 {
+		angleDeg -= 180
+	} else if angleDeg <= -90 {
+		angleDeg += 180
+	}
+	return
+}
@@ -7,11 +7,12 @@ package plt
 import (
 	"math"
 
+	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
 )
 
 // AutoScale rescales plot area
-func AutoScale(P [][]float64) {
+func (o *Plotter) AutoScale(P [][]float64) {
 	if len(P) < 1 {
 		return
 	}
@@ -31,7 +32,7 @@ func AutoScale(P [][]float64) {
 			ymax = p[1]
 		}
 	}
-	io.Ff(&bufferPy, "plt.axis([%g, %g, %g, %g])\n", xmin, xmax, ymin, ymax)
+	io.Ff(o.pyBuf(), "plt.axis([%g, %g, %g, %g])\n", xmin, xmax, ymin, ymax)
 }
 
 // Arrow adds arrow to plot
@@ -50,7 +51,7 @@ func AutoScale(P [][]float64) {
 //     Simple          simple   head_length=0.5,head_width=0.5,tail_width=0.2
 //     Wedge           wedge    tail_width=0.3,shrink_factor=0.5
 //     BarAB           |-|      widthA=1.0,angleA=None,widthB=1.0,angleB=None
-func Arrow(xi, yi, xf, yf float64, args *A) {
+func (o *Plotter) Arrow(xi, yi, xf, yf float64, args *A) {
 	style := "simple"
 	scale := 20.0
 	if args.Style != "" {
@@ -59,78 +60,298 @@ func Arrow(xi, yi, xf, yf float64, args *A) {
 	if args.Scale > 0 {
 		scale = args.Scale
 	}
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "pc%d = pat.FancyArrowPatch((%g,%g),(%g,%g),shrinkA=0,shrinkB=0,path_effects=[pff.Stroke(joinstyle='miter')],arrowstyle='%s',mutation_scale=%g", n, xi, yi, xf, yf, style, scale)
-	updateBufferAndClose(&bufferPy, args, false)
-	io.Ff(&bufferPy, "plt.gca().add_patch(pc%d)\n", n)
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "pc%d = pat.FancyArrowPatch((%s,%s),(%s,%s),shrinkA=0,shrinkB=0,path_effects=[pff.Stroke(joinstyle='miter')],arrowstyle='%s',mutation_scale=%g", n, num(xi), num(yi), num(xf), num(yf), style, scale)
+	o.addPatch(n, args)
 }
 
 // Circle adds circle to plot
-func Circle(xc, yc, r float64, args *A) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "pc%d = pat.Circle((%g,%g), %g", n, xc, yc, r)
-	updateBufferAndClose(&bufferPy, args, false)
-	io.Ff(&bufferPy, "plt.gca().add_patch(pc%d)\n", n)
+func (o *Plotter) Circle(xc, yc, r float64, args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "pc%d = pat.Circle((%s,%s), %s", n, num(xc), num(yc), num(r))
+	o.addPatch(n, args)
+}
+
+// Ellipse adds ellipse to plot
+//  rx and ry are the semi-axes; angleDeg is the rotation in degrees (anti-clockwise)
+func (o *Plotter) Ellipse(xc, yc, rx, ry, angleDeg float64, args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "pc%d = pat.Ellipse((%s,%s), %s, %s, angle=%g", n, num(xc), num(yc), num(2.0*rx), num(2.0*ry), angleDeg)
+	o.addPatch(n, args)
 }
 
 // Arc adds arc to plot
 //  minAlpha and maxAlpha are in degrees
-func Arc(xc, yc, r, minAlpha, maxAlpha float64, args *A) {
-	n := bufferPy.Len()
+func (o *Plotter) Arc(xc, yc, r, minAlpha, maxAlpha float64, args *A) {
+	n := o.bufferPy.Len()
 	r2 := 2.0 * r
 	θ1 := minAlpha * 180.0 / math.Pi
 	θ2 := maxAlpha * 180.0 / math.Pi
-	io.Ff(&bufferPy, "pc%d = pat.Arc((%g,%g),%g,%g,angle=0,theta1=%g,theta2=%g", n, xc, yc, r2, r2, θ1, θ2)
-	updateBufferAndClose(&bufferPy, args, false)
-	io.Ff(&bufferPy, "plt.gca().add_patch(pc%d)\n", n)
+	io.Ff(o.pyBuf(), "pc%d = pat.Arc((%g,%g),%g,%g,angle=0,theta1=%g,theta2=%g", n, xc, yc, r2, r2, θ1, θ2)
+	o.addPatch(n, args)
+}
+
+// RoundedRect adds rectangle with rounded corners to plot; e.g. for callout boxes. The corners
+// are rounded with radius pad, which also enlarges the rectangle on all sides
+func (o *Plotter) RoundedRect(xmin, ymin, w, h, pad float64, args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "pc%d = pat.FancyBboxPatch((%g,%g), %g, %g, boxstyle='round,pad=%g'", n, xmin, ymin, w, h, pad)
+	o.addPatch(n, args)
+}
+
+// Wedge adds wedge (circular sector) to plot. The sector is drawn anti-clockwise from theta1 to
+// theta2 (in degrees); angles are normalised such that 0 <= theta1 < 360 and theta1 < theta2 <=
+// theta1 + 360. An annular sector is drawn if args.Rin > 0. It returns the corners {xmin, ymin}
+// and {xmax, ymax} of the bounding box; e.g. to be included in the points given to AutoScale
+func (o *Plotter) Wedge(xc, yc, r, theta1, theta2 float64, args *A) (bbox [][]float64) {
+	span := math.Mod(theta2-theta1, 360)
+	if span < 0 {
+		span += 360
+	}
+	if span == 0 && theta2 != theta1 {
+		span = 360
+	}
+	θ1 := math.Mod(theta1, 360)
+	if θ1 < 0 {
+		θ1 += 360
+	}
+	θ2 := θ1 + span
+	rin := 0.0
+	if args != nil && args.Rin > 0 {
+		rin = args.Rin
+	}
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "pc%d = pat.Wedge((%g,%g), %g, %g, %g", n, xc, yc, r, θ1, θ2)
+	if rin > 0 {
+		io.Ff(o.pyBuf(), ", width=%g", r-rin)
+	}
+	o.addPatch(n, args)
+
+	// bounding box
+	var P [][]float64
+	add := func(ρ, θ float64) {
+		P = append(P, []float64{xc + ρ*math.Cos(θ*math.Pi/180.0), yc + ρ*math.Sin(θ*math.Pi/180.0)})
+	}
+	add(r, θ1)
+	add(r, θ2)
+	add(rin, θ1)
+	add(rin, θ2)
+	for θ := 90.0 * math.Ceil(θ1/90.0); θ <= θ2; θ += 90 {
+		add(r, θ)
+	}
+	bbox = [][]float64{{P[0][0], P[0][1]}, {P[0][0], P[0][1]}}
+	for _, p := range P {
+		bbox[0][0], bbox[0][1] = math.Min(bbox[0][0], p[0]), math.Min(bbox[0][1], p[1])
+		bbox[1][0], bbox[1][1] = math.Max(bbox[1][0], p[0]), math.Max(bbox[1][1], p[1])
+	}
+	return
 }
 
 // Polyline draws a polyline
-func Polyline(P [][]float64, args *A) {
+func (o *Plotter) Polyline(P [][]float64, args *A) {
 	if len(P) < 1 {
 		return
 	}
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "dat%d = [[pth.Path.MOVETO, [%g, %g]]", n, P[0][0], P[0][1])
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "dat%d = [[pth.Path.MOVETO, [%g, %g]]", n, P[0][0], P[0][1])
 	for _, p := range P {
-		io.Ff(&bufferPy, ", [pth.Path.LINETO, [%g, %g]]", p[0], p[1])
+		io.Ff(o.pyBuf(), ", [pth.Path.LINETO, [%g, %g]]", p[0], p[1])
 	}
 	closed := true
 	if args != nil {
 		closed = args.Closed
 	}
 	if closed {
-		io.Ff(&bufferPy, ", [pth.Path.CLOSEPOLY, [0, 0]]")
-	}
-	io.Ff(&bufferPy, "]\n")
-	io.Ff(&bufferPy, "commands%d, vertices%d = zip(*dat%d)\n", n, n, n)
-	io.Ff(&bufferPy, "ph%d = pth.Path(vertices%d, commands%d)\n", n, n, n)
-	io.Ff(&bufferPy, "pc%d = pat.PathPatch(ph%d", n, n)
-	updateBufferAndClose(&bufferPy, args, false)
-	io.Ff(&bufferPy, "plt.gca().add_patch(pc%d)\n", n)
+		io.Ff(o.pyBuf(), ", [pth.Path.CLOSEPOLY, [0, 0]]")
+	}
+	io.Ff(o.pyBuf(), "]\n")
+	io.Ff(o.pyBuf(), "commands%d, vertices%d = zip(*dat%d)\n", n, n, n)
+	io.Ff(o.pyBuf(), "ph%d = pth.Path(vertices%d, commands%d)\n", n, n, n)
+	io.Ff(o.pyBuf(), "pc%d = pat.PathPatch(ph%d", n, n)
+	o.addPatch(n, args)
+}
+
+// SlopeIndicator draws a right triangle indicating the slope of lines in log-log plots; e.g. the
+// rate of convergence. The horizontal leg starts at (x0,y0) and spans width decades; the
+// vertical leg spans width*slope decades. The right angle is at the right corner or, if flip
+// is true, at the left corner. The legs are labelled "1" and the slope, with font size args.Fsz.
+// It returns the vertices of the triangle; the right angle is at P[1]
+func (o *Plotter) SlopeIndicator(x0, y0, width, slope float64, flip bool, args *A) (P [][]float64) {
+	a := &A{Ec: "k", Fc: "none"}
+	if args != nil {
+		*a = *args
+		if a.Ec == "" {
+			a.Ec = "k"
+		}
+		if a.Fc == "" {
+			a.Fc = "none"
+		}
+	}
+	a.Closed = true
+	P = slopeTriangle(x0, y0, width, slope, flip)
+	o.Polyline(P, a)
+	pad := math.Pow(10, 0.05*width)
+	yh, yo := P[1][1], P[2][1] // horizontal leg and vertex off it
+	if flip {
+		yo = P[0][1]
+	}
+	ylbl, va := yh/pad, "top"
+	if yo < yh {
+		ylbl, va = yh*pad, "bottom"
+	}
+	o.Text(math.Sqrt(P[0][0]*P[2][0]), ylbl, "1", &A{C: a.Ec, Ha: "center", Va: va, Fsz: a.Fsz})
+	xlbl, ha := P[1][0]*pad, "left"
+	if flip {
+		xlbl, ha = P[1][0]/pad, "right"
+	}
+	o.Text(xlbl, math.Sqrt(P[0][1]*P[2][1]), io.Sf("%g", slope), &A{C: a.Ec, Ha: ha, Va: "center", Fsz: a.Fsz})
+	return
 }
 
-// LegendX draws legend with given lines data. fs == fontsize
-func LegendX(dat []*A, args *A) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "handles%d = [", n)
+// SlopeIndicatorLast draws a slope indicator (see SlopeIndicator) below the last segment of the
+// x-y curve, with the hypotenuse parallel to it
+func (o *Plotter) SlopeIndicatorLast(x, y []float64, width, slope float64, args *A) (P [][]float64, err error) {
+	n := len(x)
+	if n < 2 || len(y) != n {
+		return nil, chk.Err("curve must have at least 2 points and len(x) == len(y). %d, %d is invalid", len(x), len(y))
+	}
+	if x[n-2] <= 0 || x[n-1] <= 0 || y[n-2] <= 0 || y[n-1] <= 0 {
+		return nil, chk.Err("the last two points of the curve must have positive coordinates")
+	}
+	gap := width / 4.0 // decades below the curve
+	lxm := (math.Log10(x[n-2]) + math.Log10(x[n-1])) / 2.0
+	lym := (math.Log10(y[n-2]) + math.Log10(y[n-1])) / 2.0
+	x0 := math.Pow(10, lxm-width/2.0)
+	y0 := math.Pow(10, lym-gap-width*slope/2.0)
+	P = o.SlopeIndicator(x0, y0, width, slope, slope < 0, args)
+	return
+}
+
+// slopeTriangle computes the vertices of slope indicators
+func slopeTriangle(x0, y0, width, slope float64, flip bool) (P [][]float64) {
+	x1 := x0 * math.Pow(10, width)
+	y1 := y0 * math.Pow(10, width*slope)
+	if flip {
+		return [][]float64{{x0, y0}, {x0, y1}, {x1, y1}}
+	}
+	return [][]float64{{x0, y0}, {x1, y0}, {x1, y1}}
+}
+
+// BezierCurve draws a quadratic or cubic Bezier curve, or a sequence of them, defined by the
+// control points. The curve is made of cubic segments if len(P) == 1 + 3k or quadratic segments
+// if len(P) == 1 + 2k (3 => quadratic; 4 => cubic). The curve is not filled unless args.Fc is
+// given. An arrow is drawn along the curve if args.Style is given (see Arrow)
+func (o *Plotter) BezierCurve(P [][]float64, args *A) (err error) {
+	np := len(P)
+	code := "CURVE4"
+	switch {
+	case np > 3 && (np-1)%3 == 0:
+	case np > 2 && (np-1)%2 == 0:
+		code = "CURVE3"
+	default:
+		return chk.Err("number of control points must be 1+2k (quadratic) or 1+3k (cubic). %d is invalid", np)
+	}
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "dat%d = [[pth.Path.MOVETO, [%g, %g]]", n, P[0][0], P[0][1])
+	for _, p := range P[1:] {
+		io.Ff(o.pyBuf(), ", [pth.Path.%s, [%g, %g]]", code, p[0], p[1])
+	}
+	sty := &A{Fc: "none"}
+	if args != nil {
+		*sty = *args
+		if sty.Fc == "" {
+			sty.Fc = "none"
+		}
+	}
+	if sty.Closed {
+		io.Ff(o.pyBuf(), ", [pth.Path.CLOSEPOLY, [0, 0]]")
+	}
+	io.Ff(o.pyBuf(), "]\n")
+	io.Ff(o.pyBuf(), "commands%d, vertices%d = zip(*dat%d)\n", n, n, n)
+	io.Ff(o.pyBuf(), "ph%d = pth.Path(vertices%d, commands%d)\n", n, n, n)
+	if sty.Style != "" {
+		scale := 20.0
+		if sty.Scale > 0 {
+			scale = sty.Scale
+		}
+		io.Ff(o.pyBuf(), "pc%d = pat.FancyArrowPatch(path=ph%d,arrowstyle='%s',mutation_scale=%g", n, n, sty.Style, scale)
+	} else {
+		io.Ff(o.pyBuf(), "pc%d = pat.PathPatch(ph%d", n, n)
+	}
+	o.addPatch(n, sty)
+	return
+}
+
+// LegendX draws legend with given lines data. fs == fontsize. Entries with face color (Fc) are
+// drawn as patches; e.g. for filled regions or histogram bars. If args.LegLevels is given, entries
+// with the colors of these levels of the last contour (ContourF, ContourL, TricontourF or
+// TricontourL) are appended, labelled with args.UnumFmt (default "%g")
+func (o *Plotter) LegendX(dat []*A, args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "handles%d = [", n)
 	for i, d := range dat {
 		if i > 0 {
-			io.Ff(&bufferPy, ",\n")
+			io.Ff(o.pyBuf(), ",\n")
 		}
 		if d != nil {
-			io.Ff(&bufferPy, "lns.Line2D([], [], %s)", d.String(false))
+			io.Ff(o.pyBuf(), "%s", legendProxy(d))
 		}
 	}
 	fs, loc, frame := 9.0, "best", false
 	if args != nil {
-		fs = args.FszLeg
-		loc = args.LegLoc
+		if args.FszLeg > 0 {
+			fs = args.FszLeg
+		}
+		if args.LegLoc != "" {
+			loc = args.LegLoc
+		}
+		if len(args.LegLevels) > 0 {
+			if o.lastContour == "" {
+				chk.Panic("LegLevels requires a contour drawn before LegendX")
+			}
+			numFmt := "%g"
+			if args.UnumFmt != "" {
+				numFmt = args.UnumFmt
+			}
+			for i, v := range args.LegLevels {
+				if i > 0 || len(dat) > 0 {
+					io.Ff(o.pyBuf(), ",\n")
+				}
+				io.Ff(o.pyBuf(), "pat.Patch(facecolor=%s.to_rgba(%s),label='%s')", o.lastContour, num(v), io.Sf(numFmt, v))
+			}
+		}
 	}
-	io.Ff(&bufferPy, "]\nl%d=plt.legend(handles=handles%d, fontsize=%g, loc='%s'", n, n, fs, loc)
-	updateBufferAndClose(&bufferPy, args, false)
+	io.Ff(o.pyBuf(), "]\nl%d=plt.legend(handles=handles%d, fontsize=%g, loc='%s'", n, n, fs, loc)
+	updateBufferAndClose(o.pyBuf(), args, false)
 	if !frame {
-		io.Ff(&bufferPy, "if l%d: l%d.get_frame().set_linewidth(0.0)\n", n, n)
+		io.Ff(o.pyBuf(), "if l%d: l%d.get_frame().set_linewidth(0.0)\n", n, n)
+	}
+	io.Ff(o.pyBuf(), "addToEA(l%d)\n", n)
+}
+
+// legendProxy returns the command creating the legend entry (proxy artist) of d: a patch if d.Fc
+// is given; otherwise a line
+func legendProxy(d *A) string {
+	if d.Fc == "" {
+		return io.Sf("lns.Line2D([], [], %s)", d.String(false))
+	}
+	l := io.Sf("facecolor='%s'", d.Fc)
+	addToCmd(&l, d.Ec != "", io.Sf("edgecolor='%s'", d.Ec))
+	addToCmd(&l, d.Lw > 0, io.Sf("lw=%g", d.Lw))
+	addToCmd(&l, d.Ls != "", io.Sf("ls='%s'", d.Ls))
+	addToCmd(&l, d.Alpha > 0, io.Sf("alpha=%g", d.Alpha))
+	addToCmd(&l, d.L != "", io.Sf("label='%s'", d.L))
+	return io.Sf("pat.Patch(%s)", l)
+}
+
+// addPatch closes the command creating patch pc{n} with the arguments and adds it to the axes
+func (o *Plotter) addPatch(n int, args *A) {
+	if args != nil && args.useDashes() { // patches take the dashes as a line style
+		io.Ff(o.pyBuf(), ",ls=(0,%s)", floats2list(args.Dashes))
+		a := *args
+		a.Ls, a.Dashes = "", nil
+		args = &a
 	}
-	io.Ff(&bufferPy, "addToEA(l%d)\n", n)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	io.Ff(o.pyBuf(), "plt.gca().add_patch(pc%d)\n", n)
 }
@@ -0,0 +1,104 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Ecdf plots the empirical cumulative distribution function of data as a step function.
+// It returns the sorted distinct values x and the values F(x) of the empirical CDF
+func (o *Plotter) Ecdf(data []float64, args *A) (x, F []float64) {
+	x, F = ecdfSteps(data)
+	if len(x) == 0 {
+		return
+	}
+	xx := append([]float64{x[0]}, x...) // starts at F = 0
+	ff := append([]float64{0}, F...)
+	n := o.bufferPy.Len()
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	gen2Arrays(o.pyBuf(), sx, sy, xx, ff)
+	io.Ff(o.pyBuf(), "plt.plot(%s,%s,drawstyle='steps-post'", sx, sy)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	return
+}
+
+// EcdfRef plots the empirical cumulative distribution function of data (see Ecdf) and the
+// reference CDF computed with npts points between the minimum and maximum values of data
+func (o *Plotter) EcdfRef(data []float64, cdf func(x float64) float64, npts int, args, argsRef *A) (x, F []float64) {
+	x, F = o.Ecdf(data, args)
+	if len(x) == 0 {
+		return
+	}
+	X := utl.LinSpace(x[0], x[len(x)-1], npts)
+	Y := make([]float64, len(X))
+	for i, xi := range X {
+		Y[i] = cdf(xi)
+	}
+	if argsRef == nil {
+		argsRef = &A{C: "k", Ls: "--"}
+	}
+	o.Plot(X, Y, argsRef)
+	return
+}
+
+// ecdfSteps computes the steps of the empirical cumulative distribution function; i.e. the
+// sorted distinct values x and F(x) = (number of data <= x) / n
+func ecdfSteps(data []float64) (x, F []float64) {
+	n := len(data)
+	if n == 0 {
+		return
+	}
+	sorted := utl.DblGetSorted(data)
+	for i, v := range sorted {
+		if i+1 < n && sorted[i+1] == v {
+			continue // ties: only the last one counts
+		}
+		x = append(x, v)
+		F = append(F, float64(i+1)/float64(n))
+	}
+	return
+}
+
+// QQplot plots the sorted sample versus the theoretical quantiles computed with quantileFunc at
+// the plotting positions (i-0.5)/n, and the 45-degree reference line; e.g. to check whether the
+// sample follows a given distribution. Points are drawn with args (default: blue circles).
+// It returns the theoretical and sample quantiles
+func (o *Plotter) QQplot(sample []float64, quantileFunc func(p float64) float64, args *A) (xt, xs []float64) {
+	if len(sample) == 0 {
+		return
+	}
+	xs = utl.DblGetSorted(sample)
+	xt = make([]float64, len(xs))
+	for i, p := range qqPositions(len(xs)) {
+		xt[i] = quantileFunc(p)
+	}
+	a := &A{C: "b", M: "o", Ls: "none"}
+	if args != nil {
+		*a = *args
+		a.Ls = "none"
+		if a.M == "" {
+			a.M = "o"
+		}
+	}
+	o.Plot(xt, xs, a)
+	tmin, tmax := utl.DblMinMax(xt)
+	lo, hi := math.Min(tmin, xs[0]), math.Max(tmax, xs[len(xs)-1])
+	io.Ff(o.pyBuf(), "plt.plot([%g,%g],[%g,%g], color='black', linestyle='dashed', linewidth=1.2, zorder=0)\n", lo, hi, lo, hi)
+	return
+}
+
+// qqPositions computes the plotting positions p_i = (i-0.5)/n, i = 1..n
+func qqPositions(n int) (p []float64) {
+	p = make([]float64, n)
+	for i := 0; i < n; i++ {
+		p[i] = (float64(i) + 0.5) / float64(n)
+	}
+	return
+}
@@ -0,0 +1,178 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// SeriesSpec defines one x-y series in a FigSpec. The data is given either directly by X and Y or
+// by the columns Xkey and Ykey of a table file (see io.ReadTable)
+type SeriesSpec struct {
+	X    []float64 `json:"x,omitempty"`    // x values
+	Y    []float64 `json:"y,omitempty"`    // y values
+	File string    `json:"file,omitempty"` // table file; used if X and Y are empty
+	Xkey string    `json:"xkey,omitempty"` // key of x column in table file
+	Ykey string    `json:"ykey,omitempty"` // key of y column in table file
+	Args *A        `json:"args,omitempty"` // plot arguments
+}
+
+// FigSpec defines a figure declaratively; e.g. to be loaded from JSON files. See RenderSpecs
+type FigSpec struct {
+	Series []*SeriesSpec `json:"series"`           // x-y series
+	Xlabel string        `json:"xlabel,omitempty"` // x-axis label
+	Ylabel string        `json:"ylabel,omitempty"` // y-axis label
+	Title  string        `json:"title,omitempty"`  // title
+	Lims   []float64     `json:"lims,omitempty"`   // [xmin, xmax, ymin, ymax] limits; empty => automatic
+	Xlog   bool          `json:"xlog,omitempty"`   // log scale along x
+	Ylog   bool          `json:"ylog,omitempty"`   // log scale along y
+	Args   *A            `json:"args,omitempty"`   // arguments for Gll; e.g. legend options
+	Fname  string        `json:"fname"`            // output filename; e.g. "results.png"
+}
+
+// SaveOpts holds options to save batches of figures
+type SaveOpts struct {
+	Dirout string  // output directory; "" => current directory
+	Prop   float64 // proportion: height = width * prop; 0 => 0.75
+	WidPt  float64 // width in points; 0 => 400
+	Dpi    int     // resolution; 0 => 150
+}
+
+// ReadFigSpecs reads a JSON file with a list of figure specifications
+func ReadFigSpecs(fname string) (specs []FigSpec, err error) {
+	b, err := io.ReadFile(fname)
+	if err != nil {
+		return nil, chk.Err("cannot read figure specifications file <%s>:\n%v", fname, err)
+	}
+	err = json.Unmarshal(b, &specs)
+	if err != nil {
+		return nil, chk.Err("cannot parse figure specifications file <%s>:\n%v", fname, err)
+	}
+	return
+}
+
+// Validate checks the specification and loads the data of series given by table files
+func (o *FigSpec) Validate() (err error) {
+	if o.Fname == "" {
+		return chk.Err("output filename must be given")
+	}
+	if len(o.Lims) != 0 && len(o.Lims) != 4 {
+		return chk.Err("lims must have 4 values [xmin, xmax, ymin, ymax]. len(lims)=%d is incorrect", len(o.Lims))
+	}
+	if len(o.Series) < 1 {
+		return chk.Err("at least one series must be given")
+	}
+	for i, s := range o.Series {
+		if s == nil {
+			return chk.Err("series %d is nil", i)
+		}
+		if len(s.X) == 0 && len(s.Y) == 0 && s.File != "" {
+			keys, T, e := io.ReadTable(s.File)
+			if e != nil {
+				return chk.Err("cannot read table for series %d:\n%v", i, e)
+			}
+			var okx, oky bool
+			s.X, okx = T[s.Xkey]
+			s.Y, oky = T[s.Ykey]
+			if !okx || !oky {
+				return chk.Err("series %d: table <%s> with keys %v does not have columns %q and %q", i, s.File, keys, s.Xkey, s.Ykey)
+			}
+		}
+		if len(s.X) != len(s.Y) {
+			return chk.Err("series %d: lengths of x and y must be the same. %d != %d", i, len(s.X), len(s.Y))
+		}
+		if len(s.X) == 0 {
+			return chk.Err("series %d: no data given", i)
+		}
+	}
+	return
+}
+
+// RenderSpecs validates and draws all figures and saves them with one call to Python
+func (o *Plotter) RenderSpecs(specs []FigSpec, opts *SaveOpts) (err error) {
+	fnames, err := o.genSpecs(specs, opts)
+	if err != nil {
+		return
+	}
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	err = o.run("")
+	if err != nil {
+		return
+	}
+	for _, fn := range fnames {
+		io.Pf("file <%s> written\n", fn)
+	}
+	return
+}
+
+// genSpecs validates specs and generates the Python commands to draw and save all figures
+func (o *Plotter) genSpecs(specs []FigSpec, opts *SaveOpts) (fnames []string, err error) {
+
+	// check
+	for i := 0; i < len(specs); i++ {
+		err = specs[i].Validate()
+		if err != nil {
+			return nil, chk.Err("figure spec %d is invalid:\n%v", i, err)
+		}
+	}
+
+	// options
+	dirout, prop, widpt, dpi := "", 0.75, 400.0, 150
+	if opts != nil {
+		dirout = opts.Dirout
+		if opts.Prop > 0 {
+			prop = opts.Prop
+		}
+		if opts.WidPt > 0 {
+			widpt = opts.WidPt
+		}
+		if opts.Dpi > 0 {
+			dpi = opts.Dpi
+		}
+	}
+	if dirout != "" {
+		err = os.MkdirAll(dirout, 0777)
+		if err != nil {
+			return nil, chk.Err("cannot create directory to save figure files:\n%v\n", err)
+		}
+	}
+	defer func(rc RcConfig) { o.rc = rc }(o.rc) // the figure size of the specs is not kept by Reset
+	o.SetForPng(prop, widpt, dpi, nil)
+
+	// figures
+	fnames = make([]string, len(specs))
+	for i, spec := range specs {
+		io.Ff(o.pyBuf(), "plt.figure(%d)\n", i+1)
+		for _, s := range spec.Series {
+			o.Plot(s.X, s.Y, s.Args)
+		}
+		if spec.Xlog {
+			o.SetXlog()
+		}
+		if spec.Ylog {
+			o.SetYlog()
+		}
+		if len(spec.Lims) == 4 {
+			o.AxisLims(spec.Lims)
+		}
+		if spec.Title != "" {
+			o.Title(spec.Title, nil)
+		}
+		o.Gll(spec.Xlabel, spec.Ylabel, spec.Args)
+		fnames[i] = filepath.Join(dirout, spec.Fname)
+		o.saveFig(fnames[i], nil)
+		io.Ff(o.pyBuf(), "plt.close(%d)\n", i+1)
+		io.Ff(o.pyBuf(), "del EXTRA_ARTISTS[:]\n")
+	}
+	return
+}
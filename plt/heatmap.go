@@ -0,0 +1,138 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"os"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// HeatmapAnnotated draws the matrix z as an image with the value of each cell written on top;
+// e.g. to show correlation matrices. Text is white for values above the midpoint of the
+// colormap and black otherwise
+//  Input:
+//   rowLabels -- labels of rows (y ticks); nil => no labels
+//   colLabels -- labels of columns (x ticks); nil => no labels
+//   numFmt    -- format of values; e.g. "%.2f"; "" => "%g"
+//   args      -- colormap (UcmapIdx), limits (VminVmax), colorbar (UnoCbar, UcbarLbl, Ucbar...) and font size (Fsz)
+func (o *Plotter) HeatmapAnnotated(z [][]float64, rowLabels, colLabels []string, numFmt string, args *A) (err error) {
+
+	// check
+	nrow := len(z)
+	if nrow < 1 {
+		return chk.Err("matrix must have at least one row")
+	}
+	ncol := len(z[0])
+	for i := 0; i < nrow; i++ {
+		if len(z[i]) != ncol {
+			return chk.Err("all rows must have the same number of columns. %d != %d", len(z[i]), ncol)
+		}
+	}
+	if rowLabels != nil && len(rowLabels) != nrow {
+		return chk.Err("number of row labels must be equal to the number of rows. %d != %d", len(rowLabels), nrow)
+	}
+	if colLabels != nil && len(colLabels) != ncol {
+		return chk.Err("number of column labels must be equal to the number of columns. %d != %d", len(colLabels), ncol)
+	}
+
+	// arguments
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	if numFmt == "" {
+		numFmt = "%g"
+	}
+	vmin, vmax := matMinMax(z)
+	if len(a.VminVmax) == 2 {
+		vmin, vmax = a.VminVmax[0], a.VminVmax[1]
+	}
+	mid := (vmin + vmax) / 2.0
+
+	// image
+	n := o.bufferPy.Len()
+	sz := io.Sf("z%d", n)
+	genMat(o.pyBuf(), sz, z)
+	io.Ff(o.pyBuf(), "p%d = plt.imshow(%s,cmap=getCmap(%d),vmin=%g,vmax=%g,interpolation='nearest')\n", n, sz, a.UcmapIdx, vmin, vmax)
+	if !a.UnoCbar {
+		io.Ff(o.pyBuf(), "cb%d = plt.colorbar(p%d%s)\n", n, n, argsCbar(a))
+		o.cbarLabel(n, a)
+	}
+
+	// ticks
+	if colLabels != nil {
+		genStrArray(o.pyBuf(), io.Sf("xl%d", n), colLabels)
+		io.Ff(o.pyBuf(), "plt.xticks(range(%d),xl%d)\n", ncol, n)
+	}
+	if rowLabels != nil {
+		genStrArray(o.pyBuf(), io.Sf("yl%d", n), rowLabels)
+		io.Ff(o.pyBuf(), "plt.yticks(range(%d),yl%d)\n", nrow, n)
+	}
+
+	// values
+	for i := 0; i < nrow; i++ {
+		for j := 0; j < ncol; j++ {
+			clr := "black"
+			if z[i][j] > mid {
+				clr = "white"
+			}
+			o.Text(float64(j), float64(i), io.Sf(numFmt, z[i][j]), &A{C: clr, Ha: "center", Va: "center", Fsz: a.Fsz})
+		}
+	}
+	return
+}
+
+// ImageFile draws an image file (e.g. a map or photo); e.g. to be placed beneath data
+//  Input:
+//   extent -- [xmin, xmax, ymin, ymax] in data coordinates; nil => pixel coordinates
+//   args   -- z-order (Z) and transparency (Alpha)
+func (o *Plotter) ImageFile(fname string, extent []float64, args *A) (err error) {
+	if _, err = os.Stat(fname); err != nil {
+		return chk.Err("cannot find image file <%s>:\n%v", fname, err)
+	}
+	if extent != nil && len(extent) != 4 {
+		return chk.Err("extent must have 4 values [xmin, xmax, ymin, ymax]. len(extent)=%d is incorrect", len(extent))
+	}
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "img%d = plt.imread(%q)\n", n, fname)
+	io.Ff(o.pyBuf(), "plt.imshow(img%d", n)
+	if extent != nil {
+		io.Ff(o.pyBuf(), ",extent=%s", floats2list(extent))
+	}
+	if args != nil {
+		if args.Z > 0 {
+			io.Ff(o.pyBuf(), ",zorder=%d", args.Z)
+		}
+		if args.Alpha > 0 {
+			io.Ff(o.pyBuf(), ",alpha=%g", args.Alpha)
+		}
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	return
+}
+
+// ColorbarOnly draws a colorbar for the colormap cmapIdx (see getCmap) and the range [vmin, vmax];
+// e.g. when the colors of other items are computed in Go. The number format is given by
+// args.UnumFmt and the orientation, size, ticks, etc. by args.UcbarOrient, args.UcbarShrink, etc.
+// The colorbar is registered as an extra artist.
+// It returns the name of the Python variable holding the colorbar
+func (o *Plotter) ColorbarOnly(cmapIdx int, vmin, vmax float64, label string, args *A) (name string) {
+	n := o.bufferPy.Len()
+	name = io.Sf("cb%d", n)
+	io.Ff(o.pyBuf(), "sm%d = plt.cm.ScalarMappable(cmap=getCmap(%d),norm=plt.Normalize(vmin=%g,vmax=%g))\n", n, cmapIdx, vmin, vmax)
+	io.Ff(o.pyBuf(), "sm%d.set_array([])\n", n)
+	io.Ff(o.pyBuf(), "%s = plt.colorbar(sm%d,ax=plt.gca()%s", name, n, argsCbar(args))
+	if args != nil && args.UnumFmt != "" {
+		io.Ff(o.pyBuf(), ", format='%s'", args.UnumFmt)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	if label != "" {
+		io.Ff(o.pyBuf(), "%s.set_label('%s')\n", name, label)
+	}
+	o.RegisterExtraArtist(name + ".ax")
+	return
+}
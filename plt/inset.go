@@ -0,0 +1,59 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// ZoomInset creates an inset axes showing the window [xmin,xmax]×[ymin,ymax] of the current
+// axes. The zoom rectangle and the lines connecting it to the inset are drawn on the current
+// (parent) axes, which remains active after this call. The returned functions activate the
+// inset, such that the Plot commands can be re-issued inside it, and re-activate the parent.
+//  Input:
+//   xFrac, yFrac -- position of the lower-left corner of the inset (figure fraction)
+//   wFrac, hFrac -- width and height of the inset (figure fraction)
+//   args         -- color (C; default "0.5") and line width (Lw; default 1) of rectangle and connectors
+//  Example:
+//   activate, deactivate := ZoomInset(0.5, 0.5, 0.3, 0.3, 1, 2, 0, 0.5, nil)
+//   Plot(x, y, nil)
+//   activate()
+//   Plot(x, y, nil)
+//   deactivate()
+func (o *Plotter) ZoomInset(xFrac, yFrac, wFrac, hFrac float64, xmin, xmax, ymin, ymax float64, args *A) (activate, deactivate func()) {
+	if wFrac <= 0 || hFrac <= 0 {
+		chk.Panic("width and height of inset must be positive. wFrac=%g and hFrac=%g are invalid", wFrac, hFrac)
+	}
+	if xmin >= xmax || ymin >= ymax {
+		chk.Panic("zoom window [%g,%g]×[%g,%g] is invalid", xmin, xmax, ymin, ymax)
+	}
+	clr, lw := "0.5", 1.0
+	if args != nil {
+		if args.C != "" {
+			clr = args.C
+		}
+		if args.Lw > 0 {
+			lw = args.Lw
+		}
+	}
+	n := o.bufferPy.Len()
+	parent, inset := io.Sf("axp%d", n), io.Sf("axi%d", n)
+	io.Ff(o.pyBuf(), "%s = plt.gca()\n", parent)
+	io.Ff(o.pyBuf(), "%s = plt.gcf().add_axes([%g,%g,%g,%g])\n", inset, xFrac, yFrac, wFrac, hFrac)
+	io.Ff(o.pyBuf(), "%s.set_xlim(%g,%g)\n", inset, xmin, xmax)
+	io.Ff(o.pyBuf(), "%s.set_ylim(%g,%g)\n", inset, ymin, ymax)
+	io.Ff(o.pyBuf(), "from mpl_toolkits.axes_grid1.inset_locator import mark_inset\n")
+	io.Ff(o.pyBuf(), "mark_inset(%s,%s,loc1=2,loc2=4,fc='none',ec='%s',lw=%g)\n", parent, inset, clr, lw)
+	io.Ff(o.pyBuf(), "plt.sca(%s)\n", parent)
+	activate = func() {
+		io.Ff(o.pyBuf(), "plt.sca(%s)\n", inset)
+		io.Ff(o.pyBuf(), "%s.set_autoscale_on(False)\n", inset)
+	}
+	deactivate = func() {
+		io.Ff(o.pyBuf(), "plt.sca(%s)\n", parent)
+	}
+	return
+}
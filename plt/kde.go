@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/utl"
+)
+
+// Kde plots the Gaussian kernel density estimate of data computed at npts points covering the
+// range of data padded by three bandwidths. If bandwidth <= 0, Silverman's rule of thumb is
+// used. It returns the points x and the estimated density f(x)
+func (o *Plotter) Kde(data []float64, npts int, bandwidth float64, args *A) (x, f []float64) {
+	if len(data) == 0 {
+		return
+	}
+	if bandwidth <= 0 {
+		bandwidth = kdeSilverman(data)
+	}
+	xmin, xmax := utl.DblMinMax(data)
+	if npts < 2 {
+		npts = 101
+	}
+	x = utl.LinSpace(xmin-3*bandwidth, xmax+3*bandwidth, npts)
+	f = kdeGauss(data, x, bandwidth)
+	o.Plot(x, f, args)
+	return
+}
+
+// KdeHist draws the normed histogram of data and the Gaussian kernel density estimate on top
+// of it. See Kde
+func (o *Plotter) KdeHist(data []float64, npts int, bandwidth float64, argsHist, argsKde *A) (x, f []float64) {
+	if len(data) == 0 {
+		return
+	}
+	a := new(A)
+	if argsHist != nil {
+		*a = *argsHist
+	}
+	a.Hnormed = true
+	o.Hist([][]float64{data}, []string{""}, a)
+	return o.Kde(data, npts, bandwidth, argsKde)
+}
+
+// kdeGauss computes the Gaussian kernel density estimate at points x with bandwidth h
+func kdeGauss(data, x []float64, h float64) (f []float64) {
+	f = make([]float64, len(x))
+	c := 1.0 / (float64(len(data)) * h * math.Sqrt(2.0*math.Pi))
+	for i, xi := range x {
+		for _, d := range data {
+			u := (xi - d) / h
+			f[i] += math.Exp(-0.5 * u * u)
+		}
+		f[i] *= c
+	}
+	return
+}
+
+// kdeSilverman computes the bandwidth of Gaussian kernels with Silverman's rule of thumb:
+//  h = 0.9 min(σ, IQR/1.34) n^(-1/5)
+func kdeSilverman(data []float64) (h float64) {
+	n := float64(len(data))
+	if n < 2 {
+		return 1
+	}
+	mean := 0.0
+	for _, d := range data {
+		mean += d
+	}
+	mean /= n
+	sig := 0.0
+	for _, d := range data {
+		sig += (d - mean) * (d - mean)
+	}
+	sig = math.Sqrt(sig / (n - 1))
+	sorted := utl.DblGetSorted(data)
+	iqr := utl.DblQuantile(sorted, 0.75) - utl.DblQuantile(sorted, 0.25)
+	s := sig
+	if iqr > 0 {
+		s = math.Min(sig, iqr/1.34)
+	}
+	if s == 0 {
+		return 1
+	}
+	return 0.9 * s * math.Pow(n, -0.2)
+}
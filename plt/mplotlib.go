@@ -7,199 +7,330 @@ package plt
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	goio "io"
+	"io/ioutil"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
 )
 
-// default directory and temporary file name for python commands
+// former temporary file name for python commands.
+// Deprecated: scripts are now written to unique temporary files; see SetTempDir
 const TEMPORARY = "/tmp/pltgosl.py"
 
-// buffer holding Python commands
-var bufferPy bytes.Buffer
-
-// buffer holding Python extra artists commands
-var bufferEa bytes.Buffer
+// Reset resets drawing buffer (i.e. Python temporary file data). The figure size and font sizes
+// set by SetForPng, SetForEps, SetForSvg or SetFontSizes are applied again; see SetRc
+func (o *Plotter) Reset() {
+	o.bufferPy.Reset()
+	o.bufferEa.Reset()
+	o.pyOrigins = nil
+	io.Ff(&o.bufferEa, pythonHeader)
+	o.lastQuiver = ""
+	o.lastContour = ""
+	o.gridSpecs = make(map[string][]int)
+	o.sharedAxes = make(map[string]string)
+	o.nCustomCmaps = 0
+	o.genRc()
+}
+
+// DefineColormap defines a colormap interpolating the given colors and appends it to the list of
+// colormaps; thus, it can be selected by args.UcmapIdx in ContourF, Surface, etc. The definition
+// is added to the setup commands (see EaCmds); thus, the colormap can be used by commands
+// created before this call. It returns the index of the new colormap
+//  colors         -- at least two colors; e.g. []string{"blue", "white", "red"}
+//  positionsOrNil -- positions of colors in [0,1], increasing from 0 to 1; nil => evenly spaced
+func (o *Plotter) DefineColormap(name string, colors []string, positionsOrNil []float64) (idx int, err error) {
+	if len(colors) < 2 {
+		return 0, chk.Err("colormap must have at least 2 colors. %d is invalid", len(colors))
+	}
+	p := positionsOrNil
+	if p != nil {
+		if len(p) != len(colors) {
+			return 0, chk.Err("number of positions must be equal to the number of colors. %d != %d", len(p), len(colors))
+		}
+		if p[0] != 0 || p[len(p)-1] != 1 {
+			return 0, chk.Err("positions must start at 0 and end at 1. %v is invalid", p)
+		}
+		for i := 1; i < len(p); i++ {
+			if p[i] <= p[i-1] {
+				return 0, chk.Err("positions must be increasing. %v is invalid", p)
+			}
+		}
+	}
+	io.Ff(&o.bufferEa, "COLORMAPS.append(mcl.LinearSegmentedColormap.from_list(%q,[", name)
+	if p == nil {
+		for _, c := range colors {
+			io.Ff(&o.bufferEa, "'%s',", c)
+		}
+	} else {
+		for i, c := range colors {
+			io.Ff(&o.bufferEa, "(%g,'%s'),", p[i], c)
+		}
+	}
+	io.Ff(&o.bufferEa, "]))\n")
+	idx = numDefaultCmaps + o.nCustomCmaps
+	o.nCustomCmaps++
+	return
+}
 
-// init resets the buffers, in case the user doesn't do this
-func init() {
-	Reset()
+// PyCmds adds Python commands to be called when plotting
+func (o *Plotter) PyCmds(text string) {
+	io.Ff(o.pyBuf(), text)
 }
 
-// Reset resets drawing buffer (i.e. Python temporary file data)
-func Reset() {
-	bufferPy.Reset()
-	bufferEa.Reset()
-	io.Ff(&bufferPy, pythonHeader)
+// EaCmds adds Python setup commands. The script is executed in the following order:
+//  1. header with imports and definitions; e.g. EXTRA_ARTISTS and addToEA
+//  2. setup commands given to EaCmds
+//  3. plotting commands; e.g. from Plot or PyCmds
+//  4. savefig or show
+func (o *Plotter) EaCmds(text string) {
+	io.Ff(&o.bufferEa, text)
 }
 
-// PyCmds adds Python commands to be called when plotting
-func PyCmds(text string) {
-	io.Ff(&bufferPy, text)
+// RegisterExtraArtist registers the Python variable holding an artist (e.g. a text or legend
+// created with PyCmds) such that it is considered when computing the tight bounding box of the
+// saved figure
+func (o *Plotter) RegisterExtraArtist(pyVarName string) {
+	io.Ff(o.pyBuf(), "addToEA(%s)\n", pyVarName)
 }
 
 // PyFile loads Python file and copy its contents to temporary buffer
-func PyFile(filename string) (err error) {
+func (o *Plotter) PyFile(filename string) (err error) {
 	b, err := io.ReadFile(filename)
 	if err != nil {
 		return
 	}
-	io.Ff(&bufferPy, string(b))
+	io.Ff(o.pyBuf(), string(b))
 	return
 }
 
 // DoubleYscale duplicates y-scale
-func DoubleYscale(ylabelOrEmpty string) {
-	io.Ff(&bufferPy, "plt.gca().twinx()\n")
+func (o *Plotter) DoubleYscale(ylabelOrEmpty string) {
+	io.Ff(o.pyBuf(), "plt.gca().twinx()\n")
 	if ylabelOrEmpty != "" {
-		io.Ff(&bufferPy, "plt.gca().set_ylabel('%s')\n", ylabelOrEmpty)
+		io.Ff(o.pyBuf(), "plt.gca().set_ylabel('%s')\n", ylabelOrEmpty)
+	}
+}
+
+// DoubleXscale duplicates x-scale; e.g. to show other units along the top axis. Subsequent
+// commands target the new axes. See LegendCombined
+func (o *Plotter) DoubleXscale(xlabelOrEmpty string) {
+	io.Ff(o.pyBuf(), "plt.sca(plt.gca().twiny())\n")
+	if xlabelOrEmpty != "" {
+		io.Ff(o.pyBuf(), "plt.gca().set_xlabel('%s')\n", xlabelOrEmpty)
 	}
 }
 
 // SetXlog sets x-scale to be log
-func SetXlog() {
-	io.Ff(&bufferPy, "plt.gca().set_xscale('log')\n")
+func (o *Plotter) SetXlog() {
+	io.Ff(o.pyBuf(), "plt.gca().set_xscale('log')\n")
 }
 
 // SetYlog sets y-scale to be log
-func SetYlog() {
-	io.Ff(&bufferPy, "plt.gca().set_yscale('log')\n")
+func (o *Plotter) SetYlog() {
+	io.Ff(o.pyBuf(), "plt.gca().set_yscale('log')\n")
 }
 
 // SetXnticks sets number of ticks along x
-func SetXnticks(num int) {
+func (o *Plotter) SetXnticks(num int) {
 	if num == 0 {
-		io.Ff(&bufferPy, "plt.gca().get_xaxis().set_ticks([])\n")
+		io.Ff(o.pyBuf(), "plt.gca().get_xaxis().set_ticks([])\n")
 	} else {
-		io.Ff(&bufferPy, "plt.gca().get_xaxis().set_major_locator(tck.MaxNLocator(%d))\n", num)
+		io.Ff(o.pyBuf(), "plt.gca().get_xaxis().set_major_locator(tck.MaxNLocator(%d))\n", num)
 	}
 }
 
 // SetYnticks sets number of ticks along y
-func SetYnticks(num int) {
+func (o *Plotter) SetYnticks(num int) {
 	if num == 0 {
-		io.Ff(&bufferPy, "plt.gca().get_yaxis().set_ticks([])\n")
+		io.Ff(o.pyBuf(), "plt.gca().get_yaxis().set_ticks([])\n")
 	} else {
-		io.Ff(&bufferPy, "plt.gca().get_yaxis().set_major_locator(tck.MaxNLocator(%d))\n", num)
+		io.Ff(o.pyBuf(), "plt.gca().get_yaxis().set_major_locator(tck.MaxNLocator(%d))\n", num)
 	}
 }
 
 // SetTicksX sets ticks along x
-func SetTicksX(majorEvery, minorEvery float64, majorFmt string) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "majorLocator%d = tck.MultipleLocator(%g)\n", n, majorEvery)
-	io.Ff(&bufferPy, "minorLocator%d = tck.MultipleLocator(%g)\n", n, minorEvery)
-	io.Ff(&bufferPy, "majorFormatter%d = tck.FormatStrFormatter('%s')\n", n, majorFmt)
-	io.Ff(&bufferPy, "plt.gca().xaxis.set_major_locator(majorLocator%d)\n", n)
-	io.Ff(&bufferPy, "plt.gca().xaxis.set_minor_locator(minorLocator%d)\n", n)
-	io.Ff(&bufferPy, "plt.gca().xaxis.set_major_formatter(majorFormatter%d)\n", n)
+func (o *Plotter) SetTicksX(majorEvery, minorEvery float64, majorFmt string) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "majorLocator%d = tck.MultipleLocator(%g)\n", n, majorEvery)
+	io.Ff(o.pyBuf(), "minorLocator%d = tck.MultipleLocator(%g)\n", n, minorEvery)
+	io.Ff(o.pyBuf(), "majorFormatter%d = tck.FormatStrFormatter('%s')\n", n, majorFmt)
+	io.Ff(o.pyBuf(), "plt.gca().xaxis.set_major_locator(majorLocator%d)\n", n)
+	io.Ff(o.pyBuf(), "plt.gca().xaxis.set_minor_locator(minorLocator%d)\n", n)
+	io.Ff(o.pyBuf(), "plt.gca().xaxis.set_major_formatter(majorFormatter%d)\n", n)
 }
 
 // SetTicksY sets ticks along y
-func SetTicksY(majorEvery, minorEvery float64, majorFmt string) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "majorLocator%d = tck.MultipleLocator(%g)\n", n, majorEvery)
-	io.Ff(&bufferPy, "minorLocator%d = tck.MultipleLocator(%g)\n", n, minorEvery)
-	io.Ff(&bufferPy, "majorFormatter%d = tck.FormatStrFormatter('%s')\n", n, majorFmt)
-	io.Ff(&bufferPy, "plt.gca().yaxis.set_major_locator(majorLocator%d)\n", n)
-	io.Ff(&bufferPy, "plt.gca().yaxis.set_minor_locator(minorLocator%d)\n", n)
-	io.Ff(&bufferPy, "plt.gca().yaxis.set_major_formatter(majorFormatter%d)\n", n)
+func (o *Plotter) SetTicksY(majorEvery, minorEvery float64, majorFmt string) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "majorLocator%d = tck.MultipleLocator(%g)\n", n, majorEvery)
+	io.Ff(o.pyBuf(), "minorLocator%d = tck.MultipleLocator(%g)\n", n, minorEvery)
+	io.Ff(o.pyBuf(), "majorFormatter%d = tck.FormatStrFormatter('%s')\n", n, majorFmt)
+	io.Ff(o.pyBuf(), "plt.gca().yaxis.set_major_locator(majorLocator%d)\n", n)
+	io.Ff(o.pyBuf(), "plt.gca().yaxis.set_minor_locator(minorLocator%d)\n", n)
+	io.Ff(o.pyBuf(), "plt.gca().yaxis.set_major_formatter(majorFormatter%d)\n", n)
+}
+
+// SetXticksLabels sets ticks along x at positions with (string) labels; e.g. for categorical axes.
+// Labels may contain LaTeX; e.g. `$\sigma$`. Rotated labels are aligned to the right, unless
+// args.Ha is given. The font size is given by args.Fsz
+func (o *Plotter) SetXticksLabels(positions []float64, labels []string, rotationDeg float64, args *A) (err error) {
+	return o.setTicksLabels("x", positions, labels, rotationDeg, args)
+}
+
+// SetYticksLabels sets ticks along y at positions with (string) labels. See SetXticksLabels
+func (o *Plotter) SetYticksLabels(positions []float64, labels []string, rotationDeg float64, args *A) (err error) {
+	return o.setTicksLabels("y", positions, labels, rotationDeg, args)
+}
+
+// setTicksLabels implements SetXticksLabels and SetYticksLabels
+func (o *Plotter) setTicksLabels(axis string, positions []float64, labels []string, rotationDeg float64, args *A) (err error) {
+	if len(labels) != len(positions) {
+		return chk.Err("number of labels must be equal to the number of positions. %d != %d", len(labels), len(positions))
+	}
+	n := o.bufferPy.Len()
+	genArray(o.pyBuf(), io.Sf("tp%d", n), positions)
+	genStrArray(o.pyBuf(), io.Sf("tl%d", n), labels)
+	io.Ff(o.pyBuf(), "plt.%sticks(tp%d,tl%d", axis, n, n)
+	if rotationDeg != 0 {
+		io.Ff(o.pyBuf(), ",rotation=%g", rotationDeg)
+	}
+	ha := ""
+	if axis == "x" && rotationDeg != 0 {
+		ha = "right"
+	}
+	if args != nil {
+		if args.Ha != "" {
+			ha = args.Ha
+		}
+		if args.Fsz > 0 {
+			io.Ff(o.pyBuf(), ",fontsize=%g", args.Fsz)
+		}
+	}
+	if ha != "" {
+		io.Ff(o.pyBuf(), ",ha='%s'", ha)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	return
 }
 
 // SetScientificX sets scientific notation for ticks along x-axis
-func SetScientificX(minOrder, maxOrder int) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "fmt%d = plt.ScalarFormatter(useOffset=True)\n", n)
-	io.Ff(&bufferPy, "fmt%d.set_powerlimits((%d,%d))\n", n, minOrder, maxOrder)
-	io.Ff(&bufferPy, "plt.gca().xaxis.set_major_formatter(fmt%d)\n", n)
+func (o *Plotter) SetScientificX(minOrder, maxOrder int) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "fmt%d = plt.ScalarFormatter(useOffset=True)\n", n)
+	io.Ff(o.pyBuf(), "fmt%d.set_powerlimits((%d,%d))\n", n, minOrder, maxOrder)
+	io.Ff(o.pyBuf(), "plt.gca().xaxis.set_major_formatter(fmt%d)\n", n)
 }
 
 // SetScientificY sets scientific notation for ticks along y-axis
-func SetScientificY(minOrder, maxOrder int) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "fmt%d = plt.ScalarFormatter(useOffset=True)\n", n)
-	io.Ff(&bufferPy, "fmt%d.set_powerlimits((%d,%d))\n", n, minOrder, maxOrder)
-	io.Ff(&bufferPy, "plt.gca().yaxis.set_major_formatter(fmt%d)\n", n)
+func (o *Plotter) SetScientificY(minOrder, maxOrder int) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "fmt%d = plt.ScalarFormatter(useOffset=True)\n", n)
+	io.Ff(o.pyBuf(), "fmt%d.set_powerlimits((%d,%d))\n", n, minOrder, maxOrder)
+	io.Ff(o.pyBuf(), "plt.gca().yaxis.set_major_formatter(fmt%d)\n", n)
 }
 
 // SetTicksNormal sets normal ticks
-func SetTicksNormal() {
-	io.Ff(&bufferPy, "plt.gca().ticklabel_format(useOffset=False)\n")
+func (o *Plotter) SetTicksNormal() {
+	io.Ff(o.pyBuf(), "plt.gca().ticklabel_format(useOffset=False)\n")
 }
 
 // ReplaceAxes substitutes axis frame (see Axes in gosl.py)
 //   ex: xDel, yDel := 0.04, 0.04
-func ReplaceAxes(xi, yi, xf, yf, xDel, yDel float64, xLab, yLab string, argsArrow, argsText *A) {
-	io.Ff(&bufferPy, "plt.axis('off')\n")
-	Arrow(xi, yi, xf, yi, argsArrow)
-	Arrow(xi, yi, xi, yf, argsArrow)
-	Text(xf, yi-xDel, xLab, argsText)
-	Text(xi-yDel, yf, yLab, argsText)
+func (o *Plotter) ReplaceAxes(xi, yi, xf, yf, xDel, yDel float64, xLab, yLab string, argsArrow, argsText *A) {
+	io.Ff(o.pyBuf(), "plt.axis('off')\n")
+	o.Arrow(xi, yi, xf, yi, argsArrow)
+	o.Arrow(xi, yi, xi, yf, argsArrow)
+	o.Text(xf, yi-xDel, xLab, argsText)
+	o.Text(xi-yDel, yf, yLab, argsText)
 }
 
 // AxHline adds horizontal line to axis
-func AxHline(y float64, args *A) {
-	io.Ff(&bufferPy, "plt.axhline(%g", y)
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) AxHline(y float64, args *A) {
+	io.Ff(o.pyBuf(), "plt.axhline(%s", num(y))
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
 // AxVline adds vertical line to axis
-func AxVline(x float64, args *A) {
-	io.Ff(&bufferPy, "plt.axvline(%g", x)
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) AxVline(x float64, args *A) {
+	io.Ff(o.pyBuf(), "plt.axvline(%s", num(x))
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// AxHspan adds horizontal shaded band between ymin and ymax to axis; e.g. to mark an admissible
+// range. The band is shown in the legend if args.L is given
+func (o *Plotter) AxHspan(ymin, ymax float64, args *A) {
+	io.Ff(o.pyBuf(), "plt.axhspan(%s,%s", num(ymin), num(ymax))
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// AxVspan adds vertical shaded band between xmin and xmax to axis; e.g. to mark a loading phase.
+// The band is shown in the legend if args.L is given
+func (o *Plotter) AxVspan(xmin, xmax float64, args *A) {
+	io.Ff(o.pyBuf(), "plt.axvspan(%s,%s", num(xmin), num(xmax))
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
-// HideBorders hides frame borders
-func HideBorders(args *A) {
+// HideBorders hides the frame borders given by args.HideL, args.HideR, etc.; or all of them if
+// args.HideAll, except the ones with args.ShowL, args.ShowR, etc.
+func (o *Plotter) HideBorders(args *A) {
 	hide := getHideList(args)
 	if hide != "" {
-		io.Ff(&bufferPy, "for spine in %s: plt.gca().spines[spine].set_visible(0)\n", hide)
+		io.Ff(o.pyBuf(), "for spine in %s: plt.gca().spines[spine].set_visible(0)\n", hide)
 	}
 }
 
 // Annotate adds annotation to plot
-func Annotate(x, y float64, txt string, args *A) {
-	io.Ff(&bufferPy, "plt.annotate(%q, xy=(%g,%g)", txt, x, y)
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) Annotate(x, y float64, txt string, args *A) {
+	io.Ff(o.pyBuf(), "plt.annotate(%q, xy=(%s,%s)", txt, num(x), num(y))
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
 // AnnotateXlabels sets text of xlabels
-func AnnotateXlabels(x float64, txt string, args *A) {
+func (o *Plotter) AnnotateXlabels(x float64, txt string, args *A) {
 	fsz := 7.0
 	if args != nil {
 		if args.Fsz > 0 {
 			fsz = args.Fsz
 		}
 	}
-	io.Ff(&bufferPy, "plt.annotate('%s', xy=(%g, -%g-3), xycoords=('data', 'axes points'), va='top', ha='center', size=%g", txt, x, fsz, fsz)
-	updateBufferAndClose(&bufferPy, args, false)
+	io.Ff(o.pyBuf(), "plt.annotate('%s', xy=(%g, -%g-3), xycoords=('data', 'axes points'), va='top', ha='center', size=%g", txt, x, fsz, fsz)
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
 // SupTitle sets subplot title
-func SupTitle(txt string, args *A) {
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "st%d = plt.suptitle(%q", n, txt)
-	updateBufferAndClose(&bufferPy, args, false)
-	io.Ff(&bufferPy, "addToEA(st%d)\n", n)
+func (o *Plotter) SupTitle(txt string, args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "st%d = plt.suptitle(%q", n, txt)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	io.Ff(o.pyBuf(), "addToEA(st%d)\n", n)
 }
 
 // Title sets title
-func Title(txt string, args *A) {
-	io.Ff(&bufferPy, "plt.title(%q", txt)
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) Title(txt string, args *A) {
+	io.Ff(o.pyBuf(), "plt.title(%q", txt)
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
 // Text adds text to plot
-func Text(x, y float64, txt string, args *A) {
-	io.Ff(&bufferPy, "plt.text(%g,%g,%q", x, y, txt)
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) Text(x, y float64, txt string, args *A) {
+	io.Ff(o.pyBuf(), "plt.text(%s,%s,%q", num(x), num(y), txt)
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
 // Cross adds a vertical and horizontal lines @ (x0,y0) to plot (i.e. large cross)
-func Cross(x0, y0 float64, args *A) {
+func (o *Plotter) Cross(x0, y0 float64, args *A) {
 	cl, ls, lw, z := "black", "dashed", 1.2, 0
 	if args != nil {
 		if args.C != "" {
@@ -215,412 +346,1731 @@ func Cross(x0, y0 float64, args *A) {
 			z = args.Z
 		}
 	}
-	io.Ff(&bufferPy, "plt.axvline(%g, color='%s', linestyle='%s', linewidth=%g, zorder=%d)\n", x0, cl, ls, lw, z)
-	io.Ff(&bufferPy, "plt.axhline(%g, color='%s', linestyle='%s', linewidth=%g, zorder=%d)\n", y0, cl, ls, lw, z)
+	io.Ff(o.pyBuf(), "plt.axvline(%g, color='%s', linestyle='%s', linewidth=%g, zorder=%d)\n", x0, cl, ls, lw, z)
+	io.Ff(o.pyBuf(), "plt.axhline(%g, color='%s', linestyle='%s', linewidth=%g, zorder=%d)\n", y0, cl, ls, lw, z)
 }
 
 // SplotGap sets gap between subplots
-func SplotGap(w, h float64) {
-	io.Ff(&bufferPy, "plt.subplots_adjust(wspace=%g, hspace=%g)\n", w, h)
+func (o *Plotter) SplotGap(w, h float64) {
+	io.Ff(o.pyBuf(), "plt.subplots_adjust(wspace=%g, hspace=%g)\n", w, h)
 }
 
-// Subplot adds/sets a subplot
-func Subplot(i, j, k int) {
-	io.Ff(&bufferPy, "plt.subplot(%d,%d,%d)\n", i, j, k)
+// Subplot adds/sets a subplot. It returns the name of the Python variable holding the axes, which
+// can be activated again with SetCurrentAxes
+func (o *Plotter) Subplot(i, j, k int) (handle string) {
+	handle = io.Sf("ax%d", o.bufferPy.Len())
+	io.Ff(o.pyBuf(), "%s = plt.subplot(%d,%d,%d)\n", handle, i, j, k)
+	return
 }
 
-// Subplot adds/sets a subplot with given indices in I
-func SubplotI(I []int) {
+// Subplot adds/sets a subplot with given indices in I. It returns the name of the Python variable
+// holding the axes (see SetCurrentAxes) or "" if I does not have 3 indices
+func (o *Plotter) SubplotI(I []int) (handle string) {
 	if len(I) != 3 {
 		return
 	}
-	io.Ff(&bufferPy, "plt.subplot(%d,%d,%d)\n", I[0], I[1], I[2])
+	return o.Subplot(I[0], I[1], I[2])
+}
+
+// SetCurrentAxes activates the axes with the given handle returned by Subplot, SubplotI or
+// SubplotShared; e.g. to add a legend to a previously created panel
+func (o *Plotter) SetCurrentAxes(handle string) {
+	io.Ff(o.pyBuf(), "plt.sca(%s)\n", handle)
+}
+
+// GridSpec creates a grid of nrows×ncols cells for subplots with possibly unequal sizes; e.g. a
+// big main panel with a narrow colorbar or marginal histograms. Subplots are activated with
+// SubplotGS. It returns the name of the Python variable holding the grid
+//  widthRatios  -- relative widths of columns; nil => equal widths
+//  heightRatios -- relative heights of rows; nil => equal heights
+//  wspace       -- horizontal space between cells (fraction of average width); 0 => default
+//  hspace       -- vertical space between cells (fraction of average height); 0 => default
+func (o *Plotter) GridSpec(nrows, ncols int, widthRatios, heightRatios []float64, wspace, hspace float64) (name string) {
+	if nrows < 1 || ncols < 1 {
+		chk.Panic("number of rows and columns of grid must be at least 1. nrows=%d and ncols=%d are invalid", nrows, ncols)
+	}
+	if widthRatios != nil && len(widthRatios) != ncols {
+		chk.Panic("number of width ratios must be equal to the number of columns. %d != %d", len(widthRatios), ncols)
+	}
+	if heightRatios != nil && len(heightRatios) != nrows {
+		chk.Panic("number of height ratios must be equal to the number of rows. %d != %d", len(heightRatios), nrows)
+	}
+	n := o.bufferPy.Len()
+	name = io.Sf("gs%d", n)
+	io.Ff(o.pyBuf(), "%s = plt.GridSpec(%d,%d", name, nrows, ncols)
+	if widthRatios != nil {
+		io.Ff(o.pyBuf(), ",width_ratios=%s", floats2list(widthRatios))
+	}
+	if heightRatios != nil {
+		io.Ff(o.pyBuf(), ",height_ratios=%s", floats2list(heightRatios))
+	}
+	if wspace > 0 {
+		io.Ff(o.pyBuf(), ",wspace=%g", wspace)
+	}
+	if hspace > 0 {
+		io.Ff(o.pyBuf(), ",hspace=%g", hspace)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	o.gridSpecs[name] = []int{nrows, ncols}
+	return
+}
+
+// SubplotGS adds/sets a subplot spanning rows [rowStart, rowEnd) and columns [colStart, colEnd)
+// of the grid gsName created with GridSpec; e.g. SubplotGS(gs, 0, 1, 0, 1) activates the
+// top-left cell
+func (o *Plotter) SubplotGS(gsName string, rowStart, rowEnd, colStart, colEnd int) (err error) {
+	dims, ok := o.gridSpecs[gsName]
+	if !ok {
+		return chk.Err("cannot find grid %q; it must be created with GridSpec", gsName)
+	}
+	if rowStart < 0 || rowEnd > dims[0] || rowStart >= rowEnd {
+		return chk.Err("rows [%d,%d) are invalid for grid with %d rows", rowStart, rowEnd, dims[0])
+	}
+	if colStart < 0 || colEnd > dims[1] || colStart >= colEnd {
+		return chk.Err("columns [%d,%d) are invalid for grid with %d columns", colStart, colEnd, dims[1])
+	}
+	io.Ff(o.pyBuf(), "plt.gcf().add_subplot(%s[%d:%d,%d:%d])\n", gsName, rowStart, rowEnd, colStart, colEnd)
+	return
+}
+
+// SubplotSpan adds/sets a subplot in a grid with shapeRows×shapeCols cells starting at cell
+// (row, col) and spanning rowspan rows and colspan columns. The gaps between cells can be set
+// with SplotGap
+func (o *Plotter) SubplotSpan(shapeRows, shapeCols, row, col, rowspan, colspan int) (err error) {
+	if shapeRows < 1 || shapeCols < 1 {
+		return chk.Err("shape of grid must have at least 1 row and 1 column. (%d,%d) is invalid", shapeRows, shapeCols)
+	}
+	if row < 0 || col < 0 || row >= shapeRows || col >= shapeCols {
+		return chk.Err("cell (%d,%d) is outside the %d×%d grid", row, col, shapeRows, shapeCols)
+	}
+	if rowspan < 1 || colspan < 1 || row+rowspan > shapeRows || col+colspan > shapeCols {
+		return chk.Err("span (%d,%d) starting at cell (%d,%d) does not fit in the %d×%d grid", rowspan, colspan, row, col, shapeRows, shapeCols)
+	}
+	io.Ff(o.pyBuf(), "plt.subplot2grid((%d,%d),(%d,%d),rowspan=%d,colspan=%d)\n", shapeRows, shapeCols, row, col, rowspan, colspan)
+	return
+}
+
+// SubplotShared adds/sets a subplot sharing the x and/or y axes with the first subplot created by
+// SubplotShared in the same i×j grid; thus, panning and limits are consistent. Tick labels along
+// shared axes are hidden for subplots not in the bottom row (x) or not in the first column (y). It
+// returns the name of the Python variable holding the axes (see SetCurrentAxes)
+func (o *Plotter) SubplotShared(i, j, k int, shareX, shareY bool) (name string) {
+	key := io.Sf("%d,%d", i, j)
+	name = io.Sf("axs%d", o.bufferPy.Len())
+	first, ok := o.sharedAxes[key]
+	io.Ff(o.pyBuf(), "%s = plt.subplot(%d,%d,%d", name, i, j, k)
+	if ok {
+		if shareX {
+			io.Ff(o.pyBuf(), ",sharex=%s", first)
+		}
+		if shareY {
+			io.Ff(o.pyBuf(), ",sharey=%s", first)
+		}
+	} else {
+		o.sharedAxes[key] = name
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	row, col := (k-1)/j, (k-1)%j
+	if shareX && row < i-1 {
+		io.Ff(o.pyBuf(), "plt.setp(%s.get_xticklabels(),visible=False)\n", name)
+	}
+	if shareY && col > 0 {
+		io.Ff(o.pyBuf(), "plt.setp(%s.get_yticklabels(),visible=False)\n", name)
+	}
+	return
 }
 
 // SetHspace sets horizontal space between subplots
-func SetHspace(hspace float64) {
-	io.Ff(&bufferPy, "plt.subplots_adjust(hspace=%g)\n", hspace)
+func (o *Plotter) SetHspace(hspace float64) {
+	io.Ff(o.pyBuf(), "plt.subplots_adjust(hspace=%g)\n", hspace)
 }
 
 // SetVspace sets vertical space between subplots
-func SetVspace(vspace float64) {
-	io.Ff(&bufferPy, "plt.subplots_adjust(vspace=%g)\n", vspace)
+func (o *Plotter) SetVspace(vspace float64) {
+	io.Ff(o.pyBuf(), "plt.subplots_adjust(vspace=%g)\n", vspace)
 }
 
 // Equal sets same scale for both axes
-func Equal() {
-	io.Ff(&bufferPy, "plt.axis('equal')\n")
+func (o *Plotter) Equal() {
+	io.Ff(o.pyBuf(), "plt.axis('equal')\n")
+}
+
+// SetAspect sets the aspect ratio (y-unit / x-unit) of the current axes; ratio <= 0 => 'auto'
+func (o *Plotter) SetAspect(ratio float64) {
+	if ratio <= 0 {
+		io.Ff(o.pyBuf(), "plt.gca().set_aspect('auto')\n")
+		return
+	}
+	io.Ff(o.pyBuf(), "plt.gca().set_aspect(%g)\n", ratio)
+}
+
+// InvertXaxis inverts the direction of the x-axis of the current axes
+func (o *Plotter) InvertXaxis() {
+	io.Ff(o.pyBuf(), "plt.gca().invert_xaxis()\n")
+}
+
+// InvertYaxis inverts the direction of the y-axis of the current axes; e.g. for depth increasing downward
+func (o *Plotter) InvertYaxis() {
+	io.Ff(o.pyBuf(), "plt.gca().invert_yaxis()\n")
 }
 
 // AxisOff hides axes
-func AxisOff() {
-	io.Ff(&bufferPy, "plt.axis('off')\n")
+func (o *Plotter) AxisOff() {
+	io.Ff(o.pyBuf(), "plt.axis('off')\n")
 }
 
 // SetAxis sets axes limits
-func SetAxis(xmin, xmax, ymin, ymax float64) {
-	io.Ff(&bufferPy, "plt.axis([%g, %g, %g, %g])\n", xmin, xmax, ymin, ymax)
+func (o *Plotter) SetAxis(xmin, xmax, ymin, ymax float64) {
+	io.Ff(o.pyBuf(), "plt.axis([%s, %s, %s, %s])\n", num(xmin), num(xmax), num(ymin), num(ymax))
 }
 
 // AxisXmin sets minimum x
-func AxisXmin(xmin float64) {
-	io.Ff(&bufferPy, "plt.axis([%g, plt.axis()[1], plt.axis()[2], plt.axis()[3]])\n", xmin)
+func (o *Plotter) AxisXmin(xmin float64) {
+	io.Ff(o.pyBuf(), "plt.axis([%s, plt.axis()[1], plt.axis()[2], plt.axis()[3]])\n", num(xmin))
 }
 
 // AxisXmax sets maximum x
-func AxisXmax(xmax float64) {
-	io.Ff(&bufferPy, "plt.axis([plt.axis()[0], %g, plt.axis()[2], plt.axis()[3]])\n", xmax)
+func (o *Plotter) AxisXmax(xmax float64) {
+	io.Ff(o.pyBuf(), "plt.axis([plt.axis()[0], %s, plt.axis()[2], plt.axis()[3]])\n", num(xmax))
 }
 
 // AxisYmin sets minimum y
-func AxisYmin(ymin float64) {
-	io.Ff(&bufferPy, "plt.axis([plt.axis()[0], plt.axis()[1], %g, plt.axis()[3]])\n", ymin)
+func (o *Plotter) AxisYmin(ymin float64) {
+	io.Ff(o.pyBuf(), "plt.axis([plt.axis()[0], plt.axis()[1], %s, plt.axis()[3]])\n", num(ymin))
 }
 
 // AxisYmax sets maximum y
-func AxisYmax(ymax float64) {
-	io.Ff(&bufferPy, "plt.axis([plt.axis()[0], plt.axis()[1], plt.axis()[2], %g])\n", ymax)
+func (o *Plotter) AxisYmax(ymax float64) {
+	io.Ff(o.pyBuf(), "plt.axis([plt.axis()[0], plt.axis()[1], plt.axis()[2], %s])\n", num(ymax))
 }
 
 // AxisXrange sets x-range (i.e. limits)
-func AxisXrange(xmin, xmax float64) {
-	io.Ff(&bufferPy, "plt.axis([%g, %g, plt.axis()[2], plt.axis()[3]])\n", xmin, xmax)
+func (o *Plotter) AxisXrange(xmin, xmax float64) {
+	io.Ff(o.pyBuf(), "plt.axis([%s, %s, plt.axis()[2], plt.axis()[3]])\n", num(xmin), num(xmax))
 }
 
-// AxisYrange sets y-range (i.e. limits)
-func AxisYrange(ymin, ymax float64) {
-	io.Ff(&bufferPy, "plt.axis([plt.axis()[0], plt.axis()[1], %g, %g])\n", ymin, ymax)
+// AxisYrange sets y-range (i.e. limits). ymin > ymax inverts the y-axis; e.g. for depth
+// increasing downward
+func (o *Plotter) AxisYrange(ymin, ymax float64) {
+	if ymin > ymax {
+		io.Ff(o.pyBuf(), "plt.gca().set_ylim(bottom=%s, top=%s)\n", num(ymin), num(ymax))
+		return
+	}
+	io.Ff(o.pyBuf(), "plt.axis([plt.axis()[0], plt.axis()[1], %s, %s])\n", num(ymin), num(ymax))
 }
 
 // AxisRange sets x and y ranges (i.e. limits)
-func AxisRange(xmin, xmax, ymin, ymax float64) {
-	io.Ff(&bufferPy, "plt.axis([%g, %g, %g, %g])\n", xmin, xmax, ymin, ymax)
+func (o *Plotter) AxisRange(xmin, xmax, ymin, ymax float64) {
+	io.Ff(o.pyBuf(), "plt.axis([%s, %s, %s, %s])\n", num(xmin), num(xmax), num(ymin), num(ymax))
 }
 
 // AxisRange3d sets x, y, and z ranges (i.e. limits)
-func AxisRange3d(xmin, xmax, ymin, ymax, zmin, zmax float64) {
-	io.Ff(&bufferPy, "plt.gca().set_xlim3d(%g,%g)\ngca().set_ylim3d(%g,%g)\ngca().set_zlim3d(%g,%g)\n", xmin, xmax, ymin, ymax, zmin, zmax)
+func (o *Plotter) AxisRange3d(xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	io.Ff(o.pyBuf(), "plt.gca().set_xlim3d(%s,%s)\ngca().set_ylim3d(%s,%s)\ngca().set_zlim3d(%s,%s)\n", num(xmin), num(xmax), num(ymin), num(ymax), num(zmin), num(zmax))
 }
 
 // AxisLims sets x and y limits
-func AxisLims(lims []float64) {
-	io.Ff(&bufferPy, "plt.axis([%g, %g, %g, %g])\n", lims[0], lims[1], lims[2], lims[3])
+func (o *Plotter) AxisLims(lims []float64) {
+	io.Ff(o.pyBuf(), "plt.axis([%s, %s, %s, %s])\n", num(lims[0]), num(lims[1]), num(lims[2]), num(lims[3]))
 }
 
-// Plot plots x-y series
-func Plot(x, y []float64, args *A) (sx, sy string) {
-	n := bufferPy.Len()
+// Plot plots x-y series. Long series are decimated if args.MaxPoints > 0
+func (o *Plotter) Plot(x, y []float64, args *A) (sx, sy string) {
+	if args != nil && args.MaxPoints > 0 {
+		idx := decimateMinMax(x, y, args.MaxPoints)
+		x, y = pickPoints(x, idx), pickPoints(y, idx)
+	}
+	n := o.bufferPy.Len()
 	sx = io.Sf("x%d", n)
 	sy = io.Sf("y%d", n)
-	gen2Arrays(&bufferPy, sx, sy, x, y)
-	io.Ff(&bufferPy, "plt.plot(%s,%s", sx, sy)
-	updateBufferAndClose(&bufferPy, args, false)
+	gen2Arrays(o.pyBuf(), sx, sy, x, y)
+	io.Ff(o.pyBuf(), "plt.plot(%s,%s", sx, sy)
+	updateBufferAndClose(o.pyBuf(), args, false)
 	return
 }
 
 // PlotOne plots one point @ (x,y)
-func PlotOne(x, y float64, args *A) {
-	io.Ff(&bufferPy, "plt.plot(%23.15e,%23.15e", x, y)
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) PlotOne(x, y float64, args *A) {
+	io.Ff(o.pyBuf(), "plt.plot(%s,%s", num(x), num(y))
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// PlotLogX plots x-y series with log scale along x. Points with non-positive x are dropped (or
+// clipped to args.LogFloor if > 0). It returns the number of dropped or clipped points
+func (o *Plotter) PlotLogX(x, y []float64, args *A) (sx, sy string, ndropped int) {
+	xx, yy, ndropped := filterLog(x, y, true, false, args)
+	o.SetXlog()
+	sx, sy = o.Plot(xx, yy, args)
+	return
+}
+
+// PlotLogY plots x-y series with log scale along y. Points with non-positive y are dropped (or
+// clipped to args.LogFloor if > 0). It returns the number of dropped or clipped points
+func (o *Plotter) PlotLogY(x, y []float64, args *A) (sx, sy string, ndropped int) {
+	xx, yy, ndropped := filterLog(x, y, false, true, args)
+	o.SetYlog()
+	sx, sy = o.Plot(xx, yy, args)
+	return
+}
+
+// PlotLogLog plots x-y series with log scales along x and y. Points with non-positive x or y are
+// dropped (or clipped to args.LogFloor if > 0). It returns the number of dropped or clipped points
+func (o *Plotter) PlotLogLog(x, y []float64, args *A) (sx, sy string, ndropped int) {
+	xx, yy, ndropped := filterLog(x, y, true, true, args)
+	o.SetXlog()
+	o.SetYlog()
+	sx, sy = o.Plot(xx, yy, args)
+	return
+}
+
+// PlotWithBand plots x-y series with a shaded band between ylow and yhigh; e.g. to show the
+// uncertainty of results. The band has the same color as the curve with transparency args.Alpha
+// (default 0.3) and hatch pattern args.Hatch and shares the legend entry of the curve
+func (o *Plotter) PlotWithBand(x, y, ylow, yhigh []float64, args *A) (err error) {
+	if len(y) != len(x) || len(ylow) != len(x) || len(yhigh) != len(x) {
+		return chk.Err("the lengths of x, y, ylow and yhigh must be the same. %d, %d, %d, %d", len(x), len(y), len(ylow), len(yhigh))
+	}
+	alpha, line := 0.3, args
+	if args != nil && (args.Alpha > 0 || args.Hatch != "") {
+		if args.Alpha > 0 {
+			alpha = args.Alpha
+		}
+		line = new(A)
+		*line = *args
+		line.Alpha, line.Hatch = 0, "" // for the band only
+	}
+	n := o.bufferPy.Len()
+	sx, sy := io.Sf("x%d", n), io.Sf("y%d", n)
+	slo, shi := io.Sf("ylo%d", n), io.Sf("yhi%d", n)
+	gen2Arrays(o.pyBuf(), sx, sy, x, y)
+	gen2Arrays(o.pyBuf(), slo, shi, ylow, yhigh)
+	io.Ff(o.pyBuf(), "l%d, = plt.plot(%s,%s", n, sx, sy)
+	updateBufferAndClose(o.pyBuf(), line, false)
+	io.Ff(o.pyBuf(), "plt.fill_between(%s,%s,%s,color=l%d.get_color(),alpha=%g,linewidth=0", sx, slo, shi, n, alpha)
+	if args != nil && args.Z > 0 {
+		io.Ff(o.pyBuf(), ",zorder=%d", args.Z)
+	}
+	if args != nil && args.Hatch != "" {
+		io.Ff(o.pyBuf(), ",hatch='%s'", args.Hatch)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	return
+}
+
+// PlotWithStd plots x-y series with a shaded band between y-k*std and y+k*std; e.g. to show the
+// results of Monte Carlo simulations. See PlotWithBand
+func (o *Plotter) PlotWithStd(x, y, std []float64, k float64, args *A) (err error) {
+	if len(y) != len(x) || len(std) != len(x) {
+		return chk.Err("the lengths of x, y and std must be the same. %d, %d, %d", len(x), len(y), len(std))
+	}
+	ylow := make([]float64, len(y))
+	yhigh := make([]float64, len(y))
+	for i := 0; i < len(y); i++ {
+		ylow[i] = y[i] - k*std[i]
+		yhigh[i] = y[i] + k*std[i]
+	}
+	return o.PlotWithBand(x, y, ylow, yhigh, args)
 }
 
-// Hist draws histogram
-func Hist(x [][]float64, labels []string, args *A) {
-	n := bufferPy.Len()
+// Hist draws histogram. The bars of all series are hatched with args.Hatch or those of series i
+// with args.Hhatches[i % len(args.Hhatches)]
+func (o *Plotter) Hist(x [][]float64, labels []string, args *A) {
+	n := o.bufferPy.Len()
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
-	genList(&bufferPy, sx, x)
-	genStrArray(&bufferPy, sy, labels)
-	io.Ff(&bufferPy, "plt.hist(%s,label=%s", sx, sy)
-	updateBufferAndClose(&bufferPy, args, true)
+	genList(o.pyBuf(), sx, x)
+	genStrArray(o.pyBuf(), sy, labels)
+	o.histHandle(n, args)
+	io.Ff(o.pyBuf(), "plt.hist(%s,label=%s", sx, sy)
+	updateBufferAndClose(o.pyBuf(), args, true)
+	o.histHatches(n, len(x), args)
+}
+
+// histHandle starts the command of histogram h{n} with "h{n} = " if the hatch patterns of the
+// series (args.Hhatches) are set afterwards
+func (o *Plotter) histHandle(n int, args *A) {
+	if args != nil && len(args.Hhatches) > 0 {
+		io.Ff(o.pyBuf(), "h%d = ", n)
+	}
+}
+
+// histHatches sets the hatch patterns of the bars of the series of histogram h{n}. The patterns
+// in args.Hhatches are cycled if there are more series
+func (o *Plotter) histHatches(n, nseries int, args *A) {
+	if args == nil || len(args.Hhatches) == 0 {
+		return
+	}
+	if nseries == 1 { // matplotlib returns the bars of the only series
+		io.Ff(o.pyBuf(), "for p in h%d[2]: p.set_hatch('%s')\n", n, args.Hhatches[0])
+		return
+	}
+	for i := 0; i < nseries; i++ {
+		io.Ff(o.pyBuf(), "for p in h%d[2][%d]: p.set_hatch('%s')\n", n, i, args.Hhatches[i%len(args.Hhatches)])
+	}
+}
+
+// HistW draws histogram with weights; e.g. of importance sampling results. w must have the same
+// shape as x
+func (o *Plotter) HistW(x, w [][]float64, labels []string, args *A) (err error) {
+	if len(w) != len(x) {
+		return chk.Err("the number of series of weights must be equal to the number of series of samples. %d != %d", len(w), len(x))
+	}
+	for i := 0; i < len(x); i++ {
+		if len(w[i]) != len(x[i]) {
+			return chk.Err("the number of weights must be equal to the number of samples in series %d. %d != %d", i, len(w[i]), len(x[i]))
+		}
+	}
+	n := o.bufferPy.Len()
+	sx, sw, sy := io.Sf("x%d", n), io.Sf("w%d", n), io.Sf("y%d", n)
+	genList(o.pyBuf(), sx, x)
+	genList(o.pyBuf(), sw, w)
+	genStrArray(o.pyBuf(), sy, labels)
+	o.histHandle(n, args)
+	io.Ff(o.pyBuf(), "plt.hist(%s,weights=%s,label=%s", sx, sw, sy)
+	updateBufferAndClose(o.pyBuf(), args, true)
+	o.histHatches(n, len(x), args)
+	return
+}
+
+// HistLog draws histogram with logarithmic bins between the minimum and maximum of the samples
+// and sets the x-axis to log scale. The number of bins is given by args.Hnbins (default 10).
+// Non-positive samples are dropped with a warning, unless args.Hstrict is true; in which case an
+// error is returned. It returns the edges of the bins
+func (o *Plotter) HistLog(x [][]float64, labels []string, args *A) (edges []float64, err error) {
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	nbins := a.Hnbins
+	if nbins < 1 {
+		nbins = 10
+	}
+	xx := make([][]float64, len(x))
+	ndropped, first := 0, true
+	var xmin, xmax float64
+	for i, series := range x {
+		for _, v := range series {
+			if !(v > 0) {
+				ndropped++
+				continue
+			}
+			xx[i] = append(xx[i], v)
+			if first {
+				xmin, xmax, first = v, v, false
+			}
+			xmin, xmax = math.Min(xmin, v), math.Max(xmax, v)
+		}
+	}
+	if ndropped > 0 {
+		if a.Hstrict {
+			return nil, chk.Err("cannot draw histogram with log bins because there are %d non-positive samples", ndropped)
+		}
+		io.Pfred("HistLog: __WARNING__ %d non-positive samples have been dropped\n", ndropped)
+	}
+	if first {
+		return nil, chk.Err("cannot draw histogram with log bins because there are no positive samples")
+	}
+	edges = logBins(xmin, xmax, nbins)
+	n := o.bufferPy.Len()
+	sx, sy, se := io.Sf("x%d", n), io.Sf("y%d", n), io.Sf("e%d", n)
+	genList(o.pyBuf(), sx, xx)
+	genStrArray(o.pyBuf(), sy, labels)
+	genArray(o.pyBuf(), se, edges)
+	o.histHandle(n, a)
+	io.Ff(o.pyBuf(), "plt.hist(%s,bins=%s,label=%s", sx, se, sy)
+	a.Hnbins = 0
+	updateBufferAndClose(o.pyBuf(), a, true)
+	o.histHatches(n, len(x), a)
+	o.SetXlog()
+	return
 }
 
-// ContourF draws filled contour and possibly with a contour of lines (if args.UnoLines=false)
-func ContourF(x, y, z [][]float64, args *A) {
-	n := bufferPy.Len()
+// logBins computes the edges of nbins logarithmic bins between xmin and xmax (positive). If
+// xmin == xmax, the bins span one decade around xmin
+func logBins(xmin, xmax float64, nbins int) (edges []float64) {
+	lmin, lmax := math.Log10(xmin), math.Log10(xmax)
+	if lmin == lmax {
+		lmin, lmax = lmin-0.5, lmax+0.5
+	}
+	edges = utl.LinSpace(lmin, lmax, nbins+1)
+	for i, l := range edges {
+		edges[i] = math.Pow(10, l)
+	}
+	edges[0], edges[nbins] = math.Min(edges[0], xmin), math.Max(edges[nbins], xmax) // avoid round-off
+	return
+}
+
+// ContourF draws filled contour and possibly with a contour of lines (if args.UnoLines=false).
+// The filled contour is drawn as an image in vector output if args.Rasterized is true
+func (o *Plotter) ContourF(x, y, z [][]float64, args *A) (err error) {
+	a, colors, levels, err := argsContour(args, z)
+	if err != nil {
+		return
+	}
+	n := o.bufferPy.Len()
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sz := io.Sf("z%d", n)
-	genMat(&bufferPy, sx, x)
-	genMat(&bufferPy, sy, y)
-	genMat(&bufferPy, sz, z)
-	a, colors, levels := argsContour(args)
-	io.Ff(&bufferPy, "c%d = plt.contourf(%s,%s,%s%s%s)\n", n, sx, sy, sz, colors, levels)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	io.Ff(o.pyBuf(), "c%d = plt.contourf(%s,%s,%s%s%s", n, sx, sy, sz, colors, levels)
+	if a.Rasterized {
+		io.Ff(o.pyBuf(), ",rasterized=True")
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	o.lastContour = io.Sf("c%d", n)
 	if !a.UnoLines {
-		io.Ff(&bufferPy, "cc%d = plt.contour(%s,%s,%s,colors=['k']%s,linewidths=[%g])\n", n, sx, sy, sz, levels, a.Lw)
+		io.Ff(o.pyBuf(), "cc%d = plt.contour(%s,%s,%s,colors=['k']%s,linewidths=[%g])\n", n, sx, sy, sz, levels, a.Lw)
 		if !a.UnoLabels {
-			io.Ff(&bufferPy, "plt.clabel(cc%d,inline=%d,fontsize=%g)\n", n, pyBool(!a.UnoInline), a.Fsz)
+			io.Ff(o.pyBuf(), "plt.clabel(cc%d,inline=%d,fontsize=%g)\n", n, pyBool(!a.UnoInline), a.Fsz)
 		}
 	}
 	if !a.UnoCbar {
-		io.Ff(&bufferPy, "cb%d = plt.colorbar(c%d, format='%s')\n", n, n, a.UnumFmt)
-		if a.UcbarLbl != "" {
-			io.Ff(&bufferPy, "cb%d.ax.set_ylabel('%s')\n", n, a.UcbarLbl)
-		}
+		io.Ff(o.pyBuf(), "cb%d = plt.colorbar(c%d, format='%s'%s)\n", n, n, a.UnumFmt, argsCbar(a))
+		o.cbarLabel(n, a)
 	}
 	if a.UselectC != "" {
-		io.Ff(&bufferPy, "ccc%d = plt.contour(%s,%s,%s,colors=['%s'],levels=[%g],linewidths=[%g],linestyles=['-'])\n", n, sx, sy, sz, a.UselectC, a.UselectV, a.UselectLw)
+		io.Ff(o.pyBuf(), "ccc%d = plt.contour(%s,%s,%s,colors=['%s'],levels=[%g],linewidths=[%g],linestyles=['-'])\n", n, sx, sy, sz, a.UselectC, a.UselectV, a.UselectLw)
 	}
+	return
 }
 
 // ContourL draws a contour with lines only
-func ContourL(x, y, z [][]float64, args *A) {
-	n := bufferPy.Len()
+func (o *Plotter) ContourL(x, y, z [][]float64, args *A) (err error) {
+	a, colors, levels, err := argsContour(args, z)
+	if err != nil {
+		return
+	}
+	n := o.bufferPy.Len()
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sz := io.Sf("z%d", n)
-	genMat(&bufferPy, sx, x)
-	genMat(&bufferPy, sy, y)
-	genMat(&bufferPy, sz, z)
-	a, colors, levels := argsContour(args)
-	io.Ff(&bufferPy, "c%d = plt.contour(%s,%s,%s%s%s)\n", n, sx, sy, sz, colors, levels)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	io.Ff(o.pyBuf(), "c%d = plt.contour(%s,%s,%s%s%s)\n", n, sx, sy, sz, colors, levels)
+	o.lastContour = io.Sf("c%d", n)
+	if !a.UnoLabels {
+		io.Ff(o.pyBuf(), "plt.clabel(c%d,inline=%d,fontsize=%g)\n", n, pyBool(!a.UnoInline), a.Fsz)
+	}
+	if a.UselectC != "" {
+		io.Ff(o.pyBuf(), "cc%d = plt.contour(%s,%s,%s,colors=['%s'],levels=[%g],linewidths=[%g],linestyles=['-'])\n", n, sx, sy, sz, a.UselectC, a.UselectV, a.UselectLw)
+	}
+	return
+}
+
+// ContourFfromFunc draws filled contour of f(x,y) sampled on a grid with nx×ny points (see
+// ContourF). It returns the grids; e.g. to be used with Quiver
+func (o *Plotter) ContourFfromFunc(xmin, xmax, ymin, ymax float64, nx, ny int, f func(x, y float64) float64, args *A) (X, Y, F [][]float64, err error) {
+	if nx < 2 || ny < 2 {
+		return nil, nil, nil, chk.Err("numbers of points along x and y must be at least 2. nx=%d and ny=%d are invalid", nx, ny)
+	}
+	X, Y, F = utl.MeshGrid2dF(xmin, xmax, ymin, ymax, nx, ny, f)
+	err = o.ContourF(X, Y, F, args)
+	return
+}
+
+// ContourLfromFunc draws contour lines of f(x,y) sampled on a grid with nx×ny points (see
+// ContourL). It returns the grids; e.g. to be used with Quiver
+func (o *Plotter) ContourLfromFunc(xmin, xmax, ymin, ymax float64, nx, ny int, f func(x, y float64) float64, args *A) (X, Y, F [][]float64, err error) {
+	if nx < 2 || ny < 2 {
+		return nil, nil, nil, chk.Err("numbers of points along x and y must be at least 2. nx=%d and ny=%d are invalid", nx, ny)
+	}
+	X, Y, F = utl.MeshGrid2dF(xmin, xmax, ymin, ymax, nx, ny, f)
+	err = o.ContourL(X, Y, F, args)
+	return
+}
+
+// TricontourF draws filled contour of scattered data and possibly with a contour of lines (if
+// args.UnoLines=false). If triangles == nil, the Delaunay triangulation is computed by matplotlib;
+// otherwise, triangles holds the connectivity. See ContourF
+func (o *Plotter) TricontourF(x, y, z []float64, triangles [][]int, args *A) (err error) {
+	a, colors, levels, err := argsContour(args, [][]float64{z})
+	if err != nil {
+		return
+	}
+	n := o.bufferPy.Len()
+	sxyz := o.genTriData(n, x, y, z, triangles)
+	io.Ff(o.pyBuf(), "c%d = plt.tricontourf(%s%s%s)\n", n, sxyz, colors, levels)
+	o.lastContour = io.Sf("c%d", n)
+	if !a.UnoLines {
+		io.Ff(o.pyBuf(), "cc%d = plt.tricontour(%s,colors=['k']%s,linewidths=[%g])\n", n, sxyz, levels, a.Lw)
+		if !a.UnoLabels {
+			io.Ff(o.pyBuf(), "plt.clabel(cc%d,inline=%d,fontsize=%g)\n", n, pyBool(!a.UnoInline), a.Fsz)
+		}
+	}
+	if !a.UnoCbar {
+		io.Ff(o.pyBuf(), "cb%d = plt.colorbar(c%d, format='%s'%s)\n", n, n, a.UnumFmt, argsCbar(a))
+		o.cbarLabel(n, a)
+	}
+	if a.UselectC != "" {
+		io.Ff(o.pyBuf(), "ccc%d = plt.tricontour(%s,colors=['%s'],levels=[%g],linewidths=[%g],linestyles=['-'])\n", n, sxyz, a.UselectC, a.UselectV, a.UselectLw)
+	}
+	return
+}
+
+// TricontourL draws a contour of scattered data with lines only. See TricontourF
+func (o *Plotter) TricontourL(x, y, z []float64, triangles [][]int, args *A) (err error) {
+	a, colors, levels, err := argsContour(args, [][]float64{z})
+	if err != nil {
+		return
+	}
+	n := o.bufferPy.Len()
+	sxyz := o.genTriData(n, x, y, z, triangles)
+	io.Ff(o.pyBuf(), "c%d = plt.tricontour(%s%s%s)\n", n, sxyz, colors, levels)
+	o.lastContour = io.Sf("c%d", n)
 	if !a.UnoLabels {
-		io.Ff(&bufferPy, "plt.clabel(c%d,inline=%d,fontsize=%g)\n", n, pyBool(!a.UnoInline), a.Fsz)
+		io.Ff(o.pyBuf(), "plt.clabel(c%d,inline=%d,fontsize=%g)\n", n, pyBool(!a.UnoInline), a.Fsz)
 	}
 	if a.UselectC != "" {
-		io.Ff(&bufferPy, "cc%d = plt.contour(%s,%s,%s,colors=['%s'],levels=[%g],linewidths=[%g],linestyles=['-'])\n", n, sx, sy, sz, a.UselectC, a.UselectV, a.UselectLw)
+		io.Ff(o.pyBuf(), "cc%d = plt.tricontour(%s,colors=['%s'],levels=[%g],linewidths=[%g],linestyles=['-'])\n", n, sxyz, a.UselectC, a.UselectV, a.UselectLw)
+	}
+	return
+}
+
+// genTriData generates the arrays of scattered data and returns the corresponding arguments of
+// tricontour commands
+func (o *Plotter) genTriData(n int, x, y, z []float64, triangles [][]int) (sxyz string) {
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sz := io.Sf("z%d", n)
+	gen2Arrays(o.pyBuf(), sx, sy, x, y)
+	genArray(o.pyBuf(), sz, z)
+	sxyz = io.Sf("%s,%s,%s", sx, sy, sz)
+	if triangles != nil {
+		st := io.Sf("tri%d", n)
+		genIntMat(o.pyBuf(), st, triangles)
+		sxyz += ",triangles=" + st
 	}
+	return
 }
 
-// Quiver draws vector field
-func Quiver(x, y, gx, gy [][]float64, args *A) {
-	n := bufferPy.Len()
+// Quiver draws vector field. The arrows are colored by their magnitude if args.QbyMag is true.
+// It returns the name of the Python variable holding the quiver; see QuiverKey
+func (o *Plotter) Quiver(x, y, gx, gy [][]float64, args *A) (name string) {
+	n := o.bufferPy.Len()
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sgx := io.Sf("gx%d", n)
 	sgy := io.Sf("gy%d", n)
-	genMat(&bufferPy, sx, x)
-	genMat(&bufferPy, sy, y)
-	genMat(&bufferPy, sgx, gx)
-	genMat(&bufferPy, sgy, gy)
-	io.Ff(&bufferPy, "plt.quiver(%s,%s,%s,%s", sx, sy, sgx, sgy)
-	updateBufferAndClose(&bufferPy, args, false)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sgx, gx)
+	genMat(o.pyBuf(), sgy, gy)
+	name = io.Sf("q%d", n)
+	o.lastQuiver = name
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	if a.QbyMag {
+		io.Ff(o.pyBuf(), "m%d = np.sqrt(%s**2+%s**2)\n", n, sgx, sgy)
+		io.Ff(o.pyBuf(), "q%d = plt.quiver(%s,%s,%s,%s,m%d,cmap=getCmap(%d)", n, sx, sy, sgx, sgy, n, a.UcmapIdx)
+		a.C = ""
+	} else {
+		io.Ff(o.pyBuf(), "q%d = plt.quiver(%s,%s,%s,%s", n, sx, sy, sgx, sgy)
+	}
+	if a.Qscale > 0 {
+		io.Ff(o.pyBuf(), ",scale=%g", a.Qscale)
+	}
+	if a.Qwidth > 0 {
+		io.Ff(o.pyBuf(), ",width=%g", a.Qwidth)
+	}
+	updateBufferAndClose(o.pyBuf(), a, false)
+	if a.QbyMag && !a.UnoCbar {
+		io.Ff(o.pyBuf(), "cb%d = plt.colorbar(q%d%s", n, n, argsCbar(a))
+		if a.UnumFmt != "" {
+			io.Ff(o.pyBuf(), ", format='%s'", a.UnumFmt)
+		}
+		io.Ff(o.pyBuf(), ")\n")
+		o.cbarLabel(n, a)
+	}
+	return
+}
+
+// QuiverKey draws a reference arrow with length scale (in data units) and label for the last
+// Quiver. The position (xFrac,yFrac) is given in axes coordinates. args.C and args.Fsz give
+// the color and font size
+func (o *Plotter) QuiverKey(scale float64, label string, xFrac, yFrac float64, args *A) (err error) {
+	if o.lastQuiver == "" {
+		return chk.Err("QuiverKey requires a previous call to Quiver")
+	}
+	io.Ff(o.pyBuf(), "plt.quiverkey(%s,%g,%g,%g,r'%s',coordinates='axes'", o.lastQuiver, xFrac, yFrac, scale, label)
+	if args != nil {
+		if args.C != "" {
+			io.Ff(o.pyBuf(), ",color='%s'", args.C)
+		}
+		if args.Fsz > 0 {
+			io.Ff(o.pyBuf(), ",fontproperties={'size':%g}", args.Fsz)
+		}
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	return
+}
+
+// Triplot draws 2D triangulation. If triangles == nil, the Delaunay triangulation is computed by
+// matplotlib; otherwise, triangles holds the connectivity
+func (o *Plotter) Triplot(x, y []float64, triangles [][]int, args *A) {
+	n := o.bufferPy.Len()
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	st := io.Sf("tri%d", n)
+	gen2Arrays(o.pyBuf(), sx, sy, x, y)
+	if triangles != nil {
+		genIntMat(o.pyBuf(), st, triangles)
+		io.Ff(o.pyBuf(), "plt.triplot(%s,%s,%s", sx, sy, st)
+	} else {
+		io.Ff(o.pyBuf(), "plt.triplot(%s,%s", sx, sy)
+	}
+	updateBufferAndClose(o.pyBuf(), args, false)
 }
 
 // Grid adds grid to plot
-func Grid(args *A) {
-	io.Ff(&bufferPy, "plt.grid(")
-	updateBufferAndClose(&bufferPy, args, false)
+func (o *Plotter) Grid(args *A) {
+	io.Ff(o.pyBuf(), "plt.grid(")
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// GridMinor turns minor ticks on and adds the minor grid to plot. Defaults: color (C) "grey",
+// line style (Ls) ":" and line width (Lw) 0.5. The transparency is given by args.Alpha
+func (o *Plotter) GridMinor(args *A) {
+	clr, ls, lw := "grey", ":", 0.5
+	if args != nil {
+		if args.C != "" {
+			clr = args.C
+		}
+		if args.Ls != "" {
+			ls = args.Ls
+		}
+		if args.Lw > 0 {
+			lw = args.Lw
+		}
+	}
+	io.Ff(o.pyBuf(), "plt.minorticks_on()\n")
+	io.Ff(o.pyBuf(), "plt.grid(which='minor', color='%s', linestyle='%s', linewidth=%g", clr, ls, lw)
+	if args != nil && args.Alpha > 0 {
+		io.Ff(o.pyBuf(), ", alpha=%g", args.Alpha)
+	}
+	io.Ff(o.pyBuf(), ", zorder=-1000)\n")
 }
 
 // Legend adds legend to plot
-func Legend(args *A) {
+func (o *Plotter) Legend(args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "h%d, l%d = plt.gca().get_legend_handles_labels()\n", n, n)
+	o.genLegend(n, "", args)
+}
+
+// LegendCombined adds one legend with the entries of all axes sharing the position of the current
+// axes; e.g. after DoubleXscale or DoubleYscale, where Legend would only consider the twin axes
+func (o *Plotter) LegendCombined(args *A) {
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "h%d, l%d = [], []\n", n, n)
+	io.Ff(o.pyBuf(), "for a%d in plt.gcf().get_axes():\n", n)
+	io.Ff(o.pyBuf(), "    if a%d.get_position().bounds == plt.gca().get_position().bounds:\n", n)
+	io.Ff(o.pyBuf(), "        hh%d, ll%d = a%d.get_legend_handles_labels()\n", n, n, n)
+	io.Ff(o.pyBuf(), "        h%d += hh%d; l%d += ll%d\n", n, n, n, n)
+	o.genLegend(n, io.Sf("h%d, l%d, ", n, n), args)
+}
+
+// genLegend generates the legend with the handles and labels h<n> and l<n>
+//  handles -- "" => handles are found by plt.legend
+func (o *Plotter) genLegend(n int, handles string, args *A) {
 	loc, ncol, hlen, fsz, frame, out, outX := argsLeg(args)
-	n := bufferPy.Len()
-	io.Ff(&bufferPy, "h%d, l%d = plt.gca().get_legend_handles_labels()\n", n, n)
-	io.Ff(&bufferPy, "if len(h%d) > 0 and len(l%d) > 0:\n", n, n)
+	io.Ff(o.pyBuf(), "if len(h%d) > 0 and len(l%d) > 0:\n", n, n)
 	if out == 1 {
-		io.Ff(&bufferPy, "    d%d = %s\n", n, outX)
-		io.Ff(&bufferPy, "    l%d = plt.legend(bbox_to_anchor=d%d, ncol=%d, handlelength=%g, prop={'size':%g}, loc=3, mode='expand', borderaxespad=0.0, columnspacing=1, handletextpad=0.05)\n", n, n, ncol, hlen, fsz)
-		io.Ff(&bufferPy, "    addToEA(l%d)\n", n)
+		io.Ff(o.pyBuf(), "    d%d = %s\n", n, outX)
+		io.Ff(o.pyBuf(), "    l%d = plt.legend(%sbbox_to_anchor=d%d, ncol=%d, handlelength=%g, prop={'size':%g}, loc=3, mode='expand', borderaxespad=0.0, columnspacing=1, handletextpad=0.05)\n", n, handles, n, ncol, hlen, fsz)
+		io.Ff(o.pyBuf(), "    addToEA(l%d)\n", n)
 	} else {
-		io.Ff(&bufferPy, "    l%d = plt.legend(loc=%s, ncol=%d, handlelength=%g, prop={'size':%g})\n", n, loc, ncol, hlen, fsz)
-		io.Ff(&bufferPy, "    addToEA(l%d)\n", n)
+		io.Ff(o.pyBuf(), "    l%d = plt.legend(%sloc=%s, ncol=%d, handlelength=%g, prop={'size':%g})\n", n, handles, loc, ncol, hlen, fsz)
+		io.Ff(o.pyBuf(), "    addToEA(l%d)\n", n)
 	}
 	if frame == 0 {
-		io.Ff(&bufferPy, "    l%d.get_frame().set_linewidth(0.0)\n", n)
+		io.Ff(o.pyBuf(), "    l%d.get_frame().set_linewidth(0.0)\n", n)
 	}
 }
 
-// Gll adds grid, labels, and legend to plot
-func Gll(xl, yl string, args *A) {
+// Gll adds grid, labels, and legend to plot. Empty labels are skipped. The grid is styled by
+// args.GridC, args.GridLs, args.GridLw and args.GridAlpha, or not drawn if args.NoGrid. The frame
+// borders are hidden as in HideBorders and their colors and linewidths are given by
+// args.BorderCL, args.BorderLwL, etc.
+func (o *Plotter) Gll(xl, yl string, args *A) {
 	hide := getHideList(args)
 	if hide != "" {
-		io.Ff(&bufferPy, "for spine in %s: plt.gca().spines[spine].set_visible(False)\n", hide)
+		io.Ff(o.pyBuf(), "for spine in %s: plt.gca().spines[spine].set_visible(False)\n", hide)
+	}
+	io.Ff(o.pyBuf(), "%s", getBorderStyles(args))
+	if args == nil || !args.NoGrid {
+		clr, extra := "grey", ""
+		if args != nil {
+			if args.GridC != "" {
+				clr = args.GridC
+			}
+			if args.GridLs != "" {
+				extra += io.Sf(", linestyle='%s'", args.GridLs)
+			}
+			if args.GridLw > 0 {
+				extra += io.Sf(", linewidth=%g", args.GridLw)
+			}
+			if args.GridAlpha > 0 && args.GridAlpha <= 1 {
+				extra += io.Sf(", alpha=%g", args.GridAlpha)
+			}
+		}
+		io.Ff(o.pyBuf(), "plt.grid(color='%s'%s, zorder=-1000)\n", clr, extra)
+	}
+	if xl != "" {
+		io.Ff(o.pyBuf(), "plt.xlabel(r'%s')\n", xl)
+	}
+	if yl != "" {
+		io.Ff(o.pyBuf(), "plt.ylabel(r'%s')\n", yl)
 	}
-	io.Ff(&bufferPy, "plt.grid(color='grey', zorder=-1000)\n")
-	io.Ff(&bufferPy, "plt.xlabel(r'%s')\n", xl)
-	io.Ff(&bufferPy, "plt.ylabel(r'%s')\n", yl)
-	Legend(args)
+	o.Legend(args)
 }
 
 // Clf clears current figure
-func Clf() {
-	io.Ff(&bufferPy, "plt.clf()\n")
+func (o *Plotter) Clf() {
+	io.Ff(o.pyBuf(), "plt.clf()\n")
 }
 
-// SetFontSizes sets font sizes
-func SetFontSizes(args *A) {
-	txt, lbl, leg, xtck, ytck := argsFsz(args)
-	io.Ff(&bufferPy, "plt.rcParams.update({\n")
-	io.Ff(&bufferPy, "    'font.size'       : %g,\n", txt)
-	io.Ff(&bufferPy, "    'axes.labelsize'  : %g,\n", lbl)
-	io.Ff(&bufferPy, "    'legend.fontsize' : %g,\n", leg)
-	io.Ff(&bufferPy, "    'xtick.labelsize' : %g,\n", xtck)
-	io.Ff(&bufferPy, "    'ytick.labelsize' : %g})\n", ytck)
+// Cla clears current axes; e.g. to redraw one panel of a figure with subplots
+func (o *Plotter) Cla() {
+	io.Ff(o.pyBuf(), "plt.cla()\n")
+}
+
+// SetFontSizes sets font sizes. They are kept by Reset (see RcConfig)
+func (o *Plotter) SetFontSizes(args *A) {
+	fsz := fontSizes(args)
+	o.rc.FontSizes = fsz[:]
+	o.genFontSizes(o.rc.FontSizes)
+}
+
+// genFontSizes generates the commands to set font sizes: text, label, legend, xticks, yticks
+func (o *Plotter) genFontSizes(fsz []float64) {
+	io.Ff(o.pyBuf(), "plt.rcParams.update({\n")
+	io.Ff(o.pyBuf(), "    'font.size'       : %g,\n", fsz[0])
+	io.Ff(o.pyBuf(), "    'axes.labelsize'  : %g,\n", fsz[1])
+	io.Ff(o.pyBuf(), "    'legend.fontsize' : %g,\n", fsz[2])
+	io.Ff(o.pyBuf(), "    'xtick.labelsize' : %g,\n", fsz[3])
+	io.Ff(o.pyBuf(), "    'ytick.labelsize' : %g})\n", fsz[4])
 }
 
 // 3D /////////////////////////////////////////////////////////////////////////////////////////////
 
-func get3daxes(doInit bool) (n int) {
-	n = bufferPy.Len()
+func (o *Plotter) get3daxes(doInit bool) (n int) {
+	n = o.bufferPy.Len()
 	if doInit {
-		io.Ff(&bufferPy, "ax%d = plt.gcf().add_subplot(111, projection='3d')\n", n)
-		io.Ff(&bufferPy, "ax%d.set_xlabel('x');ax%d.set_ylabel('y');ax%d.set_zlabel('z')\n", n, n, n)
+		io.Ff(o.pyBuf(), "ax%d = plt.gcf().add_subplot(111, projection='3d')\n", n)
+		io.Ff(o.pyBuf(), "ax%d.set_xlabel('x');ax%d.set_ylabel('y');ax%d.set_zlabel('z')\n", n, n, n)
 	} else {
-		io.Ff(&bufferPy, "ax%d = plt.gca()\n", n)
+		io.Ff(o.pyBuf(), "ax%d = plt.gca()\n", n)
 	}
 	return
 }
 
-// Plot3dLine plots 3d line
-func Plot3dLine(x, y, z []float64, doInit bool, args *A) {
-	n := get3daxes(doInit)
+// Plot3dLine plots 3d line. Long lines are decimated (keeping the min/max of z) if args.MaxPoints > 0
+func (o *Plotter) Plot3dLine(x, y, z []float64, doInit bool, args *A) {
+	if args != nil && args.MaxPoints > 0 && len(x) == len(z) && len(y) == len(z) {
+		idx := decimateMinMax(nil, z, args.MaxPoints)
+		x, y, z = pickPoints(x, idx), pickPoints(y, idx), pickPoints(z, idx)
+	}
+	n := o.get3daxes(doInit)
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sz := io.Sf("z%d", n)
-	genArray(&bufferPy, sx, x)
-	genArray(&bufferPy, sy, y)
-	genArray(&bufferPy, sz, z)
-	io.Ff(&bufferPy, "p%d = ax%d.plot(%s,%s,%s", n, n, sx, sy, sz)
-	updateBufferAndClose(&bufferPy, args, false)
+	genArray(o.pyBuf(), sx, x)
+	genArray(o.pyBuf(), sy, y)
+	genArray(o.pyBuf(), sz, z)
+	io.Ff(o.pyBuf(), "p%d = ax%d.plot(%s,%s,%s", n, n, sx, sy, sz)
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// Text3d adds text to the current 3D axes. args.Zdir gives the direction of the text
+func (o *Plotter) Text3d(x, y, z float64, txt string, args *A) {
+	n := o.get3daxes(false)
+	io.Ff(o.pyBuf(), "ax%d.text(%s,%s,%s,%q", n, num(x), num(y), num(z), txt)
+	if args != nil && args.Zdir != "" {
+		io.Ff(o.pyBuf(), ",zdir='%s'", args.Zdir)
+	}
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// Polygons3d draws a collection of 3D polygons (faces); e.g. finite element meshes on surfaces or
+// convex hulls. faces[k] holds the xyz coordinates of the vertices of face k. The colors are
+// given by args.Fc and args.Ec. If doInit is true, the axes are scaled to fit the faces
+func (o *Plotter) Polygons3d(faces [][][]float64, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
+	sf := io.Sf("f%d", n)
+	genPoints3(o.pyBuf(), sf, faces)
+	io.Ff(o.pyBuf(), "pc%d = m3d.art3d.Poly3DCollection(%s", n, sf)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	io.Ff(o.pyBuf(), "ax%d.add_collection3d(pc%d)\n", n, n)
+	if doInit {
+		lims, ok := points3Limits(faces)
+		if ok {
+			io.Ff(o.pyBuf(), "ax%d.auto_scale_xyz([%g,%g],[%g,%g],[%g,%g])\n", n, lims[0], lims[1], lims[2], lims[3], lims[4], lims[5])
+		}
+	}
 }
 
 // Plot3dPoints plots 3d points
-func Plot3dPoints(x, y, z []float64, doInit bool, args *A) {
-	n := get3daxes(doInit)
+func (o *Plotter) Plot3dPoints(x, y, z []float64, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sz := io.Sf("z%d", n)
+	genArray(o.pyBuf(), sx, x)
+	genArray(o.pyBuf(), sy, y)
+	genArray(o.pyBuf(), sz, z)
+	io.Ff(o.pyBuf(), "p%d = ax%d.scatter(%s,%s,%s", n, n, sx, sy, sz)
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// Plot3dPointsC plots 3d points with colors mapped from the values in v. The colormap and limits
+// are given by args.UcmapIdx and args.VminVmax; a colorbar is added unless args.UnoCbar is true.
+// It returns the name of the Python variable holding the scatter object
+func (o *Plotter) Plot3dPointsC(x, y, z, v []float64, doInit bool, args *A) (name string) {
+	n := o.get3daxes(doInit)
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sz := io.Sf("z%d", n)
-	genArray(&bufferPy, sx, x)
-	genArray(&bufferPy, sy, y)
-	genArray(&bufferPy, sz, z)
-	io.Ff(&bufferPy, "p%d = ax%d.scatter(%s,%s,%s", n, n, sx, sy, sz)
-	updateBufferAndClose(&bufferPy, args, false)
+	sv := io.Sf("v%d", n)
+	genArray(o.pyBuf(), sx, x)
+	genArray(o.pyBuf(), sy, y)
+	genArray(o.pyBuf(), sz, z)
+	genArray(o.pyBuf(), sv, v)
+	name = io.Sf("p%d", n)
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	io.Ff(o.pyBuf(), "%s = ax%d.scatter(%s,%s,%s,c=%s,cmap=getCmap(%d)", name, n, sx, sy, sz, sv, a.UcmapIdx)
+	if len(a.VminVmax) == 2 {
+		io.Ff(o.pyBuf(), ",vmin=%g,vmax=%g", a.VminVmax[0], a.VminVmax[1])
+	}
+	if a.Ms > 0 {
+		io.Ff(o.pyBuf(), ",s=%d", a.Ms*a.Ms) // s is the area in points²
+	}
+	if a.Mec != "" {
+		io.Ff(o.pyBuf(), ",edgecolors='%s'", a.Mec)
+	}
+	a.C, a.Ls, a.Ms, a.Me, a.Mec, a.Mew, a.Void, a.Dashes = "", "", 0, 0, "", 0, false, nil // not applicable to scatter
+	updateBufferAndClose(o.pyBuf(), a, false)
+	o.addSurfCbar(n, a)
+	return
 }
 
 // Wireframe draws wireframe
-func Wireframe(x, y, z [][]float64, doInit bool, args *A) {
-	n := get3daxes(doInit)
+func (o *Plotter) Wireframe(x, y, z [][]float64, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sz := io.Sf("z%d", n)
-	genMat(&bufferPy, sx, x)
-	genMat(&bufferPy, sy, y)
-	genMat(&bufferPy, sz, z)
-	io.Ff(&bufferPy, "p%d = ax%d.plot_wireframe(%s,%s,%s", n, n, sx, sy, sz)
-	updateBufferAndClose(&bufferPy, args, false)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	cmap := argsSurfCmap(args)
+	io.Ff(o.pyBuf(), "p%d = ax%d.plot_wireframe(%s,%s,%s%s", n, n, sx, sy, sz, cmap)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	if cmap != "" {
+		io.Ff(o.pyBuf(), "p%d.set_array(np.array([np.mean(s[:,2]) for s in p%d._segments3d]))\n", n, n) // colors by mean z of lines
+		o.addSurfCbar(n, args)
+	}
 }
 
 // Surface draws surface
-func Surface(x, y, z [][]float64, doInit bool, args *A) {
-	n := get3daxes(doInit)
+//  Note: the colormap is used if args.UcmapIdx > 0 or args.VminVmax is given; in this case, a
+//        colorbar is added unless args.UnoCbar is true
+func (o *Plotter) Surface(x, y, z [][]float64, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
 	sx := io.Sf("x%d", n)
 	sy := io.Sf("y%d", n)
 	sz := io.Sf("z%d", n)
-	genMat(&bufferPy, sx, x)
-	genMat(&bufferPy, sy, y)
-	genMat(&bufferPy, sz, z)
-	io.Ff(&bufferPy, "p%d = ax%d.plot_surface(%s,%s,%s", n, n, sx, sy, sz)
-	updateBufferAndClose(&bufferPy, args, false)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	o.plotSurface(n, sx, sy, sz, args)
+}
+
+// plotSurface draws surface p{n} with the given arrays
+func (o *Plotter) plotSurface(n int, sx, sy, sz string, args *A) {
+	cmap := argsSurfCmap(args)
+	io.Ff(o.pyBuf(), "p%d = ax%d.plot_surface(%s,%s,%s%s", n, n, sx, sy, sz, cmap)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	if cmap != "" {
+		o.addSurfCbar(n, args)
+	}
 }
 
-// Camera sets camera in 3d graph
-func Camera(elev, azim float64, args *A) {
-	io.Ff(&bufferPy, "plt.gca().view_init(elev=%g, azim=%g", elev, azim)
-	updateBufferAndClose(&bufferPy, args, false)
+// addSurfCbar adds colorbar to surface or wireframe p{n}. The default shrink and aspect are 0.5
+// and 10
+func (o *Plotter) addSurfCbar(n int, args *A) {
+	if args.UnoCbar {
+		return
+	}
+	a := *args
+	if a.UcbarShrink <= 0 {
+		a.UcbarShrink = 0.5
+	}
+	if a.UcbarAspect <= 0 {
+		a.UcbarAspect = 10
+	}
+	io.Ff(o.pyBuf(), "cb%d = plt.colorbar(p%d%s", n, n, argsCbar(&a))
+	if a.UnumFmt != "" {
+		io.Ff(o.pyBuf(), ", format='%s'", a.UnumFmt)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	o.cbarLabel(n, &a)
 }
 
-// AxDist sets distance in 3d graph
-func AxDist(dist float64) {
-	io.Ff(&bufferPy, "plt.gca().dist = %g\n", dist)
+// cbarLabel sets the label (args.UcbarLbl) of colorbar cb{n} along its long side
+func (o *Plotter) cbarLabel(n int, args *A) {
+	if args.UcbarLbl == "" {
+		return
+	}
+	if args.UcbarOrient == "horizontal" {
+		io.Ff(o.pyBuf(), "cb%d.ax.set_xlabel('%s')\n", n, args.UcbarLbl)
+		return
+	}
+	io.Ff(o.pyBuf(), "cb%d.ax.set_ylabel('%s')\n", n, args.UcbarLbl)
 }
 
-// functions to save figure ///////////////////////////////////////////////////////////////////////
+// SurfaceWithProjections draws surface and the projections of filled contours onto the z pane
+// and, optionally (see args.SprojX and args.SprojY), onto the x and y panes. The offsets of the
+// panes are computed from the ranges of x, y and z. The colormap and levels are given as in ContourF.
+// The transparency of the surface is given by args.Alpha (default 0.3)
+func (o *Plotter) SurfaceWithProjections(x, y, z [][]float64, doInit bool, args *A) (err error) {
+	a, colors, levels, err := argsContour(args, z)
+	if err != nil {
+		return
+	}
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sz := io.Sf("z%d", n)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	cmapIdx := 0
+	if args != nil {
+		cmapIdx = args.UcmapIdx
+	}
+	io.Ff(o.pyBuf(), "p%d = ax%d.plot_surface(%s,%s,%s,cmap=getCmap(%d)", n, n, sx, sy, sz, cmapIdx)
+	if args == nil || args.Alpha <= 0 {
+		io.Ff(o.pyBuf(), ",alpha=0.3")
+	}
+	updateBufferAndClose(o.pyBuf(), args, false)
+	xmin, xmax := matMinMax(x)
+	ymin, ymax := matMinMax(y)
+	zmin, zmax := matMinMax(z)
+	m := a.Smargin
+	if m <= 0 {
+		m = 0.1
+	}
+	xoff := xmin - m*(xmax-xmin)
+	yoff := ymax + m*(ymax-ymin)
+	zoff := zmin - m*(zmax-zmin)
+	io.Ff(o.pyBuf(), "ax%d.contourf(%s,%s,%s,zdir='z',offset=%g%s%s)\n", n, sx, sy, sz, zoff, colors, levels)
+	io.Ff(o.pyBuf(), "ax%d.set_zlim3d(%g,%g)\n", n, zoff, zmax)
+	if a.SprojX {
+		io.Ff(o.pyBuf(), "ax%d.contourf(%s,%s,%s,zdir='x',offset=%g%s%s)\n", n, sx, sy, sz, xoff, colors, levels)
+		io.Ff(o.pyBuf(), "ax%d.set_xlim3d(%g,%g)\n", n, xoff, xmax)
+	}
+	if a.SprojY {
+		io.Ff(o.pyBuf(), "ax%d.contourf(%s,%s,%s,zdir='y',offset=%g%s%s)\n", n, sx, sy, sz, yoff, colors, levels)
+		io.Ff(o.pyBuf(), "ax%d.set_ylim3d(%g,%g)\n", n, ymin, yoff)
+	}
+	return
+}
+
+// SurfaceContourFloor draws surface (see Surface) and the filled contour of z on a floor placed
+// below the surface; at a distance args.Smargin (default 0.1) times the range of z. The z limits
+// are set such that both the surface and the floor are visible. The levels are given as in ContourF
+func (o *Plotter) SurfaceContourFloor(x, y, z [][]float64, doInit bool, args *A) (err error) {
+	var b A
+	if args != nil {
+		b = *args
+	}
+	_, colors, levels, err := argsContour(&b, z)
+	if err != nil {
+		return
+	}
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sz := io.Sf("z%d", n)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	o.plotSurface(n, sx, sy, sz, args)
+	m := b.Smargin
+	if m <= 0 {
+		m = 0.1
+	}
+	zmin, zmax := matMinMax(z)
+	zoff := zmin - m*(zmax-zmin)
+	io.Ff(o.pyBuf(), "ax%d.contourf(%s,%s,%s,zdir='z',offset=%g%s%s)\n", n, sx, sy, sz, zoff, colors, levels)
+	io.Ff(o.pyBuf(), "ax%d.set_zlim3d(%g,%g)\n", n, zoff, zmax)
+	return
+}
+
+// Trisurf draws surface from scattered (unstructured) points. If tri == nil, the Delaunay
+// triangulation is computed by matplotlib; otherwise, tri holds the connectivity of triangles
+func (o *Plotter) Trisurf(x, y, z []float64, tri [][]int, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sz := io.Sf("z%d", n)
+	genArray(o.pyBuf(), sx, x)
+	genArray(o.pyBuf(), sy, y)
+	genArray(o.pyBuf(), sz, z)
+	st := io.Sf("tri%d", n)
+	if tri != nil {
+		genIntMat(o.pyBuf(), st, tri)
+	}
+	io.Ff(o.pyBuf(), "p%d = ax%d.plot_trisurf(%s,%s,%s", n, n, sx, sy, sz)
+	if tri != nil {
+		io.Ff(o.pyBuf(), ",triangles=%s", st)
+	}
+	cmapIdx, aa := 0, true
+	if args != nil {
+		cmapIdx, aa = args.UcmapIdx, !args.SnoAa
+	}
+	if args == nil || args.C == "" {
+		io.Ff(o.pyBuf(), ",cmap=getCmap(%d)", cmapIdx)
+	}
+	io.Ff(o.pyBuf(), ",antialiased=%d", pyBool(aa))
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// Bar3d draws 3D bars with bases at (xpos,ypos,0), sizes dx and dy, and given heights.
+// The colors in args.Colors are cycled over the bars. Shading is on
+func (o *Plotter) Bar3d(xpos, ypos, heights []float64, dx, dy float64, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sh := io.Sf("h%d", n)
+	genArray(o.pyBuf(), sx, xpos)
+	genArray(o.pyBuf(), sy, ypos)
+	genArray(o.pyBuf(), sh, heights)
+	io.Ff(o.pyBuf(), "p%d = ax%d.bar3d(%s,%s,np.zeros(len(%s)),%g,%g,%s,shade=True", n, n, sx, sy, sh, dx, dy, sh)
+	if args != nil && len(args.Colors) > 0 {
+		colors := make([]string, len(heights))
+		for i := 0; i < len(heights); i++ {
+			colors[i] = args.Colors[i%len(args.Colors)]
+		}
+		io.Ff(o.pyBuf(), ",color=%s", strings2list(colors))
+		a := *args
+		a.C, a.Colors = "", nil // replaced by the colors of the bars
+		args = &a
+	}
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// Quiver3d draws vector field in 3d graph. The coordinates (x,y,z) and components (u,v,w) are
+// given as matrices, as in Wireframe or Surface; flattened series can be given as one-row matrices
+func (o *Plotter) Quiver3d(x, y, z, u, v, w [][]float64, doInit bool, args *A) {
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	sy := io.Sf("y%d", n)
+	sz := io.Sf("z%d", n)
+	su := io.Sf("u%d", n)
+	sv := io.Sf("v%d", n)
+	sw := io.Sf("w%d", n)
+	genMat(o.pyBuf(), sx, x)
+	genMat(o.pyBuf(), sy, y)
+	genMat(o.pyBuf(), sz, z)
+	genMat(o.pyBuf(), su, u)
+	genMat(o.pyBuf(), sv, v)
+	genMat(o.pyBuf(), sw, w)
+	io.Ff(o.pyBuf(), "p%d = ax%d.quiver(%s,%s,%s,%s,%s,%s", n, n, sx, sy, sz, su, sv, sw)
+	if args != nil {
+		if args.Qlength > 0 {
+			io.Ff(o.pyBuf(), ",length=%g", args.Qlength)
+		}
+		if args.Qnormalize {
+			io.Ff(o.pyBuf(), ",normalize=True")
+		}
+	}
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// Camera sets camera in 3d graph
+func (o *Plotter) Camera(elev, azim float64, args *A) {
+	io.Ff(o.pyBuf(), "plt.gca().view_init(elev=%g, azim=%g", elev, azim)
+	updateBufferAndClose(o.pyBuf(), args, false)
+}
+
+// SetBoxAspect3d sets the aspect ratio of the box of the current 3D axes; e.g. (1,1,0.5) for a
+// box with half height
+func (o *Plotter) SetBoxAspect3d(ax, ay, az float64) {
+	io.Ff(o.pyBuf(), "plt.gca().set_box_aspect((%g,%g,%g))\n", ax, ay, az)
+}
+
+// AxDist sets distance in 3d graph
+func (o *Plotter) AxDist(dist float64) {
+	io.Ff(o.pyBuf(), "plt.gca().dist = %g\n", dist)
+}
+
+// functions to save figure ///////////////////////////////////////////////////////////////////////
+
+// RcConfig holds the figure size and font sizes set by SetForPng, SetForEps, SetForSvg,
+// SetForPdfTex and SetFontSizes. Reset applies them again; thus, they are not lost when the buffer
+// is reset
+type RcConfig struct {
+	Format    string     // "png", "eps", "svg" or "pdftex"; "" => figure size is not set
+	Prop      float64    // proportion: height = width * Prop
+	Widpt     float64    // width in points
+	Dpi       int        // resolution of PNG figures or of rasterized artists in EPS figures
+	Fonts     [5]float64 // font sizes of SetForPng, SetForEps or SetForSvg: text, label, legend, xticks, yticks
+	FontSizes []float64  // font sizes of the last SetFontSizes (as Fonts); nil => not called
+	TexSystem string     // LaTeX executable of SetForPdfTex
+	TexFamily string     // font family of SetForPdfTex
+}
 
 // SetForPng prepares plot for saving PNG figure
-func SetForPng(prop, widpt float64, dpi int, args *A) {
-	txt, lbl, leg, xtck, ytck := argsFsz(args)
-	Reset()
-	width := widpt / 72.27 // width in inches
-	height := width * prop // height in inches
-	io.Ff(&bufferPy, "plt.rcdefaults()\n")
-	io.Ff(&bufferPy, "plt.rcParams.update({\n")
-	io.Ff(&bufferPy, "    'figure.figsize'  : [%d,%d],\n", int(width), int(height))
-	io.Ff(&bufferPy, "    'savefig.dpi'     : %d,\n", dpi)
-	io.Ff(&bufferPy, "    'font.size'       : %g,\n", txt)
-	io.Ff(&bufferPy, "    'axes.labelsize'  : %g,\n", lbl)
-	io.Ff(&bufferPy, "    'legend.fontsize' : %g,\n", leg)
-	io.Ff(&bufferPy, "    'xtick.labelsize' : %g,\n", xtck)
-	io.Ff(&bufferPy, "    'ytick.labelsize' : %g})\n", ytck)
-}
-
-// SetForEps prepares plot for saving EPS figure
-func SetForEps(prop, widpt float64, args *A) {
-	txt, lbl, leg, xtck, ytck := argsFsz(args)
-	Reset()
-	width := widpt / 72.27 // width in inches
-	height := width * prop // height in inches
-	io.Ff(&bufferPy, "plt.rcdefaults()\n")
-	io.Ff(&bufferPy, "plt.rcParams.update({\n")
-	io.Ff(&bufferPy, "    'figure.figsize'     : [%d,%d],\n", int(width), int(height))
-	io.Ff(&bufferPy, "    'font.size'          : %g,\n", txt)
-	io.Ff(&bufferPy, "    'axes.labelsize'     : %g,\n", lbl)
-	io.Ff(&bufferPy, "    'legend.fontsize'    : %g,\n", leg)
-	io.Ff(&bufferPy, "    'xtick.labelsize'    : %g,\n", xtck)
-	io.Ff(&bufferPy, "    'ytick.labelsize'    : %g,\n", ytck)
-	io.Ff(&bufferPy, "    'backend'            : 'ps',\n")
-	io.Ff(&bufferPy, "    'text.usetex'        : True,\n")  // very IMPORTANT to avoid Type 3 fonts
-	io.Ff(&bufferPy, "    'ps.useafm'          : True,\n")  // very IMPORTANT to avoid Type 3 fonts
-	io.Ff(&bufferPy, "    'pdf.use14corefonts' : True})\n") // very IMPORTANT to avoid Type 3 fonts
-}
-
-// Save saves figure
-func Save(fname string) error {
-	io.Ff(&bufferPy, "plt.savefig(r'%s', bbox_inches='tight', bbox_extra_artists=EXTRA_ARTISTS)\n", fname)
-	return run(fname)
+func (o *Plotter) SetForPng(prop, widpt float64, dpi int, args *A) {
+	o.setRc(RcConfig{Format: "png", Prop: prop, Widpt: widpt, Dpi: dpi, Fonts: fontSizes(args)})
+}
+
+// SetForEps prepares plot for saving EPS figure. Rasterized artists (see A.Rasterized) are drawn
+// with 300 dpi
+func (o *Plotter) SetForEps(prop, widpt float64, args *A) {
+	o.setRc(RcConfig{Format: "eps", Prop: prop, Widpt: widpt, Dpi: 300, Fonts: fontSizes(args)})
+}
+
+// SetForSvg prepares plot for saving SVG figure. Text is kept as text (not paths); thus, it can
+// be edited afterwards
+func (o *Plotter) SetForSvg(prop, widpt float64, args *A) {
+	o.setRc(RcConfig{Format: "svg", Prop: prop, Widpt: widpt, Fonts: fontSizes(args)})
+}
+
+// SetForPdfTex prepares plot for saving PDF figure with the text rendered by LaTeX (pgf backend);
+// thus, the fonts match the ones of the document. The LaTeX executable and font family are given
+// by args.TexSystem (default "pdflatex") and args.TexFamily (default "serif"). Saving fails with
+// an error if the LaTeX executable cannot be found
+func (o *Plotter) SetForPdfTex(prop, widpt float64, args *A) {
+	texSystem, texFamily := "pdflatex", "serif"
+	if args != nil {
+		if args.TexSystem != "" {
+			texSystem = args.TexSystem
+		}
+		if args.TexFamily != "" {
+			texFamily = args.TexFamily
+		}
+	}
+	o.setRc(RcConfig{Format: "pdftex", Prop: prop, Widpt: widpt, Fonts: fontSizes(args), TexSystem: texSystem, TexFamily: texFamily})
+}
+
+// checkTex returns an error if the LaTeX executable required by SetForPdfTex cannot be found
+func (o *Plotter) checkTex() (err error) {
+	if o.rc.Format != "pdftex" {
+		return
+	}
+	_, err = exec.LookPath(o.rc.TexSystem)
+	if err != nil {
+		return chk.Err("cannot find LaTeX executable %q required by SetForPdfTex. Install LaTeX (e.g. TeX Live) or use SetForEps instead:\n%v", o.rc.TexSystem, err)
+	}
+	return
+}
+
+// savefigBackend returns the backend argument of savefig for the given format (extension without
+// dot); i.e. pgf for PDF figures if SetForPdfTex was called
+func (o *Plotter) savefigBackend(format string) string {
+	if o.rc.Format == "pdftex" && strings.ToLower(format) == "pdf" {
+		return ", backend='pgf'"
+	}
+	return ""
+}
+
+// SetRc sets the figure size and font sizes and resets the buffer. nil => matplotlib defaults;
+// e.g. to discard the settings of SetForPng
+func (o *Plotter) SetRc(rc *RcConfig) {
+	if rc == nil {
+		rc = new(RcConfig)
+	}
+	o.setRc(*rc)
+}
+
+// setRc sets rc configuration and resets buffer
+func (o *Plotter) setRc(rc RcConfig) {
+	o.rc = rc
+	if rc.FontSizes != nil {
+		o.rc.FontSizes = append([]float64(nil), rc.FontSizes...)
+	}
+	o.Reset()
+}
+
+// genRc generates the commands of the rc configuration
+func (o *Plotter) genRc() {
+	rc := &o.rc
+	txt, lbl, leg, xtck, ytck := rc.Fonts[0], rc.Fonts[1], rc.Fonts[2], rc.Fonts[3], rc.Fonts[4]
+	width := rc.Widpt / 72.27 // width in inches
+	height := width * rc.Prop // height in inches
+	switch rc.Format {
+	case "png":
+		io.Ff(o.pyBuf(), "plt.rcdefaults()\n")
+		io.Ff(o.pyBuf(), "plt.rcParams.update({\n")
+		io.Ff(o.pyBuf(), "    'figure.figsize'  : [%d,%d],\n", int(width), int(height))
+		io.Ff(o.pyBuf(), "    'savefig.dpi'     : %d,\n", rc.Dpi)
+		io.Ff(o.pyBuf(), "    'font.size'       : %g,\n", txt)
+		io.Ff(o.pyBuf(), "    'axes.labelsize'  : %g,\n", lbl)
+		io.Ff(o.pyBuf(), "    'legend.fontsize' : %g,\n", leg)
+		io.Ff(o.pyBuf(), "    'xtick.labelsize' : %g,\n", xtck)
+		io.Ff(o.pyBuf(), "    'ytick.labelsize' : %g})\n", ytck)
+	case "eps":
+		io.Ff(o.pyBuf(), "plt.rcdefaults()\n")
+		io.Ff(o.pyBuf(), "plt.rcParams.update({\n")
+		io.Ff(o.pyBuf(), "    'figure.figsize'     : [%d,%d],\n", int(width), int(height))
+		if rc.Dpi > 0 {
+			io.Ff(o.pyBuf(), "    'savefig.dpi'        : %d,\n", rc.Dpi)
+		}
+		io.Ff(o.pyBuf(), "    'font.size'          : %g,\n", txt)
+		io.Ff(o.pyBuf(), "    'axes.labelsize'     : %g,\n", lbl)
+		io.Ff(o.pyBuf(), "    'legend.fontsize'    : %g,\n", leg)
+		io.Ff(o.pyBuf(), "    'xtick.labelsize'    : %g,\n", xtck)
+		io.Ff(o.pyBuf(), "    'ytick.labelsize'    : %g,\n", ytck)
+		io.Ff(o.pyBuf(), "    'backend'            : 'ps',\n")
+		io.Ff(o.pyBuf(), "    'text.usetex'        : True,\n")  // very IMPORTANT to avoid Type 3 fonts
+		io.Ff(o.pyBuf(), "    'ps.useafm'          : True,\n")  // very IMPORTANT to avoid Type 3 fonts
+		io.Ff(o.pyBuf(), "    'pdf.use14corefonts' : True})\n") // very IMPORTANT to avoid Type 3 fonts
+	case "svg":
+		io.Ff(o.pyBuf(), "plt.rcdefaults()\n")
+		io.Ff(o.pyBuf(), "plt.rcParams.update({\n")
+		io.Ff(o.pyBuf(), "    'figure.figsize'  : [%g,%g],\n", width, height)
+		io.Ff(o.pyBuf(), "    'font.size'       : %g,\n", txt)
+		io.Ff(o.pyBuf(), "    'axes.labelsize'  : %g,\n", lbl)
+		io.Ff(o.pyBuf(), "    'legend.fontsize' : %g,\n", leg)
+		io.Ff(o.pyBuf(), "    'xtick.labelsize' : %g,\n", xtck)
+		io.Ff(o.pyBuf(), "    'ytick.labelsize' : %g,\n", ytck)
+		io.Ff(o.pyBuf(), "    'svg.fonttype'    : 'none'})\n")
+	case "pdftex":
+		io.Ff(o.pyBuf(), "plt.rcdefaults()\n")
+		io.Ff(o.pyBuf(), "plt.rcParams.update({\n")
+		io.Ff(o.pyBuf(), "    'figure.figsize'  : [%g,%g],\n", width, height)
+		io.Ff(o.pyBuf(), "    'font.size'       : %g,\n", txt)
+		io.Ff(o.pyBuf(), "    'axes.labelsize'  : %g,\n", lbl)
+		io.Ff(o.pyBuf(), "    'legend.fontsize' : %g,\n", leg)
+		io.Ff(o.pyBuf(), "    'xtick.labelsize' : %g,\n", xtck)
+		io.Ff(o.pyBuf(), "    'ytick.labelsize' : %g,\n", ytck)
+		io.Ff(o.pyBuf(), "    'pgf.texsystem'   : '%s',\n", rc.TexSystem)
+		io.Ff(o.pyBuf(), "    'pgf.rcfonts'     : False,\n") // fonts of the LaTeX document
+		io.Ff(o.pyBuf(), "    'font.family'     : '%s'})\n", rc.TexFamily)
+	}
+	if len(rc.FontSizes) == 5 {
+		o.genFontSizes(rc.FontSizes)
+	}
+}
+
+// Figure creates or activates the figure with the given id; e.g. to build several figures
+func (o *Plotter) Figure(id int) {
+	io.Ff(o.pyBuf(), "plt.figure(%d)\n", id)
+}
+
+// CloseFigure closes the figure with the given id
+func (o *Plotter) CloseFigure(id int) {
+	io.Ff(o.pyBuf(), "plt.close(%d)\n", id)
+}
+
+// Save saves figure. If figId is given, the figure with this id is saved; otherwise the current one
+func (o *Plotter) Save(fname string, figId ...int) error {
+	if len(figId) > 0 {
+		o.Figure(figId[0])
+	}
+	return o.SaveA(fname, nil)
+}
+
+// SaveArgs holds options to save figures. See SaveA
+type SaveArgs struct {
+	Dpi         int     // resolution in dots per inch; 0 => default
+	Transparent bool    // transparent background; e.g. for slides
+	Facecolor   string  // background color; "" => default
+	PadInches   float64 // padding around the figure when cropping (inches); 0 => default
+	Crop        bool    // crop to the tight bounding box, including the extra artists (as done by Save)
+}
+
+// SaveA saves the current figure with the given options. args == nil => same as Save
+func (o *Plotter) SaveA(fname string, args *SaveArgs) (err error) {
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	o.saveFig(fname, args)
+	return o.run(fname)
+}
+
+// SaveCtx saves figure (see Save). The Python process is killed if the context is done before
+// Python finishes; e.g. ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+func (o *Plotter) SaveCtx(ctx context.Context, fname string) (err error) {
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	o.saveFig(fname, nil)
+	return o.runCtx(ctx, fname)
+}
+
+// SaveFigures saves the figures with the given ids to files in dirout with one call to Python
+func (o *Plotter) SaveFigures(dirout string, figIds []int, fnames []string) (err error) {
+	if len(figIds) != len(fnames) {
+		return chk.Err("the number of figure ids must be equal to the number of file names. %d != %d", len(figIds), len(fnames))
+	}
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	if dirout != "" {
+		err = os.MkdirAll(dirout, 0777)
+		if err != nil {
+			return chk.Err("cannot create directory to save figure files:\n%v\n", err)
+		}
+	}
+	fns := make([]string, len(fnames))
+	for i, id := range figIds {
+		fns[i] = filepath.Join(dirout, fnames[i])
+		o.Figure(id)
+		o.saveFig(fns[i], nil)
+	}
+	err = o.run("")
+	if err != nil {
+		return
+	}
+	for _, fn := range fns {
+		io.Pf("file <%s> written\n", fn)
+	}
+	return
+}
+
+// payloadMark delimits the (base64 encoded) image printed by Python in SaveToWriter
+const payloadMark = "<<<GOSL-PAYLOAD>>>"
+
+// SaveToWriter renders the current figure in the given format (e.g. "png", "svg" or "pdf") and
+// writes the image to w; e.g. for web services. The image is sent back by Python through the
+// standard output; thus, no image file is created
+func (o *Plotter) SaveToWriter(w goio.Writer, format string) (err error) {
+	if format == "" {
+		return chk.Err("format of image must be given; e.g. \"png\"")
+	}
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	nbuf := o.bufferPy.Len()
+	defer o.bufferPy.Truncate(nbuf)
+	o.tightLayout()
+	io.Ff(o.pyBuf(), "import io as pyio, base64\n")
+	io.Ff(o.pyBuf(), "payload = pyio.BytesIO()\n")
+	io.Ff(o.pyBuf(), "plt.savefig(payload, format='%s'%s%s)\n", format, savefigArgs(&SaveArgs{Crop: !o.layout.NoBboxTight}), o.savefigBackend(format))
+	io.Ff(o.pyBuf(), "print('%s' + base64.b64encode(payload.getvalue()).decode('ascii') + '%s')\n", payloadMark, payloadMark)
+	out, err := o.runPy(context.Background())
+	if err != nil {
+		return
+	}
+	i := strings.Index(out, payloadMark)
+	j := strings.LastIndex(out, payloadMark)
+	if i < 0 || j <= i {
+		return chk.Err("cannot find image in the output of Python:\n%s", out)
+	}
+	b, err := base64.StdEncoding.DecodeString(out[i+len(payloadMark) : j])
+	if err != nil {
+		return chk.Err("cannot decode image sent by Python:\n%v", err)
+	}
+	io.Pf("%s", out[:i]+strings.TrimPrefix(out[j+len(payloadMark):], "\n"))
+	_, err = w.Write(b)
+	if err != nil {
+		return chk.Err("cannot write image:\n%v", err)
+	}
+	return
+}
+
+// SaveMulti saves the current figure to several files with one call to Python; e.g. a PNG for
+// quick viewing and a PDF for the paper. The formats are given by the extensions. If some files
+// cannot be saved, the others are still saved and the error lists the failed files
+func (o *Plotter) SaveMulti(fnames []string) (err error) {
+	if len(fnames) < 1 {
+		return chk.Err("at least one file name must be given")
+	}
+	for _, fn := range fnames {
+		if filepath.Ext(fn) == "" {
+			return chk.Err("file name %q must have an extension to infer the format", fn)
+		}
+	}
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	o.tightLayout()
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "failed%d = []\n", n)
+	for _, fn := range fnames {
+		io.Ff(o.pyBuf(), "try: %s\n", o.savefigCmd(fn, nil))
+		io.Ff(o.pyBuf(), "except Exception as e: failed%d.append(r'%s: ' + str(e))\n", n, fn)
+	}
+	io.Ff(o.pyBuf(), "if len(failed%d) > 0: raise RuntimeError('cannot save files:\\n' + '\\n'.join(failed%d))\n", n, n)
+	err = o.run("")
+	if err != nil {
+		return
+	}
+	for _, fn := range fnames {
+		io.Pf("file <%s> written\n", fn)
+	}
+	return
+}
+
+// saveFig adds the command to save the current figure with the extra artists of this figure.
+// args == nil => cropped figure unless disabled by SetLayout
+func (o *Plotter) saveFig(fname string, args *SaveArgs) {
+	o.tightLayout()
+	if strings.ToLower(filepath.Ext(fname)) == ".svg" {
+		io.Ff(o.pyBuf(), "plt.rcParams['svg.fonttype'] = 'none'\n")
+	}
+	io.Ff(o.pyBuf(), "%s\n", o.savefigCmd(fname, args))
+}
+
+// tightLayout adds the call to tight_layout if requested by SetLayout
+func (o *Plotter) tightLayout() {
+	if o.layout.Tight {
+		io.Ff(o.pyBuf(), "plt.tight_layout(")
+		l := ""
+		addToCmd(&l, o.layout.Pad > 0, io.Sf("pad=%g", o.layout.Pad))
+		addToCmd(&l, o.layout.Wpad > 0, io.Sf("w_pad=%g", o.layout.Wpad))
+		addToCmd(&l, o.layout.Hpad > 0, io.Sf("h_pad=%g", o.layout.Hpad))
+		io.Ff(o.pyBuf(), "%s)\n", l)
+	}
+}
+
+// savefigCmd returns the savefig command. args == nil => cropped figure unless disabled by SetLayout
+func (o *Plotter) savefigCmd(fname string, args *SaveArgs) (l string) {
+	if args == nil {
+		args = &SaveArgs{Crop: !o.layout.NoBboxTight}
+	}
+	return io.Sf("plt.savefig(r'%s'%s%s)", fname, savefigArgs(args), o.savefigBackend(strings.TrimPrefix(filepath.Ext(fname), ".")))
+}
+
+// savefigArgs returns the keyword arguments of savefig
+func savefigArgs(args *SaveArgs) (l string) {
+	if args.Crop {
+		l += ", bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf())"
+		if args.PadInches > 0 {
+			l += io.Sf(", pad_inches=%g", args.PadInches)
+		}
+	}
+	if args.Dpi > 0 {
+		l += io.Sf(", dpi=%d", args.Dpi)
+	}
+	if args.Transparent {
+		l += ", transparent=True"
+	}
+	if args.Facecolor != "" {
+		l += io.Sf(", facecolor='%s'", args.Facecolor)
+	}
+	return
+}
+
+// Layout holds options to arrange the subplots when saving figures. See SetLayout
+type Layout struct {
+	Tight       bool    // call tight_layout before saving
+	Pad         float64 // tight layout: padding around the figure (fraction of font size); 0 => default
+	Wpad        float64 // tight layout: horizontal padding between subplots; 0 => default
+	Hpad        float64 // tight layout: vertical padding between subplots; 0 => default
+	Constrained bool    // use constrained layout (set in rcParams before creating figures)
+	NoBboxTight bool    // do not use bbox_inches='tight'; e.g. to keep the size of figures for publications
+}
+
+// SetLayout sets the options to arrange subplots when saving figures; e.g. to avoid collisions of
+// long labels. nil => default (bbox_inches='tight' only). Reset does not change these options
+func (o *Plotter) SetLayout(l *Layout) {
+	o.layout = Layout{}
+	if l != nil {
+		o.layout = *l
+	}
 }
 
 // SaveD saves figure after creating a directory
-func SaveD(dirout, fname string) (err error) {
+func (o *Plotter) SaveD(dirout, fname string) (err error) {
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
 	err = os.MkdirAll(dirout, 0777)
 	if err != nil {
 		return chk.Err("cannot create directory to save figure file:\n%v\n", err)
 	}
 	fn := filepath.Join(dirout, fname)
-	io.Ff(&bufferPy, "plt.savefig(r'%s', bbox_inches='tight', bbox_extra_artists=EXTRA_ARTISTS)\n", fn)
-	return run(fn)
+	o.saveFig(fn, nil)
+	return o.run(fn)
 }
 
 // Show shows figure
-func Show() error {
-	io.Ff(&bufferPy, "plt.show()\n")
-	return run("")
+func (o *Plotter) Show() error {
+	io.Ff(o.pyBuf(), "plt.show()\n")
+	return o.run("")
+}
+
+// ShowNonBlocking shows figure without blocking and waits pause seconds before closing the
+// window; e.g. for quick previews in scripts
+func (o *Plotter) ShowNonBlocking(pause float64) error {
+	io.Ff(o.pyBuf(), "plt.show(block=False)\n")
+	io.Ff(o.pyBuf(), "plt.pause(%g)\n", pause)
+	return o.run("")
+}
+
+// ShowAndSave saves figure and then shows it with one call to Python
+func (o *Plotter) ShowAndSave(fname string) (err error) {
+	_, err = CheckBackend()
+	if err != nil {
+		return
+	}
+	o.saveFig(fname, nil)
+	io.Ff(o.pyBuf(), "plt.show()\n")
+	return o.run(fname)
+}
+
+// QueryLimits runs the current script without saving or showing the figure and returns the limits
+// computed by matplotlib for the current axes; e.g. after autoscaling. The buffer is restored,
+// thus Save can be called afterwards
+func (o *Plotter) QueryLimits() (xmin, xmax, ymin, ymax float64, err error) {
+	fnout, err := o.newTempFile("pltgosl-*.json")
+	if err != nil {
+		return
+	}
+	defer o.removeTempFile(fnout)
+	nbuf := o.bufferPy.Len()
+	defer o.bufferPy.Truncate(nbuf)
+	io.Ff(o.pyBuf(), "import json\n")
+	io.Ff(o.pyBuf(), "with open(r'%s', 'w') as f: json.dump([float(v) for v in plt.axis()], f)\n", fnout)
+	err = o.run("")
+	if err != nil {
+		return
+	}
+	b, err := io.ReadFile(fnout)
+	if err != nil {
+		return 0, 0, 0, 0, chk.Err("cannot read limits from Python:\n%v", err)
+	}
+	var lims []float64
+	err = json.Unmarshal(b, &lims)
+	if err != nil || len(lims) != 4 {
+		return 0, 0, 0, 0, chk.Err("cannot parse limits from Python: %q\n%v", string(b), err)
+	}
+	return lims[0], lims[1], lims[2], lims[3], nil
 }
 
 // generate arrays and matrices ///////////////////////////////////////////////////////////////////
 
+// floatFmt holds the format of floating point numbers set by SetFloatFmt
+var floatFmt atomic.Value
+
+// SetFloatFmt sets the format of floating point numbers in the generated arrays and coordinates;
+// e.g. "%.17g", or "%.6g" to make scripts of large figures smaller. "" => "%g", i.e. the shortest
+// representation that is read back as the same number. NaN and ±Inf are written as np.nan and
+// ±np.inf regardless of the format. The format is shared by all Plotters
+func SetFloatFmt(format string) {
+	if format != "" {
+		if _, err := strconv.ParseFloat(io.Sf(format, 1.5), 64); err != nil {
+			chk.Panic("format of floating point numbers %q is invalid", format)
+		}
+	}
+	floatFmt.Store(format)
+}
+
+// num formats floating point number for Python; see SetFloatFmt
+func num(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "np.nan"
+	case math.IsInf(v, 1):
+		return "np.inf"
+	case math.IsInf(v, -1):
+		return "-np.inf"
+	}
+	format, _ := floatFmt.Load().(string)
+	if format == "" {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return io.Sf(format, v)
+}
+
 // genMat generates matrix
 func genMat(buf *bytes.Buffer, name string, a [][]float64) {
 	io.Ff(buf, "%s=np.array([", name)
 	for i, _ := range a {
 		io.Ff(buf, "[")
 		for j, _ := range a[i] {
-			io.Ff(buf, "%g,", a[i][j])
+			io.Ff(buf, "%s,", num(a[i][j]))
 		}
 		io.Ff(buf, "],")
 	}
 	io.Ff(buf, "],dtype=float)\n")
 }
 
+// genIntMat generates matrix of integers
+func genIntMat(buf *bytes.Buffer, name string, a [][]int) {
+	io.Ff(buf, "%s=np.array([", name)
+	for i, _ := range a {
+		io.Ff(buf, "[")
+		for j, _ := range a[i] {
+			io.Ff(buf, "%d,", a[i][j])
+		}
+		io.Ff(buf, "],")
+	}
+	io.Ff(buf, "],dtype=int)\n")
+}
+
 // genList generates list
 func genList(buf *bytes.Buffer, name string, a [][]float64) {
 	io.Ff(buf, "%s=[", name)
 	for i, _ := range a {
 		io.Ff(buf, "[")
 		for j, _ := range a[i] {
-			io.Ff(buf, "%g,", a[i][j])
+			io.Ff(buf, "%s,", num(a[i][j]))
+		}
+		io.Ff(buf, "],")
+	}
+	io.Ff(buf, "]\n")
+}
+
+// genPoints3 generates list of lists of 3D points; e.g. faces of polygons
+func genPoints3(buf *bytes.Buffer, name string, a [][][]float64) {
+	io.Ff(buf, "%s=[", name)
+	for i := range a {
+		io.Ff(buf, "[")
+		for j := range a[i] {
+			io.Ff(buf, "[")
+			for k := range a[i][j] {
+				io.Ff(buf, "%s,", num(a[i][j][k]))
+			}
+			io.Ff(buf, "],")
 		}
 		io.Ff(buf, "],")
 	}
 	io.Ff(buf, "]\n")
 }
 
+// points3Limits computes the limits [xmin,xmax, ymin,ymax, zmin,zmax] of lists of 3D points
+func points3Limits(a [][][]float64) (lims []float64, ok bool) {
+	for i := range a {
+		for _, p := range a[i] {
+			if len(p) < 3 {
+				continue
+			}
+			if !ok {
+				lims, ok = []float64{p[0], p[0], p[1], p[1], p[2], p[2]}, true
+			}
+			for d := 0; d < 3; d++ {
+				lims[2*d] = math.Min(lims[2*d], p[d])
+				lims[2*d+1] = math.Max(lims[2*d+1], p[d])
+			}
+		}
+	}
+	return
+}
+
 // genArray generates the NumPy text corresponding to an array of float point numbers
 func genArray(buf *bytes.Buffer, name string, u []float64) {
 	io.Ff(buf, "%s=np.array([", name)
 	for i, _ := range u {
-		io.Ff(buf, "%g,", u[i])
+		io.Ff(buf, "%s,", num(u[i]))
 	}
 	io.Ff(buf, "],dtype=float)\n")
 }
@@ -640,16 +2090,137 @@ func genStrArray(buf *bytes.Buffer, name string, u []string) {
 	io.Ff(buf, "]\n")
 }
 
+// filterLog removes (or clips) the non-positive values of x and/or y to be used in log plots
+func filterLog(x, y []float64, logx, logy bool, args *A) (xx, yy []float64, ndropped int) {
+	floor := 0.0
+	if args != nil {
+		floor = args.LogFloor
+	}
+	n := utl.Imin(len(x), len(y))
+	xx = make([]float64, 0, n)
+	yy = make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		xi, yi := x[i], y[i]
+		badx := logx && !(xi > 0)
+		bady := logy && !(yi > 0)
+		if badx || bady {
+			ndropped++
+			if floor <= 0 {
+				continue
+			}
+			if badx {
+				xi = floor
+			}
+			if bady {
+				yi = floor
+			}
+		}
+		xx = append(xx, xi)
+		yy = append(yy, yi)
+	}
+	return
+}
+
 // call Python ////////////////////////////////////////////////////////////////////////////////////
 
+// Script returns the Python script written by Save, Show, etc.; i.e. the header, the setup commands
+// (see EaCmds) and the plotting commands. The savefig or show commands are added by Save or Show.
+// The selection of the Agg backend on machines without display (see CheckBackend) is added only
+// when Python is called; thus, the script does not depend on the current machine
+func (o *Plotter) Script() string {
+	return o.scriptPrefix() + o.bufferPy.String()
+}
+
+// scriptPrefix returns the part of the Python script before the commands in bufferPy
+func (o *Plotter) scriptPrefix() string {
+	var b bytes.Buffer
+	b.Write(o.bufferEa.Bytes())
+	if o.layout.Constrained {
+		io.Ff(&b, "plt.rcParams['figure.constrained_layout.use'] = True\n")
+	}
+	return b.String()
+}
+
+// ExportPy writes the Python script (see Script) to fname without calling Python; e.g. to debug
+// or to run the script on a machine with matplotlib
+func (o *Plotter) ExportPy(fname string) (err error) {
+	err = ioutil.WriteFile(fname, []byte(o.Script()), 0644)
+	if err != nil {
+		return chk.Err("cannot write Python script:\n%v", err)
+	}
+	return
+}
+
 // run calls Python to generate plot
-func run(fn string) (err error) {
+func (o *Plotter) run(fn string) (err error) {
+	return o.runCtx(context.Background(), fn)
+}
+
+// runCtx calls Python to generate plot. The Python process is killed if ctx is done
+func (o *Plotter) runCtx(ctx context.Context, fn string) (err error) {
+	out, err := o.runPy(ctx)
+	if err != nil {
+		return
+	}
+
+	// show filename
+	if fn != "" {
+		io.Pf("file <%s> written\n", fn)
+	}
+
+	// show output
+	io.Pf("%s", out)
+	return
+}
+
+// runPy runs the script with the worker started by StartWorker or, otherwise, writes the script
+// and calls Python. It returns the output of Python without printing it
+func (o *Plotter) runPy(ctx context.Context) (output string, err error) {
+
+	// LaTeX is required by SetForPdfTex; thus, avoid the long traceback of matplotlib
+	err = o.checkTex()
+	if err != nil {
+		return
+	}
+
+	// backend
+	info, err := CheckBackend()
+	if err != nil {
+		return
+	}
+	prefix := o.scriptPrefix()
+	if info.UseAgg {
+		prefix = pythonAgg + prefix
+	}
+
+	// long-lived Python process
+	nskip := strings.Count(prefix, "\n")
+	r, used, err := runWorker(ctx, prefix+o.bufferPy.String())
+	if used {
+		if err != nil {
+			return
+		}
+		if !r.Ok {
+			return "", o.pyError(r.Err, workerFile, nskip)
+		}
+		return r.Out, nil
+	}
 
 	// write file
-	io.WriteFile(TEMPORARY, &bufferEa, &bufferPy)
+	fn, err := o.newTempFile("pltgosl-*.py")
+	if err != nil {
+		return
+	}
+	defer o.removeTempFile(fn)
+	err = ioutil.WriteFile(fn, []byte(prefix+o.bufferPy.String()), 0644)
+	if err != nil {
+		return "", chk.Err("cannot write Python script:\n%v", err)
+	}
 
 	// set command
-	cmd := exec.Command("python", TEMPORARY)
+	python, env, _ := pythonSettings()
+	cmd := exec.CommandContext(ctx, python, fn)
+	cmd.Env = env
 	var out, serr bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &serr
@@ -657,19 +2228,41 @@ func run(fn string) (err error) {
 	// call Python
 	err = cmd.Run()
 	if err != nil {
-		return chk.Err("call to Python failed:\n%v\n", serr.String())
+		if cmd.ProcessState == nil { // not started
+			return "", chk.Err("cannot run Python command %q:\n%v\n", python, err)
+		}
+		if ctx.Err() != nil {
+			return "", chk.Err("call to Python was stopped (%v); e.g. by timeout. stderr ends with:\n%v\n", ctx.Err(), tailLines(serr.String(), 10))
+		}
+		return "", o.pyError(serr.String(), fn, nskip)
 	}
+	return out.String(), nil
+}
 
-	// show filename
-	if fn != "" {
-		io.Pf("file <%s> written\n", fn)
+// pyError returns the error of a failed call to Python, including the Go call that generated the
+// line in the traceback (stderr). See pyTracebackOrigin
+func (o *Plotter) pyError(stderr, fn string, nskip int) error {
+	if origin := o.pyTracebackOrigin(stderr, fn, nskip); origin != "" {
+		return chk.Err("call to Python failed:\n%v\ngenerated by %s\n", stderr, origin)
 	}
+	return chk.Err("call to Python failed:\n%v\n", stderr)
+}
 
-	// show output
-	io.Pf("%s", out.String())
-	return
+// tailLines returns the last n lines of txt
+func tailLines(txt string, n int) string {
+	lines := strings.Split(strings.TrimRight(txt, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
 }
 
+// pythonAgg selects the Agg backend on machines without display (see CheckBackend)
+const pythonAgg = "import matplotlib\nmatplotlib.use('Agg')\n"
+
+// number of colormaps in COLORMAPS of pythonHeader
+const numDefaultCmaps = 7
+
 const pythonHeader = `### file generated by Gosl #################################################
 import numpy as np
 import matplotlib.pyplot as plt
@@ -678,10 +2271,14 @@ import matplotlib.patches as pat
 import matplotlib.path as pth
 import matplotlib.patheffects as pff
 import matplotlib.lines as lns
+import matplotlib.dates as mdt
+import matplotlib.colors as mcl
 import mpl_toolkits.mplot3d as m3d
+NaN, Inf = np.nan, np.inf # as printed by Go
 EXTRA_ARTISTS = []
 def addToEA(obj):
     if obj!=None: EXTRA_ARTISTS.append(obj)
+def eaOf(fig): return [a for a in EXTRA_ARTISTS if getattr(a, 'figure', fig) in (fig, None)]
 COLORMAPS = [plt.cm.bwr, plt.cm.RdBu, plt.cm.hsv, plt.cm.jet, plt.cm.terrain, plt.cm.pink, plt.cm.Greys]
 def getCmap(idx): return COLORMAPS[idx %% len(COLORMAPS)]
 `
@@ -0,0 +1,871 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+	"context"
+	goio "io"
+	"time"
+)
+
+// Plotter holds the Python commands of a figure (or of a set of figures) and the options to run
+// Python. Each Plotter has its own buffers; thus, different goroutines can build and save figures
+// at the same time with different Plotters. The methods of one Plotter must not be called
+// concurrently. The package-level functions (Plot, Save, ...) call the methods of a default Plotter
+type Plotter struct {
+	bufferPy      bytes.Buffer      // buffer holding Python commands
+	bufferEa      bytes.Buffer      // buffer holding Python extra artists commands
+	pyOrigins     []pyOrigin        // origins of the commands in bufferPy, sorted by position
+	lastQuiver    string            // name of the Python variable holding the result of the last Quiver call
+	lastContour   string            // name of the Python variable holding the last contour; see LegendX
+	gridSpecs     map[string][]int  // dimensions (nrows, ncols) of grids created with GridSpec
+	sharedAxes    map[string]string // first axes created by SubplotShared for each grid "i,j"
+	nCustomCmaps  int               // number of colormaps defined by DefineColormap
+	layout        Layout            // options set by SetLayout
+	rc            RcConfig          // figure and font sizes applied by Reset; see SetRc
+	tempDir       string            // directory of temporary files; "" => os.TempDir()
+	keepTempFiles bool              // temporary files must not be removed
+}
+
+// NewPlotter returns a new Plotter with empty buffers
+func NewPlotter() (o *Plotter) {
+	o = new(Plotter)
+	o.Reset()
+	return
+}
+
+// defaultPlotter is used by the package-level functions
+var defaultPlotter = NewPlotter()
+
+// Animate calls Animate of the default Plotter
+func Animate(nframes int, fps int, fname string, frame func(i int)) (err error) {
+	return defaultPlotter.Animate(nframes, fps, fname, frame)
+}
+
+// SetTempDir calls SetTempDir of the default Plotter
+func SetTempDir(dir string) {
+	defaultPlotter.SetTempDir(dir)
+}
+
+// SetKeepTempFiles calls SetKeepTempFiles of the default Plotter
+func SetKeepTempFiles(keep bool) {
+	defaultPlotter.SetKeepTempFiles(keep)
+}
+
+// BoxplotStats calls BoxplotStats of the default Plotter
+func BoxplotStats(stats []BoxStats, labels []string, args *A) (err error) {
+	return defaultPlotter.BoxplotStats(stats, labels, args)
+}
+
+// Bubble calls Bubble of the default Plotter
+func Bubble(x, y, sizes []float64, args *A) (err error) {
+	return defaultPlotter.Bubble(x, y, sizes, args)
+}
+
+// BubbleLegend calls BubbleLegend of the default Plotter
+func BubbleLegend(sizes []float64, numFmt string, args *A) {
+	defaultPlotter.BubbleLegend(sizes, numFmt, args)
+}
+
+// AngleDim calls AngleDim of the default Plotter
+func AngleDim(xc, yc, r, alphaDeg, betaDeg float64, label string, args *A) {
+	defaultPlotter.AngleDim(xc, yc, r, alphaDeg, betaDeg, label, args)
+}
+
+// LinearDim calls LinearDim of the default Plotter
+func LinearDim(x1, y1, x2, y2, offset float64, label string, args *A) {
+	defaultPlotter.LinearDim(x1, y1, x2, y2, offset, label, args)
+}
+
+// AutoScale calls AutoScale of the default Plotter
+func AutoScale(P [][]float64) {
+	defaultPlotter.AutoScale(P)
+}
+
+// Arrow calls Arrow of the default Plotter
+func Arrow(xi, yi, xf, yf float64, args *A) {
+	defaultPlotter.Arrow(xi, yi, xf, yf, args)
+}
+
+// Circle calls Circle of the default Plotter
+func Circle(xc, yc, r float64, args *A) {
+	defaultPlotter.Circle(xc, yc, r, args)
+}
+
+// Ellipse calls Ellipse of the default Plotter
+func Ellipse(xc, yc, rx, ry, angleDeg float64, args *A) {
+	defaultPlotter.Ellipse(xc, yc, rx, ry, angleDeg, args)
+}
+
+// Arc calls Arc of the default Plotter
+func Arc(xc, yc, r, minAlpha, maxAlpha float64, args *A) {
+	defaultPlotter.Arc(xc, yc, r, minAlpha, maxAlpha, args)
+}
+
+// RoundedRect calls RoundedRect of the default Plotter
+func RoundedRect(xmin, ymin, w, h, pad float64, args *A) {
+	defaultPlotter.RoundedRect(xmin, ymin, w, h, pad, args)
+}
+
+// Wedge calls Wedge of the default Plotter
+func Wedge(xc, yc, r, theta1, theta2 float64, args *A) (bbox [][]float64) {
+	return defaultPlotter.Wedge(xc, yc, r, theta1, theta2, args)
+}
+
+// Polyline calls Polyline of the default Plotter
+func Polyline(P [][]float64, args *A) {
+	defaultPlotter.Polyline(P, args)
+}
+
+// SlopeIndicator calls SlopeIndicator of the default Plotter
+func SlopeIndicator(x0, y0, width, slope float64, flip bool, args *A) (P [][]float64) {
+	return defaultPlotter.SlopeIndicator(x0, y0, width, slope, flip, args)
+}
+
+// SlopeIndicatorLast calls SlopeIndicatorLast of the default Plotter
+func SlopeIndicatorLast(x, y []float64, width, slope float64, args *A) (P [][]float64, err error) {
+	return defaultPlotter.SlopeIndicatorLast(x, y, width, slope, args)
+}
+
+// BezierCurve calls BezierCurve of the default Plotter
+func BezierCurve(P [][]float64, args *A) (err error) {
+	return defaultPlotter.BezierCurve(P, args)
+}
+
+// LegendX calls LegendX of the default Plotter
+func LegendX(dat []*A, args *A) {
+	defaultPlotter.LegendX(dat, args)
+}
+
+// Ecdf calls Ecdf of the default Plotter
+func Ecdf(data []float64, args *A) (x, F []float64) {
+	return defaultPlotter.Ecdf(data, args)
+}
+
+// EcdfRef calls EcdfRef of the default Plotter
+func EcdfRef(data []float64, cdf func(x float64) float64, npts int, args, argsRef *A) (x, F []float64) {
+	return defaultPlotter.EcdfRef(data, cdf, npts, args, argsRef)
+}
+
+// QQplot calls QQplot of the default Plotter
+func QQplot(sample []float64, quantileFunc func(p float64) float64, args *A) (xt, xs []float64) {
+	return defaultPlotter.QQplot(sample, quantileFunc, args)
+}
+
+// RenderSpecs calls RenderSpecs of the default Plotter
+func RenderSpecs(specs []FigSpec, opts *SaveOpts) (err error) {
+	return defaultPlotter.RenderSpecs(specs, opts)
+}
+
+// HeatmapAnnotated calls HeatmapAnnotated of the default Plotter
+func HeatmapAnnotated(z [][]float64, rowLabels, colLabels []string, numFmt string, args *A) (err error) {
+	return defaultPlotter.HeatmapAnnotated(z, rowLabels, colLabels, numFmt, args)
+}
+
+// ImageFile calls ImageFile of the default Plotter
+func ImageFile(fname string, extent []float64, args *A) (err error) {
+	return defaultPlotter.ImageFile(fname, extent, args)
+}
+
+// ColorbarOnly calls ColorbarOnly of the default Plotter
+func ColorbarOnly(cmapIdx int, vmin, vmax float64, label string, args *A) (name string) {
+	return defaultPlotter.ColorbarOnly(cmapIdx, vmin, vmax, label, args)
+}
+
+// ZoomInset calls ZoomInset of the default Plotter
+func ZoomInset(xFrac, yFrac, wFrac, hFrac float64, xmin, xmax, ymin, ymax float64, args *A) (activate, deactivate func()) {
+	return defaultPlotter.ZoomInset(xFrac, yFrac, wFrac, hFrac, xmin, xmax, ymin, ymax, args)
+}
+
+// Kde calls Kde of the default Plotter
+func Kde(data []float64, npts int, bandwidth float64, args *A) (x, f []float64) {
+	return defaultPlotter.Kde(data, npts, bandwidth, args)
+}
+
+// KdeHist calls KdeHist of the default Plotter
+func KdeHist(data []float64, npts int, bandwidth float64, argsHist, argsKde *A) (x, f []float64) {
+	return defaultPlotter.KdeHist(data, npts, bandwidth, argsHist, argsKde)
+}
+
+// Reset calls Reset of the default Plotter
+func Reset() {
+	defaultPlotter.Reset()
+}
+
+// DefineColormap calls DefineColormap of the default Plotter
+func DefineColormap(name string, colors []string, positionsOrNil []float64) (idx int, err error) {
+	return defaultPlotter.DefineColormap(name, colors, positionsOrNil)
+}
+
+// PyCmds calls PyCmds of the default Plotter
+func PyCmds(text string) {
+	defaultPlotter.PyCmds(text)
+}
+
+// EaCmds calls EaCmds of the default Plotter
+func EaCmds(text string) {
+	defaultPlotter.EaCmds(text)
+}
+
+// RegisterExtraArtist calls RegisterExtraArtist of the default Plotter
+func RegisterExtraArtist(pyVarName string) {
+	defaultPlotter.RegisterExtraArtist(pyVarName)
+}
+
+// PyFile calls PyFile of the default Plotter
+func PyFile(filename string) (err error) {
+	return defaultPlotter.PyFile(filename)
+}
+
+// DoubleYscale calls DoubleYscale of the default Plotter
+func DoubleYscale(ylabelOrEmpty string) {
+	defaultPlotter.DoubleYscale(ylabelOrEmpty)
+}
+
+// DoubleXscale calls DoubleXscale of the default Plotter
+func DoubleXscale(xlabelOrEmpty string) {
+	defaultPlotter.DoubleXscale(xlabelOrEmpty)
+}
+
+// SetXlog calls SetXlog of the default Plotter
+func SetXlog() {
+	defaultPlotter.SetXlog()
+}
+
+// SetYlog calls SetYlog of the default Plotter
+func SetYlog() {
+	defaultPlotter.SetYlog()
+}
+
+// SetXnticks calls SetXnticks of the default Plotter
+func SetXnticks(num int) {
+	defaultPlotter.SetXnticks(num)
+}
+
+// SetYnticks calls SetYnticks of the default Plotter
+func SetYnticks(num int) {
+	defaultPlotter.SetYnticks(num)
+}
+
+// SetTicksX calls SetTicksX of the default Plotter
+func SetTicksX(majorEvery, minorEvery float64, majorFmt string) {
+	defaultPlotter.SetTicksX(majorEvery, minorEvery, majorFmt)
+}
+
+// SetTicksY calls SetTicksY of the default Plotter
+func SetTicksY(majorEvery, minorEvery float64, majorFmt string) {
+	defaultPlotter.SetTicksY(majorEvery, minorEvery, majorFmt)
+}
+
+// SetXticksLabels calls SetXticksLabels of the default Plotter
+func SetXticksLabels(positions []float64, labels []string, rotationDeg float64, args *A) (err error) {
+	return defaultPlotter.SetXticksLabels(positions, labels, rotationDeg, args)
+}
+
+// SetYticksLabels calls SetYticksLabels of the default Plotter
+func SetYticksLabels(positions []float64, labels []string, rotationDeg float64, args *A) (err error) {
+	return defaultPlotter.SetYticksLabels(positions, labels, rotationDeg, args)
+}
+
+// SetScientificX calls SetScientificX of the default Plotter
+func SetScientificX(minOrder, maxOrder int) {
+	defaultPlotter.SetScientificX(minOrder, maxOrder)
+}
+
+// SetScientificY calls SetScientificY of the default Plotter
+func SetScientificY(minOrder, maxOrder int) {
+	defaultPlotter.SetScientificY(minOrder, maxOrder)
+}
+
+// SetTicksNormal calls SetTicksNormal of the default Plotter
+func SetTicksNormal() {
+	defaultPlotter.SetTicksNormal()
+}
+
+// ReplaceAxes calls ReplaceAxes of the default Plotter
+func ReplaceAxes(xi, yi, xf, yf, xDel, yDel float64, xLab, yLab string, argsArrow, argsText *A) {
+	defaultPlotter.ReplaceAxes(xi, yi, xf, yf, xDel, yDel, xLab, yLab, argsArrow, argsText)
+}
+
+// AxHline calls AxHline of the default Plotter
+func AxHline(y float64, args *A) {
+	defaultPlotter.AxHline(y, args)
+}
+
+// AxVline calls AxVline of the default Plotter
+func AxVline(x float64, args *A) {
+	defaultPlotter.AxVline(x, args)
+}
+
+// AxHspan calls AxHspan of the default Plotter
+func AxHspan(ymin, ymax float64, args *A) {
+	defaultPlotter.AxHspan(ymin, ymax, args)
+}
+
+// AxVspan calls AxVspan of the default Plotter
+func AxVspan(xmin, xmax float64, args *A) {
+	defaultPlotter.AxVspan(xmin, xmax, args)
+}
+
+// HideBorders calls HideBorders of the default Plotter
+func HideBorders(args *A) {
+	defaultPlotter.HideBorders(args)
+}
+
+// Annotate calls Annotate of the default Plotter
+func Annotate(x, y float64, txt string, args *A) {
+	defaultPlotter.Annotate(x, y, txt, args)
+}
+
+// AnnotateXlabels calls AnnotateXlabels of the default Plotter
+func AnnotateXlabels(x float64, txt string, args *A) {
+	defaultPlotter.AnnotateXlabels(x, txt, args)
+}
+
+// SupTitle calls SupTitle of the default Plotter
+func SupTitle(txt string, args *A) {
+	defaultPlotter.SupTitle(txt, args)
+}
+
+// Title calls Title of the default Plotter
+func Title(txt string, args *A) {
+	defaultPlotter.Title(txt, args)
+}
+
+// Text calls Text of the default Plotter
+func Text(x, y float64, txt string, args *A) {
+	defaultPlotter.Text(x, y, txt, args)
+}
+
+// Cross calls Cross of the default Plotter
+func Cross(x0, y0 float64, args *A) {
+	defaultPlotter.Cross(x0, y0, args)
+}
+
+// SplotGap calls SplotGap of the default Plotter
+func SplotGap(w, h float64) {
+	defaultPlotter.SplotGap(w, h)
+}
+
+// Subplot calls Subplot of the default Plotter
+func Subplot(i, j, k int) (handle string) {
+	return defaultPlotter.Subplot(i, j, k)
+}
+
+// SubplotI calls SubplotI of the default Plotter
+func SubplotI(I []int) (handle string) {
+	return defaultPlotter.SubplotI(I)
+}
+
+// SetCurrentAxes calls SetCurrentAxes of the default Plotter
+func SetCurrentAxes(handle string) {
+	defaultPlotter.SetCurrentAxes(handle)
+}
+
+// GridSpec calls GridSpec of the default Plotter
+func GridSpec(nrows, ncols int, widthRatios, heightRatios []float64, wspace, hspace float64) (name string) {
+	return defaultPlotter.GridSpec(nrows, ncols, widthRatios, heightRatios, wspace, hspace)
+}
+
+// SubplotGS calls SubplotGS of the default Plotter
+func SubplotGS(gsName string, rowStart, rowEnd, colStart, colEnd int) (err error) {
+	return defaultPlotter.SubplotGS(gsName, rowStart, rowEnd, colStart, colEnd)
+}
+
+// SubplotSpan calls SubplotSpan of the default Plotter
+func SubplotSpan(shapeRows, shapeCols, row, col, rowspan, colspan int) (err error) {
+	return defaultPlotter.SubplotSpan(shapeRows, shapeCols, row, col, rowspan, colspan)
+}
+
+// SubplotShared calls SubplotShared of the default Plotter
+func SubplotShared(i, j, k int, shareX, shareY bool) (name string) {
+	return defaultPlotter.SubplotShared(i, j, k, shareX, shareY)
+}
+
+// SetHspace calls SetHspace of the default Plotter
+func SetHspace(hspace float64) {
+	defaultPlotter.SetHspace(hspace)
+}
+
+// SetVspace calls SetVspace of the default Plotter
+func SetVspace(vspace float64) {
+	defaultPlotter.SetVspace(vspace)
+}
+
+// Equal calls Equal of the default Plotter
+func Equal() {
+	defaultPlotter.Equal()
+}
+
+// SetAspect calls SetAspect of the default Plotter
+func SetAspect(ratio float64) {
+	defaultPlotter.SetAspect(ratio)
+}
+
+// InvertXaxis calls InvertXaxis of the default Plotter
+func InvertXaxis() {
+	defaultPlotter.InvertXaxis()
+}
+
+// InvertYaxis calls InvertYaxis of the default Plotter
+func InvertYaxis() {
+	defaultPlotter.InvertYaxis()
+}
+
+// AxisOff calls AxisOff of the default Plotter
+func AxisOff() {
+	defaultPlotter.AxisOff()
+}
+
+// SetAxis calls SetAxis of the default Plotter
+func SetAxis(xmin, xmax, ymin, ymax float64) {
+	defaultPlotter.SetAxis(xmin, xmax, ymin, ymax)
+}
+
+// AxisXmin calls AxisXmin of the default Plotter
+func AxisXmin(xmin float64) {
+	defaultPlotter.AxisXmin(xmin)
+}
+
+// AxisXmax calls AxisXmax of the default Plotter
+func AxisXmax(xmax float64) {
+	defaultPlotter.AxisXmax(xmax)
+}
+
+// AxisYmin calls AxisYmin of the default Plotter
+func AxisYmin(ymin float64) {
+	defaultPlotter.AxisYmin(ymin)
+}
+
+// AxisYmax calls AxisYmax of the default Plotter
+func AxisYmax(ymax float64) {
+	defaultPlotter.AxisYmax(ymax)
+}
+
+// AxisXrange calls AxisXrange of the default Plotter
+func AxisXrange(xmin, xmax float64) {
+	defaultPlotter.AxisXrange(xmin, xmax)
+}
+
+// AxisYrange calls AxisYrange of the default Plotter
+func AxisYrange(ymin, ymax float64) {
+	defaultPlotter.AxisYrange(ymin, ymax)
+}
+
+// AxisRange calls AxisRange of the default Plotter
+func AxisRange(xmin, xmax, ymin, ymax float64) {
+	defaultPlotter.AxisRange(xmin, xmax, ymin, ymax)
+}
+
+// AxisRange3d calls AxisRange3d of the default Plotter
+func AxisRange3d(xmin, xmax, ymin, ymax, zmin, zmax float64) {
+	defaultPlotter.AxisRange3d(xmin, xmax, ymin, ymax, zmin, zmax)
+}
+
+// AxisLims calls AxisLims of the default Plotter
+func AxisLims(lims []float64) {
+	defaultPlotter.AxisLims(lims)
+}
+
+// Plot calls Plot of the default Plotter
+func Plot(x, y []float64, args *A) (sx, sy string) {
+	return defaultPlotter.Plot(x, y, args)
+}
+
+// PlotOne calls PlotOne of the default Plotter
+func PlotOne(x, y float64, args *A) {
+	defaultPlotter.PlotOne(x, y, args)
+}
+
+// PlotLogX calls PlotLogX of the default Plotter
+func PlotLogX(x, y []float64, args *A) (sx, sy string, ndropped int) {
+	return defaultPlotter.PlotLogX(x, y, args)
+}
+
+// PlotLogY calls PlotLogY of the default Plotter
+func PlotLogY(x, y []float64, args *A) (sx, sy string, ndropped int) {
+	return defaultPlotter.PlotLogY(x, y, args)
+}
+
+// PlotLogLog calls PlotLogLog of the default Plotter
+func PlotLogLog(x, y []float64, args *A) (sx, sy string, ndropped int) {
+	return defaultPlotter.PlotLogLog(x, y, args)
+}
+
+// PlotWithBand calls PlotWithBand of the default Plotter
+func PlotWithBand(x, y, ylow, yhigh []float64, args *A) (err error) {
+	return defaultPlotter.PlotWithBand(x, y, ylow, yhigh, args)
+}
+
+// PlotWithStd calls PlotWithStd of the default Plotter
+func PlotWithStd(x, y, std []float64, k float64, args *A) (err error) {
+	return defaultPlotter.PlotWithStd(x, y, std, k, args)
+}
+
+// Hist calls Hist of the default Plotter
+func Hist(x [][]float64, labels []string, args *A) {
+	defaultPlotter.Hist(x, labels, args)
+}
+
+// HistW calls HistW of the default Plotter
+func HistW(x, w [][]float64, labels []string, args *A) (err error) {
+	return defaultPlotter.HistW(x, w, labels, args)
+}
+
+// HistLog calls HistLog of the default Plotter
+func HistLog(x [][]float64, labels []string, args *A) (edges []float64, err error) {
+	return defaultPlotter.HistLog(x, labels, args)
+}
+
+// ContourF calls ContourF of the default Plotter
+func ContourF(x, y, z [][]float64, args *A) (err error) {
+	return defaultPlotter.ContourF(x, y, z, args)
+}
+
+// ContourL calls ContourL of the default Plotter
+func ContourL(x, y, z [][]float64, args *A) (err error) {
+	return defaultPlotter.ContourL(x, y, z, args)
+}
+
+// ContourFfromFunc calls ContourFfromFunc of the default Plotter
+func ContourFfromFunc(xmin, xmax, ymin, ymax float64, nx, ny int, f func(x, y float64) float64, args *A) (X, Y, F [][]float64, err error) {
+	return defaultPlotter.ContourFfromFunc(xmin, xmax, ymin, ymax, nx, ny, f, args)
+}
+
+// ContourLfromFunc calls ContourLfromFunc of the default Plotter
+func ContourLfromFunc(xmin, xmax, ymin, ymax float64, nx, ny int, f func(x, y float64) float64, args *A) (X, Y, F [][]float64, err error) {
+	return defaultPlotter.ContourLfromFunc(xmin, xmax, ymin, ymax, nx, ny, f, args)
+}
+
+// TricontourF calls TricontourF of the default Plotter
+func TricontourF(x, y, z []float64, triangles [][]int, args *A) (err error) {
+	return defaultPlotter.TricontourF(x, y, z, triangles, args)
+}
+
+// TricontourL calls TricontourL of the default Plotter
+func TricontourL(x, y, z []float64, triangles [][]int, args *A) (err error) {
+	return defaultPlotter.TricontourL(x, y, z, triangles, args)
+}
+
+// Quiver calls Quiver of the default Plotter
+func Quiver(x, y, gx, gy [][]float64, args *A) (name string) {
+	return defaultPlotter.Quiver(x, y, gx, gy, args)
+}
+
+// QuiverKey calls QuiverKey of the default Plotter
+func QuiverKey(scale float64, label string, xFrac, yFrac float64, args *A) (err error) {
+	return defaultPlotter.QuiverKey(scale, label, xFrac, yFrac, args)
+}
+
+// Triplot calls Triplot of the default Plotter
+func Triplot(x, y []float64, triangles [][]int, args *A) {
+	defaultPlotter.Triplot(x, y, triangles, args)
+}
+
+// Grid calls Grid of the default Plotter
+func Grid(args *A) {
+	defaultPlotter.Grid(args)
+}
+
+// GridMinor calls GridMinor of the default Plotter
+func GridMinor(args *A) {
+	defaultPlotter.GridMinor(args)
+}
+
+// Legend calls Legend of the default Plotter
+func Legend(args *A) {
+	defaultPlotter.Legend(args)
+}
+
+// LegendCombined calls LegendCombined of the default Plotter
+func LegendCombined(args *A) {
+	defaultPlotter.LegendCombined(args)
+}
+
+// Gll calls Gll of the default Plotter
+func Gll(xl, yl string, args *A) {
+	defaultPlotter.Gll(xl, yl, args)
+}
+
+// Clf calls Clf of the default Plotter
+func Clf() {
+	defaultPlotter.Clf()
+}
+
+// Cla calls Cla of the default Plotter
+func Cla() {
+	defaultPlotter.Cla()
+}
+
+// SetFontSizes calls SetFontSizes of the default Plotter
+func SetFontSizes(args *A) {
+	defaultPlotter.SetFontSizes(args)
+}
+
+// Plot3dLine calls Plot3dLine of the default Plotter
+func Plot3dLine(x, y, z []float64, doInit bool, args *A) {
+	defaultPlotter.Plot3dLine(x, y, z, doInit, args)
+}
+
+// Text3d calls Text3d of the default Plotter
+func Text3d(x, y, z float64, txt string, args *A) {
+	defaultPlotter.Text3d(x, y, z, txt, args)
+}
+
+// Polygons3d calls Polygons3d of the default Plotter
+func Polygons3d(faces [][][]float64, doInit bool, args *A) {
+	defaultPlotter.Polygons3d(faces, doInit, args)
+}
+
+// Plot3dPoints calls Plot3dPoints of the default Plotter
+func Plot3dPoints(x, y, z []float64, doInit bool, args *A) {
+	defaultPlotter.Plot3dPoints(x, y, z, doInit, args)
+}
+
+// Plot3dPointsC calls Plot3dPointsC of the default Plotter
+func Plot3dPointsC(x, y, z, v []float64, doInit bool, args *A) (name string) {
+	return defaultPlotter.Plot3dPointsC(x, y, z, v, doInit, args)
+}
+
+// Wireframe calls Wireframe of the default Plotter
+func Wireframe(x, y, z [][]float64, doInit bool, args *A) {
+	defaultPlotter.Wireframe(x, y, z, doInit, args)
+}
+
+// Surface calls Surface of the default Plotter
+func Surface(x, y, z [][]float64, doInit bool, args *A) {
+	defaultPlotter.Surface(x, y, z, doInit, args)
+}
+
+// SurfaceWithProjections calls SurfaceWithProjections of the default Plotter
+func SurfaceWithProjections(x, y, z [][]float64, doInit bool, args *A) (err error) {
+	return defaultPlotter.SurfaceWithProjections(x, y, z, doInit, args)
+}
+
+// SurfaceContourFloor calls SurfaceContourFloor of the default Plotter
+func SurfaceContourFloor(x, y, z [][]float64, doInit bool, args *A) (err error) {
+	return defaultPlotter.SurfaceContourFloor(x, y, z, doInit, args)
+}
+
+// Trisurf calls Trisurf of the default Plotter
+func Trisurf(x, y, z []float64, tri [][]int, doInit bool, args *A) {
+	defaultPlotter.Trisurf(x, y, z, tri, doInit, args)
+}
+
+// Bar3d calls Bar3d of the default Plotter
+func Bar3d(xpos, ypos, heights []float64, dx, dy float64, doInit bool, args *A) {
+	defaultPlotter.Bar3d(xpos, ypos, heights, dx, dy, doInit, args)
+}
+
+// Quiver3d calls Quiver3d of the default Plotter
+func Quiver3d(x, y, z, u, v, w [][]float64, doInit bool, args *A) {
+	defaultPlotter.Quiver3d(x, y, z, u, v, w, doInit, args)
+}
+
+// Camera calls Camera of the default Plotter
+func Camera(elev, azim float64, args *A) {
+	defaultPlotter.Camera(elev, azim, args)
+}
+
+// SetBoxAspect3d calls SetBoxAspect3d of the default Plotter
+func SetBoxAspect3d(ax, ay, az float64) {
+	defaultPlotter.SetBoxAspect3d(ax, ay, az)
+}
+
+// AxDist calls AxDist of the default Plotter
+func AxDist(dist float64) {
+	defaultPlotter.AxDist(dist)
+}
+
+// SetForPng calls SetForPng of the default Plotter
+func SetForPng(prop, widpt float64, dpi int, args *A) {
+	defaultPlotter.SetForPng(prop, widpt, dpi, args)
+}
+
+// SetForEps calls SetForEps of the default Plotter
+func SetForEps(prop, widpt float64, args *A) {
+	defaultPlotter.SetForEps(prop, widpt, args)
+}
+
+// SetForPdfTex calls SetForPdfTex of the default Plotter
+func SetForPdfTex(prop, widpt float64, args *A) {
+	defaultPlotter.SetForPdfTex(prop, widpt, args)
+}
+
+// SetForSvg calls SetForSvg of the default Plotter
+func SetForSvg(prop, widpt float64, args *A) {
+	defaultPlotter.SetForSvg(prop, widpt, args)
+}
+
+// SetRc calls SetRc of the default Plotter
+func SetRc(rc *RcConfig) {
+	defaultPlotter.SetRc(rc)
+}
+
+// Figure calls Figure of the default Plotter
+func Figure(id int) {
+	defaultPlotter.Figure(id)
+}
+
+// CloseFigure calls CloseFigure of the default Plotter
+func CloseFigure(id int) {
+	defaultPlotter.CloseFigure(id)
+}
+
+// Save calls Save of the default Plotter
+func Save(fname string, figId ...int) error {
+	return defaultPlotter.Save(fname, figId...)
+}
+
+// SaveA calls SaveA of the default Plotter
+func SaveA(fname string, args *SaveArgs) (err error) {
+	return defaultPlotter.SaveA(fname, args)
+}
+
+// SaveCtx calls SaveCtx of the default Plotter
+func SaveCtx(ctx context.Context, fname string) (err error) {
+	return defaultPlotter.SaveCtx(ctx, fname)
+}
+
+// SaveFigures calls SaveFigures of the default Plotter
+func SaveFigures(dirout string, figIds []int, fnames []string) (err error) {
+	return defaultPlotter.SaveFigures(dirout, figIds, fnames)
+}
+
+// SaveToWriter calls SaveToWriter of the default Plotter
+func SaveToWriter(w goio.Writer, format string) (err error) {
+	return defaultPlotter.SaveToWriter(w, format)
+}
+
+// SaveMulti calls SaveMulti of the default Plotter
+func SaveMulti(fnames []string) (err error) {
+	return defaultPlotter.SaveMulti(fnames)
+}
+
+// SetLayout calls SetLayout of the default Plotter
+func SetLayout(l *Layout) {
+	defaultPlotter.SetLayout(l)
+}
+
+// SaveD calls SaveD of the default Plotter
+func SaveD(dirout, fname string) (err error) {
+	return defaultPlotter.SaveD(dirout, fname)
+}
+
+// Show calls Show of the default Plotter
+func Show() error {
+	return defaultPlotter.Show()
+}
+
+// ShowNonBlocking calls ShowNonBlocking of the default Plotter
+func ShowNonBlocking(pause float64) error {
+	return defaultPlotter.ShowNonBlocking(pause)
+}
+
+// ShowAndSave calls ShowAndSave of the default Plotter
+func ShowAndSave(fname string) (err error) {
+	return defaultPlotter.ShowAndSave(fname)
+}
+
+// QueryLimits calls QueryLimits of the default Plotter
+func QueryLimits() (xmin, xmax, ymin, ymax float64, err error) {
+	return defaultPlotter.QueryLimits()
+}
+
+// Script calls Script of the default Plotter
+func Script() string {
+	return defaultPlotter.Script()
+}
+
+// ExportPy calls ExportPy of the default Plotter
+func ExportPy(fname string) (err error) {
+	return defaultPlotter.ExportPy(fname)
+}
+
+// ProbPaper calls ProbPaper of the default Plotter
+func ProbPaper(sample []float64, args *A) (xs, z []float64) {
+	return defaultPlotter.ProbPaper(sample, args)
+}
+
+// ProbPaperFit calls ProbPaperFit of the default Plotter
+func ProbPaperFit(sample []float64, args, argsFit *A) (mean, std float64, err error) {
+	return defaultPlotter.ProbPaperFit(sample, args, argsFit)
+}
+
+// Radar calls Radar of the default Plotter
+func Radar(categories []string, series [][]float64, labels []string, args *A) (err error) {
+	return defaultPlotter.Radar(categories, series, labels, args)
+}
+
+// ScatterMatrix calls ScatterMatrix of the default Plotter
+func ScatterMatrix(data [][]float64, names []string, args *A) (err error) {
+	return defaultPlotter.ScatterMatrix(data, names, args)
+}
+
+// Sphere calls Sphere of the default Plotter
+func Sphere(xc, yc, zc, r float64, nu, nv int, doInit bool, args *A) (X, Y, Z [][]float64) {
+	return defaultPlotter.Sphere(xc, yc, zc, r, nu, nv, doInit, args)
+}
+
+// Cylinder calls Cylinder of the default Plotter
+func Cylinder(xc, yc, zc, r, h float64, nu, nv int, doInit bool, args *A) (X, Y, Z [][]float64) {
+	return defaultPlotter.Cylinder(xc, yc, zc, r, h, nu, nv, doInit, args)
+}
+
+// Cone calls Cone of the default Plotter
+func Cone(xc, yc, zc, r, h float64, nu, nv int, doInit bool, args *A) (X, Y, Z [][]float64) {
+	return defaultPlotter.Cone(xc, yc, zc, r, h, nu, nv, doInit, args)
+}
+
+// SurfaceF calls SurfaceF of the default Plotter
+func SurfaceF(umin, umax, vmin, vmax float64, nu, nv int, f func(u, v float64) (x, y, z float64), doInit bool, args *A) (X, Y, Z [][]float64, err error) {
+	return defaultPlotter.SurfaceF(umin, umax, vmin, vmax, nu, nv, f, doInit, args)
+}
+
+// Spy calls Spy of the default Plotter
+func Spy(a [][]float64, tol float64, args *A) {
+	defaultPlotter.Spy(a, tol, args)
+}
+
+// SpyTriplet calls SpyTriplet of the default Plotter
+func SpyTriplet(rows, cols []int, vals []float64, m, n int, tol float64, args *A) (err error) {
+	return defaultPlotter.SpyTriplet(rows, cols, vals, m, n, tol, args)
+}
+
+// TableArtist calls TableArtist of the default Plotter
+func TableArtist(cells [][]string, rowLabels, colLabels []string, loc string, args *A) (name string) {
+	return defaultPlotter.TableArtist(cells, rowLabels, colLabels, loc, args)
+}
+
+// Ternary calls Ternary of the default Plotter
+func Ternary(a, b, c []float64, labels [3]string, args *A) (err error) {
+	return defaultPlotter.Ternary(a, b, c, labels, args)
+}
+
+// PlotTime calls PlotTime of the default Plotter
+func PlotTime(t []time.Time, y []float64, args *A) (st, sy string) {
+	return defaultPlotter.PlotTime(t, y, args)
+}
+
+// SetTimeTicksX calls SetTimeTicksX of the default Plotter
+func SetTimeTicksX(format string, interval string) (err error) {
+	return defaultPlotter.SetTimeTicksX(format, interval)
+}
+
+// Voxels calls Voxels of the default Plotter
+func Voxels(filled [][][]bool, doInit bool, args *A) (err error) {
+	return defaultPlotter.Voxels(filled, doInit, args)
+}
+
+// Waterfall calls Waterfall of the default Plotter
+func Waterfall(x []float64, ys [][]float64, offsets []float64, doInit bool, args *A) (err error) {
+	return defaultPlotter.Waterfall(x, ys, offsets, doInit, args)
+}
+
+// Ribbon calls Ribbon of the default Plotter
+func Ribbon(x []float64, ys [][]float64, width float64, doInit bool, args *A) (err error) {
+	return defaultPlotter.Ribbon(x, ys, width, doInit, args)
+}
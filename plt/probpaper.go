@@ -0,0 +1,122 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+// probPaperTicks holds the probabilities shown along the y-axis of normal probability plots
+var probPaperTicks = []float64{0.001, 0.01, 0.05, 0.1, 0.2, 0.3, 0.5, 0.7, 0.8, 0.9, 0.95, 0.99, 0.999}
+
+// ProbPaper plots the empirical CDF of sample on normal probability axes; i.e. the plotting
+// positions (i-0.5)/n are transformed by the inverse standard normal CDF. The y ticks show the
+// original probabilities. The points fall on a straight line if the sample is normal.
+// It returns the sorted sample and the transformed positions
+func (o *Plotter) ProbPaper(sample []float64, args *A) (xs, z []float64) {
+	if len(sample) == 0 {
+		return
+	}
+	xs = utl.DblGetSorted(sample)
+	z = make([]float64, len(xs))
+	for i, p := range qqPositions(len(xs)) {
+		z[i] = stdNormalInv(p)
+	}
+	a := &A{C: "b", M: "o", Ls: "none"}
+	if args != nil {
+		*a = *args
+		a.Ls = "none"
+		if a.M == "" {
+			a.M = "o"
+		}
+	}
+	o.Plot(xs, z, a)
+	o.probPaperYticks()
+	return
+}
+
+// ProbPaperFit plots sample on normal probability axes (see ProbPaper) and the straight line
+// fitted by least squares. It returns the mean and standard deviation of the fitted normal
+// distribution
+func (o *Plotter) ProbPaperFit(sample []float64, args, argsFit *A) (mean, std float64, err error) {
+	if len(sample) < 2 {
+		return 0, 0, chk.Err("at least 2 samples are required to fit a line. %d is invalid", len(sample))
+	}
+	xs, z := o.ProbPaper(sample, args)
+	mean, std = leastSquaresLine(z, xs) // x = mean + std * z
+	if argsFit == nil {
+		argsFit = &A{C: "r", Ls: "-"}
+	}
+	o.Plot([]float64{mean + std*z[0], mean + std*z[len(z)-1]}, []float64{z[0], z[len(z)-1]}, argsFit)
+	return
+}
+
+// probPaperYticks sets the y ticks of normal probability plots
+func (o *Plotter) probPaperYticks() {
+	z := make([]float64, len(probPaperTicks))
+	l := make([]string, len(probPaperTicks))
+	for i, p := range probPaperTicks {
+		z[i] = stdNormalInv(p)
+		l[i] = io.Sf("%g%%", 100*p)
+	}
+	n := o.bufferPy.Len()
+	genArray(o.pyBuf(), io.Sf("zt%d", n), z)
+	genStrArray(o.pyBuf(), io.Sf("lt%d", n), l)
+	io.Ff(o.pyBuf(), "plt.yticks(zt%d,lt%d)\n", n, n)
+	io.Ff(o.pyBuf(), "plt.ylim(%g,%g)\n", z[0], z[len(z)-1])
+}
+
+// leastSquaresLine computes the coefficients of y = a + b x fitted by least squares
+func leastSquaresLine(x, y []float64) (a, b float64) {
+	n := float64(len(x))
+	var sx, sy, sxx, sxy float64
+	for i := 0; i < len(x); i++ {
+		sx += x[i]
+		sy += y[i]
+		sxx += x[i] * x[i]
+		sxy += x[i] * y[i]
+	}
+	b = (n*sxy - sx*sy) / (n*sxx - sx*sx)
+	a = (sy - b*sx) / n
+	return
+}
+
+// coefficients of the rational approximations of the inverse standard normal CDF by P. J. Acklam
+var (
+	stdNormalInvA = []float64{-3.969683028665376e+01, 2.209460984245205e+02, -2.759285104469687e+02, 1.383577518672690e+02, -3.066479806614716e+01, 2.506628277459239e+00}
+	stdNormalInvB = []float64{-5.447609879822406e+01, 1.615858368580409e+02, -1.556989798598866e+02, 6.680131188771972e+01, -1.328068155288572e+01}
+	stdNormalInvC = []float64{-7.784894002430293e-03, -3.223964580411365e-01, -2.400758277161838e+00, -2.549732539343734e+00, 4.374664141464968e+00, 2.938163982698783e+00}
+	stdNormalInvD = []float64{7.784695709041462e-03, 3.224671290700398e-01, 2.445134137142996e+00, 3.754408661907416e+00}
+)
+
+// stdNormalInv computes the inverse of the standard normal CDF with the rational approximations
+// by P. J. Acklam (relative error < 1.15e-9) followed by one step of Halley's method
+func stdNormalInv(p float64) (x float64) {
+	if p <= 0 || p >= 1 {
+		chk.Panic("probability must be in (0, 1). p=%g is invalid", p)
+	}
+	a, b, c, d := stdNormalInvA, stdNormalInvB, stdNormalInvC, stdNormalInvD
+	const plow, phigh = 0.02425, 1 - 0.02425
+	switch {
+	case p < plow:
+		q := math.Sqrt(-2 * math.Log(p))
+		x = (((((c[0]*q+c[1])*q+c[2])*q+c[3])*q+c[4])*q + c[5]) / ((((d[0]*q+d[1])*q+d[2])*q+d[3])*q + 1)
+	case p > phigh:
+		q := math.Sqrt(-2 * math.Log(1-p))
+		x = -(((((c[0]*q+c[1])*q+c[2])*q+c[3])*q+c[4])*q + c[5]) / ((((d[0]*q+d[1])*q+d[2])*q+d[3])*q + 1)
+	default:
+		q := p - 0.5
+		r := q * q
+		x = (((((a[0]*r+a[1])*r+a[2])*r+a[3])*r+a[4])*r + a[5]) * q / (((((b[0]*r+b[1])*r+b[2])*r+b[3])*r+b[4])*r + 1)
+	}
+	e := 0.5*math.Erfc(-x/math.Sqrt2) - p // refinement
+	u := e * math.Sqrt(2*math.Pi) * math.Exp(x*x/2)
+	x = x - u/(1+x*u/2)
+	return
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Radar draws a radar (spider) chart on a new polar subplot. Each series is drawn as a closed
+// polygon with one value per category
+//  Input:
+//   categories -- names of categories (angular ticks)
+//   series     -- values [nseries][ncategories]
+//   labels     -- labels of series (legend); nil => no legend
+//   args       -- colors of series (Colors), line width (Lw), marker (M), and transparency of
+//                 fill (Alpha); Alpha == 0 => no fill
+func (o *Plotter) Radar(categories []string, series [][]float64, labels []string, args *A) (err error) {
+
+	// check
+	nc := len(categories)
+	if nc < 3 {
+		return chk.Err("radar chart needs at least 3 categories. %d is invalid", nc)
+	}
+	for i, s := range series {
+		if len(s) != nc {
+			return chk.Err("series %d must have %d values (number of categories). %d is invalid", i, nc, len(s))
+		}
+	}
+	if labels != nil && len(labels) != len(series) {
+		return chk.Err("number of labels must be equal to the number of series. %d != %d", len(labels), len(series))
+	}
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+
+	// axes and angles
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "ax%d = plt.gcf().add_subplot(111, projection='polar')\n", n)
+	θ := radarAngles(nc)
+	st := io.Sf("t%d", n)
+	genArray(o.pyBuf(), st, θ)
+
+	// series
+	for i, s := range series {
+		sy := io.Sf("y%d_%d", n, i)
+		genArray(o.pyBuf(), sy, append(append([]float64{}, s...), s[0]))
+		sty := &A{Lw: a.Lw, M: a.M}
+		if len(a.Colors) > 0 {
+			sty.C = a.Colors[i%len(a.Colors)]
+		}
+		if labels != nil {
+			sty.L = labels[i]
+		}
+		io.Ff(o.pyBuf(), "l%d_%d = ax%d.plot(%s,%s", n, i, n, st, sy)
+		updateBufferAndClose(o.pyBuf(), sty, false)
+		if a.Alpha > 0 {
+			io.Ff(o.pyBuf(), "ax%d.fill(%s,%s,color=l%d_%d[0].get_color(),alpha=%g)\n", n, st, sy, n, i, a.Alpha)
+		}
+	}
+
+	// ticks and legend
+	io.Ff(o.pyBuf(), "ax%d.set_xticks(%s[:-1])\n", n, st)
+	io.Ff(o.pyBuf(), "ax%d.set_xticklabels(%s)\n", n, strings2list(categories))
+	if labels != nil {
+		io.Ff(o.pyBuf(), "lg%d = ax%d.legend(loc='upper left',bbox_to_anchor=(1.05,1.0))\n", n, n)
+		io.Ff(o.pyBuf(), "addToEA(lg%d)\n", n)
+	}
+	return
+}
+
+// radarAngles returns the closed list of angles (in radians) of n categories in a radar chart
+func radarAngles(n int) (θ []float64) {
+	θ = make([]float64, n+1)
+	for k := 0; k < n; k++ {
+		θ[k] = 2.0 * math.Pi * float64(k) / float64(n)
+	}
+	θ[n] = θ[0]
+	return
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// ScatterMatrix draws an n×n grid of subplots with the histograms of each variable on the
+// diagonal and the scatter plots of each pair of variables off the diagonal; e.g. to inspect
+// correlated random variables. Only the outer axes are labelled
+//  Input:
+//   data  -- samples: data[i] are the samples of the i-th variable
+//   names -- names of variables; nil => x0, x1, ...
+//   args  -- color (C; default "b"), marker (M; default "."), marker size (Ms) and number of bins (Hnbins)
+func (o *Plotter) ScatterMatrix(data [][]float64, names []string, args *A) (err error) {
+
+	// check
+	nv := len(data)
+	if nv < 1 {
+		return chk.Err("at least one variable must be given")
+	}
+	for i := 1; i < nv; i++ {
+		if len(data[i]) != len(data[0]) {
+			return chk.Err("all variables must have the same number of samples. %d != %d", len(data[i]), len(data[0]))
+		}
+	}
+	if names == nil {
+		names = make([]string, nv)
+		for i := 0; i < nv; i++ {
+			names[i] = io.Sf("x%d", i)
+		}
+	}
+	if len(names) != nv {
+		return chk.Err("number of names must be equal to the number of variables. %d != %d", len(names), nv)
+	}
+
+	// arguments
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	if a.C == "" {
+		a.C = "b"
+	}
+	if a.M == "" {
+		a.M = "."
+	}
+	a.Ls = "none"
+	ah := &A{C: a.C, Hnbins: a.Hnbins}
+
+	// samples
+	n := o.bufferPy.Len()
+	for i := 0; i < nv; i++ {
+		genArray(o.pyBuf(), io.Sf("d%d_%d", n, i), data[i])
+	}
+
+	// subplots
+	for i := 0; i < nv; i++ {
+		for j := 0; j < nv; j++ {
+			o.Subplot(nv, nv, i*nv+j+1)
+			if i == j {
+				io.Ff(o.pyBuf(), "plt.hist(d%d_%d", n, i)
+				updateBufferAndClose(o.pyBuf(), ah, true)
+			} else {
+				io.Ff(o.pyBuf(), "plt.plot(d%d_%d,d%d_%d", n, j, n, i)
+				updateBufferAndClose(o.pyBuf(), a, false)
+			}
+			if i < nv-1 {
+				io.Ff(o.pyBuf(), "plt.setp(plt.gca().get_xticklabels(),visible=False)\n")
+			} else {
+				io.Ff(o.pyBuf(), "plt.xlabel(r'%s')\n", names[j])
+			}
+			if j > 0 {
+				io.Ff(o.pyBuf(), "plt.setp(plt.gca().get_yticklabels(),visible=False)\n")
+			} else {
+				io.Ff(o.pyBuf(), "plt.ylabel(r'%s')\n", names[i])
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Sphere draws a sphere with centre (xc,yc,zc) and radius r using nu points along the
+// longitude and nv points along the latitude. The sphere is drawn as a wireframe if args.Swire
+// is true. It returns the grids; with shape (nv,nu)
+func (o *Plotter) Sphere(xc, yc, zc, r float64, nu, nv int, doInit bool, args *A) (X, Y, Z [][]float64) {
+	X, Y, Z = shapeGrid(nu, nv, func(u, v float64) (x, y, z float64) { // u ∈ [0,2π], v ∈ [0,1]
+		φ := math.Pi * v
+		return xc + r*math.Cos(u)*math.Sin(φ), yc + r*math.Sin(u)*math.Sin(φ), zc - r*math.Cos(φ)
+	})
+	o.drawShape(X, Y, Z, doInit, args)
+	return
+}
+
+// Cylinder draws a vertical cylinder with radius r and height h whose base is centred at
+// (xc,yc,zc). See Sphere
+func (o *Plotter) Cylinder(xc, yc, zc, r, h float64, nu, nv int, doInit bool, args *A) (X, Y, Z [][]float64) {
+	X, Y, Z = shapeGrid(nu, nv, func(u, v float64) (x, y, z float64) {
+		return xc + r*math.Cos(u), yc + r*math.Sin(u), zc + h*v
+	})
+	o.drawShape(X, Y, Z, doInit, args)
+	return
+}
+
+// Cone draws a vertical cone with base radius r and height h whose base is centred at
+// (xc,yc,zc); i.e. the apex is at (xc,yc,zc+h). See Sphere
+func (o *Plotter) Cone(xc, yc, zc, r, h float64, nu, nv int, doInit bool, args *A) (X, Y, Z [][]float64) {
+	X, Y, Z = shapeGrid(nu, nv, func(u, v float64) (x, y, z float64) {
+		return xc + r*(1-v)*math.Cos(u), yc + r*(1-v)*math.Sin(u), zc + h*v
+	})
+	o.drawShape(X, Y, Z, doInit, args)
+	return
+}
+
+// SurfaceF draws the parametric surface {x,y,z} = f(u,v) sampled on a grid with nu points
+// along u and nv points along v. See Surface. It returns the grids; with shape (nv,nu)
+func (o *Plotter) SurfaceF(umin, umax, vmin, vmax float64, nu, nv int, f func(u, v float64) (x, y, z float64), doInit bool, args *A) (X, Y, Z [][]float64, err error) {
+	if nu < 2 || nv < 2 {
+		return nil, nil, nil, chk.Err("numbers of points along u and v must be at least 2. nu=%d and nv=%d are invalid", nu, nv)
+	}
+	X, Y, Z = paramGrid(umin, umax, vmin, vmax, nu, nv, f)
+	o.Surface(X, Y, Z, doInit, args)
+	return
+}
+
+// shapeGrid computes the grids of primitive shapes with u ∈ [0,2π] and v ∈ [0,1]. The default
+// numbers of points are nu=21 and nv=11
+func shapeGrid(nu, nv int, f func(u, v float64) (x, y, z float64)) (X, Y, Z [][]float64) {
+	if nu < 2 {
+		nu = 21
+	}
+	if nv < 2 {
+		nv = 11
+	}
+	return paramGrid(0, 2.0*math.Pi, 0, 1, nu, nv, f)
+}
+
+// paramGrid samples the parametric function f on a grid with shape (nv,nu)
+func paramGrid(umin, umax, vmin, vmax float64, nu, nv int, f func(u, v float64) (x, y, z float64)) (X, Y, Z [][]float64) {
+	X, Y, Z = make([][]float64, nv), make([][]float64, nv), make([][]float64, nv)
+	for i := 0; i < nv; i++ {
+		X[i], Y[i], Z[i] = make([]float64, nu), make([]float64, nu), make([]float64, nu)
+		v := vmin + (vmax-vmin)*float64(i)/float64(nv-1)
+		for j := 0; j < nu; j++ {
+			u := umin + (umax-umin)*float64(j)/float64(nu-1)
+			X[i][j], Y[i][j], Z[i][j] = f(u, v)
+		}
+	}
+	return
+}
+
+// drawShape draws the grids of primitive shapes as surfaces or wireframes
+func (o *Plotter) drawShape(X, Y, Z [][]float64, doInit bool, args *A) {
+	if args != nil && args.Swire {
+		o.Wireframe(X, Y, Z, doInit, args)
+		return
+	}
+	o.Surface(X, Y, Z, doInit, args)
+}
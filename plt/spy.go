@@ -0,0 +1,80 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Spy plots the sparsity pattern of a matrix. Entries with |aij| <= tol are considered zero.
+// The marker size is given by args.Ms (default 2)
+func (o *Plotter) Spy(a [][]float64, tol float64, args *A) {
+	n := o.bufferPy.Len()
+	sa := io.Sf("a%d", n)
+	genMat(o.pyBuf(), sa, a)
+	sty := argsSpy(args)
+	io.Ff(o.pyBuf(), "plt.spy(%s,precision=%g,markersize=%d", sa, tol, sty.Ms)
+	sty.Ms = 0
+	updateBufferAndClose(o.pyBuf(), sty, false)
+}
+
+// SpyTriplet plots the sparsity pattern of an m×n matrix given in triplet format without
+// densifying it. Repeated entries are summed. Entries with |aij| <= tol are considered zero
+func (o *Plotter) SpyTriplet(rows, cols []int, vals []float64, m, n int, tol float64, args *A) (err error) {
+
+	// check
+	if len(cols) != len(rows) || len(vals) != len(rows) {
+		return chk.Err("the lengths of rows, cols and vals must be the same. %d, %d, %d", len(rows), len(cols), len(vals))
+	}
+
+	// sum repeated entries
+	sum := make(map[int]float64)
+	for k := 0; k < len(rows); k++ {
+		if rows[k] < 0 || rows[k] >= m || cols[k] < 0 || cols[k] >= n {
+			return chk.Err("entry (%d,%d) is outside the %d×%d matrix", rows[k], cols[k], m, n)
+		}
+		sum[rows[k]*n+cols[k]] += vals[k]
+	}
+	var keys []int
+	for key, v := range sum {
+		if math.Abs(v) > tol {
+			keys = append(keys, key)
+		}
+	}
+	sort.Ints(keys)
+
+	// points at (j, -i)
+	x := make([]float64, len(keys))
+	y := make([]float64, len(keys))
+	for k, key := range keys {
+		x[k] = float64(key % n)
+		y[k] = float64(-(key / n))
+	}
+	sty := argsSpy(args)
+	if sty.M == "" {
+		sty.M = "s"
+	}
+	sty.Ls = "none"
+	o.Plot(x, y, sty)
+	io.Ff(o.pyBuf(), "plt.gca().set_aspect('equal')\n")
+	o.AxisRange(-0.5, float64(n)-0.5, -float64(m)+0.5, 0.5)
+	return
+}
+
+// argsSpy returns a copy of args with default marker size
+func argsSpy(args *A) (sty *A) {
+	sty = new(A)
+	if args != nil {
+		*sty = *args
+	}
+	if sty.Ms < 1 {
+		sty.Ms = 2
+	}
+	return
+}
@@ -0,0 +1,117 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_animation01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("animation01. frames and writers")
+
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	Reset()
+	err := Animate(2, 4, dir+"/t_animation01.gif", func(i int) {
+		Plot([]float64{0, 1}, []float64{0, float64(i)}, nil)
+		Legend(nil)
+	})
+	if err != nil {
+		restore()
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	txt := string(b)
+	for _, cmd := range []string{
+		"import matplotlib.animation as ani\ndef frame0_0():\n    pass\n    x",
+		"    plt.plot(x",
+		"\n    if len(h",
+		"\n        l",
+		"def frame0_1():\n    pass\n    x",
+		"=np.array([0,0,],dtype=float)\n",
+		"frames0 = [frame0_0,frame0_1,]\ndef animate0(i):\n    plt.clf()\n    frames0[i]()\n",
+		"anim0 = ani.FuncAnimation(plt.gcf(), animate0, frames=2, interval=250)\n",
+		"anim0.save(r'/tmp/gosl/t_animation01.gif', writer=ani.PillowWriter(fps=4))\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			restore()
+			tst.Errorf("script does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	if strings.Contains(txt, "ffmpeg") {
+		restore()
+		tst.Errorf("GIF animation should not check ffmpeg\n")
+		return
+	}
+
+	// mp4
+	Reset()
+	err = Animate(1, 10, dir+"/t_animation01.mp4", func(i int) {})
+	restore()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	txt = string(b)
+	for _, cmd := range []string{
+		"def frame0_0():\n    pass\nframes0",
+		"if not ani.writers.is_available('ffmpeg'): raise RuntimeError(",
+		"anim0.save(r'/tmp/gosl/t_animation01.mp4', writer=ani.FFMpegWriter(fps=10))\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("script does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// errors
+	if Animate(0, 10, "a.gif", func(i int) {}) == nil {
+		tst.Errorf("Animate should have failed with no frames\n")
+		return
+	}
+	if Animate(5, 10, "a.avi", func(i int) {}) == nil {
+		tst.Errorf("Animate should have failed with unknown extension\n")
+		return
+	}
+}
+
+func Test_animation02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("animation02. gif")
+
+	if chk.Verbose {
+		x := utl.LinSpace(0, 2*math.Pi, 41)
+		Reset()
+		fn := "/tmp/gosl/t_animation02.gif"
+		err := Animate(5, 5, fn, func(i int) {
+			y := make([]float64, len(x))
+			for j := 0; j < len(x); j++ {
+				y[j] = math.Sin(x[j] - float64(i)*math.Pi/5)
+			}
+			Plot(x, y, &A{C: "r"})
+			AxisRange(0, 2*math.Pi, -1.1, 1.1)
+		})
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		if _, err = os.Stat(fn); err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,954 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_backend01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("backend01. parse diagnostic output")
+
+	info := parseBackendInfo("python=3.9.1\nnumpy=1.20.0\nmatplotlib=3.3.4\nbackend=TkAgg\n")
+	chk.String(tst, info.PyVersion, "3.9.1")
+	chk.String(tst, info.Numpy, "1.20.0")
+	chk.String(tst, info.Matplotlib, "3.3.4")
+	chk.String(tst, info.Backend, "TkAgg")
+
+	info = parseBackendInfo("python=2.7.18\nnumpy=\nmatplotlib=\n")
+	chk.String(tst, info.PyVersion, "2.7.18")
+	chk.String(tst, info.Numpy, "")
+	chk.String(tst, info.Matplotlib, "")
+
+	// Windows line endings
+	info = parseBackendInfo("python=3.8.5\r\nnumpy=1.19.2\r\nmatplotlib=3.3.2\r\nbackend=TkAgg\r\n")
+	chk.String(tst, info.PyVersion, "3.8.5")
+	chk.String(tst, info.Numpy, "1.19.2")
+	chk.String(tst, info.Matplotlib, "3.3.2")
+	chk.String(tst, info.Backend, "TkAgg")
+
+	// messages printed by sitecustomize or matplotlib and unknown keys are ignored
+	info = parseBackendInfo("Matplotlib is building the font cache; this may take a moment.\n" +
+		"python=3.10.4\nPYTHONPATH=/opt/lib\nnumpy=1.22.3\nmatplotlib=3.5.1\n  backend=module://ipykernel.pylab.backend_inline\n")
+	chk.String(tst, info.PyVersion, "3.10.4")
+	chk.String(tst, info.Numpy, "1.22.3")
+	chk.String(tst, info.Matplotlib, "3.5.1")
+	chk.String(tst, info.Backend, "module://ipykernel.pylab.backend_inline")
+
+	// nothing printed
+	info = parseBackendInfo("")
+	chk.String(tst, info.PyVersion, "")
+	chk.String(tst, info.Backend, "")
+}
+
+func Test_backend02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("backend02. fake interpreter and Agg injection")
+
+	// fake interpreter: prints diagnostic output or copies the script
+	dir := "/tmp/gosl"
+	os.MkdirAll(dir, 0777)
+	fake := func(name, mplVersion string) string {
+		fn := dir + "/" + name
+		io.WriteFileS(fn, "#!/bin/sh\n"+
+			"if [ \"$1\" = \"-c\" ]; then\n"+
+			"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib="+mplVersion+"; echo backend=agg\n"+
+			"else\n"+
+			"  cp \"$1\" "+dir+"/fakepython.out\n"+
+			"fi\n")
+		os.Chmod(fn, 0755)
+		return fn
+	}
+
+	// restore state at the end
+	oldCmd, oldDisplay, oldWayland, oldBackend := pythonCmd, os.Getenv("DISPLAY"), os.Getenv("WAYLAND_DISPLAY"), os.Getenv("MPLBACKEND")
+	defer func() {
+		pythonCmd, backendInfo = oldCmd, nil
+		os.Setenv("DISPLAY", oldDisplay)
+		os.Setenv("WAYLAND_DISPLAY", oldWayland)
+		os.Setenv("MPLBACKEND", oldBackend)
+	}()
+	os.Setenv("DISPLAY", "")
+	os.Setenv("WAYLAND_DISPLAY", "")
+	os.Setenv("MPLBACKEND", "")
+
+	// headless
+	pythonCmd, backendInfo = fake("fakepython.sh", "3.3.4"), nil
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err := Save(dir + "/fake.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if !backendInfo.UseAgg || backendInfo.Matplotlib != "3.3.4" {
+		tst.Errorf("backend info is incorrect: %+v\n", backendInfo)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	if !strings.HasPrefix(string(b), "import matplotlib\nmatplotlib.use('Agg')\n") {
+		tst.Errorf("script should start with matplotlib.use('Agg'):\n%s\n", string(b))
+		return
+	}
+
+	// override
+	os.Setenv("MPLBACKEND", "pdf")
+	backendInfo = nil
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err = Save(dir + "/fake.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	if strings.Contains(string(b), "matplotlib.use(") {
+		tst.Errorf("script should not contain matplotlib.use:\n%s\n", string(b))
+		return
+	}
+
+	// matplotlib not found
+	pythonCmd, backendInfo = fake("fakepython_nompl.sh", ""), nil
+	err = Save(dir + "/fake.png")
+	if err == nil || !strings.Contains(err.Error(), "matplotlib not found for "+pythonCmd) {
+		tst.Errorf("Save should have failed with actionable error. err = %v\n", err)
+		return
+	}
+	io.Pforan("%v\n", err)
+
+	// executable not found
+	pythonCmd, backendInfo = "/nonexistent/python", nil
+	_, err = CheckBackend()
+	if err == nil || !strings.Contains(err.Error(), "/nonexistent/python") {
+		tst.Errorf("CheckBackend should have failed with the attempted command. err = %v\n", err)
+		return
+	}
+}
+
+func Test_figures01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("figures01. multiple figures saved with one call to Python")
+
+	// fake interpreter: counts the calls and copies the script
+	dir := "/tmp/gosl"
+	os.MkdirAll(dir, 0777)
+	fake := dir + "/fakepython_count.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  echo run >> "+dir+"/fakepython_count.out\n"+
+		"  cp \"$1\" "+dir+"/fakepython.out\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	os.Remove(dir + "/fakepython_count.out")
+	oldCmd := pythonCmd
+	defer func() { pythonCmd, backendInfo = oldCmd, nil }()
+	pythonCmd, backendInfo = fake, nil
+
+	// two figures
+	Reset()
+	Figure(1)
+	Plot([]float64{0, 1}, []float64{0, 1}, &A{C: "r", L: "first"})
+	Legend(nil)
+	Figure(2)
+	Plot([]float64{0, 1}, []float64{1, 0}, &A{C: "b"})
+	err := SaveFigures(dir, []int{1, 2}, []string{"t_figures01a.png", "t_figures01b.png"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython_count.out")
+	chk.String(tst, string(b), "run\n")
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	script := string(b)
+	for _, cmd := range []string{
+		"plt.figure(1)\nplt.savefig(r'/tmp/gosl/t_figures01a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n",
+		"plt.figure(2)\nplt.savefig(r'/tmp/gosl/t_figures01b.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n",
+	} {
+		if !strings.Contains(script, cmd) {
+			tst.Errorf("script does not contain %q:\n%v\n", cmd, script)
+			return
+		}
+	}
+
+	// save one figure by id
+	CloseFigure(2)
+	err = Save(dir+"/t_figures01c.png", 1)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	if !strings.HasSuffix(string(b), "plt.close(2)\nplt.figure(1)\nplt.savefig(r'/tmp/gosl/t_figures01c.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n") {
+		tst.Errorf("script should end with saving figure 1:\n%s\n", string(b))
+		return
+	}
+
+	// error
+	if SaveFigures(dir, []int{1}, nil) == nil {
+		tst.Errorf("SaveFigures should have failed with wrong number of file names\n")
+	}
+}
+
+// useFakePython sets pythonCmd to a fake interpreter that copies the script to dir/fakepython.out.
+// It returns a function to restore the previous interpreter
+func useFakePython(dir string) (restore func()) {
+	os.MkdirAll(dir, 0777)
+	fn := dir + "/fakepython_copy.sh"
+	io.WriteFileS(fn, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  cp \"$1\" "+dir+"/fakepython.out\n"+
+		"fi\n")
+	os.Chmod(fn, 0755)
+	oldCmd := pythonCmd
+	pythonCmd, backendInfo = fn, nil
+	return func() { pythonCmd, backendInfo = oldCmd, nil }
+}
+
+func Test_layout01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("layout01. tight and constrained layouts")
+
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	defer restore()
+	defer SetLayout(nil)
+
+	// default
+	Reset()
+	defaultPlotter.saveFig("a.png", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.savefig(r'a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n")
+
+	// tight layout
+	SetLayout(&Layout{Tight: true, Wpad: 2, Hpad: 0.5})
+	Reset()
+	defaultPlotter.saveFig("a.png", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.tight_layout(w_pad=2,h_pad=0.5)\n"+
+		"plt.savefig(r'a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n")
+
+	// tight layout with defaults and without bbox_inches
+	SetLayout(&Layout{Tight: true, NoBboxTight: true})
+	Reset()
+	defaultPlotter.saveFig("a.png", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.tight_layout()\nplt.savefig(r'a.png')\n")
+
+	// constrained layout
+	SetLayout(&Layout{Constrained: true})
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err := Save(dir + "/t_layout01.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	script := string(b)
+	if !strings.Contains(script, "plt.rcParams['figure.constrained_layout.use'] = True\nx0=") {
+		tst.Errorf("constrained layout should have been set before plotting:\n%v\n", script)
+		return
+	}
+	if strings.Contains(script, "tight_layout") {
+		tst.Errorf("tight layout should not have been used:\n%v\n", script)
+		return
+	}
+
+	// back to default
+	SetLayout(nil)
+	Reset()
+	err = Save(dir + "/t_layout01.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	if strings.Contains(string(b), "constrained_layout") {
+		tst.Errorf("constrained layout should not have been set:\n%v\n", string(b))
+	}
+}
+
+func Test_save01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("save01. save with options")
+
+	for i, c := range []struct {
+		args *SaveArgs
+		cmd  string
+	}{
+		{nil, "plt.savefig(r'a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n"},
+		{&SaveArgs{}, "plt.savefig(r'a.png')\n"},
+		{&SaveArgs{Dpi: 300, Transparent: true}, "plt.savefig(r'a.png', dpi=300, transparent=True)\n"},
+		{&SaveArgs{Crop: true, PadInches: 0.02, Facecolor: "#eeeeee"}, "plt.savefig(r'a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()), pad_inches=0.02, facecolor='#eeeeee')\n"},
+		{&SaveArgs{PadInches: 0.02, Dpi: 150}, "plt.savefig(r'a.png', dpi=150)\n"},
+	} {
+		Reset()
+		defaultPlotter.saveFig("a.png", c.args)
+		if defaultPlotter.bufferPy.String() != c.cmd {
+			tst.Errorf("case %d: savefig command is incorrect:\n%q\n!=\n%q\n", i, defaultPlotter.bufferPy.String(), c.cmd)
+			return
+		}
+	}
+
+	// SaveA
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	defer restore()
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err := SaveA(dir+"/t_save01.png", &SaveArgs{Dpi: 200, Crop: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	if !strings.HasSuffix(string(b), "plt.savefig(r'/tmp/gosl/t_save01.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()), dpi=200)\n") {
+		tst.Errorf("script should end with savefig with options:\n%v\n", string(b))
+	}
+}
+
+func Test_save02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("save02. save to multiple formats")
+
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	n := defaultPlotter.bufferPy.Len()
+	err := SaveMulti([]string{dir + "/t_save02.png", dir + "/t_save02.pdf"})
+	restore()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	cmds := io.Sf("failed%d = []\n", n) +
+		"try: plt.savefig(r'/tmp/gosl/t_save02.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n" +
+		io.Sf("except Exception as e: failed%d.append(r'/tmp/gosl/t_save02.png: ' + str(e))\n", n) +
+		"try: plt.savefig(r'/tmp/gosl/t_save02.pdf', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n" +
+		io.Sf("except Exception as e: failed%d.append(r'/tmp/gosl/t_save02.pdf: ' + str(e))\n", n) +
+		io.Sf("if len(failed%d) > 0: raise RuntimeError('cannot save files:\\n' + '\\n'.join(failed%d))\n", n, n)
+	if !strings.HasSuffix(string(b), cmds) {
+		tst.Errorf("script should end with:\n%v\n", cmds)
+		return
+	}
+
+	// errors
+	if SaveMulti(nil) == nil {
+		tst.Errorf("SaveMulti should have failed without file names\n")
+		return
+	}
+	if SaveMulti([]string{"a.png", "b"}) == nil {
+		tst.Errorf("SaveMulti should have failed with file name without extension\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Plot([]float64{0, 1, 2}, []float64{0, 1, 0}, &A{C: "r", M: "o"})
+		err = SaveMulti([]string{dir + "/t_save02.png", dir + "/t_save02.pdf"})
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		for _, fn := range []string{dir + "/t_save02.png", dir + "/t_save02.pdf"} {
+			if _, err = os.Stat(fn); err != nil {
+				tst.Errorf("%v", err)
+			}
+		}
+	}
+}
+
+func Test_save03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("save03. save to writer")
+
+	// fake interpreter: prints some output and the header of a PNG file
+	dir := "/tmp/gosl"
+	os.MkdirAll(dir, 0777)
+	fake := dir + "/fakepython_payload.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  cp \"$1\" "+dir+"/fakepython.out\n"+
+		"  echo hello\n"+
+		"  echo '"+payloadMark+"iVBORw0KGgo="+payloadMark+"'\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	oldCmd := pythonCmd
+	pythonCmd, backendInfo = fake, nil
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	nbuf := defaultPlotter.bufferPy.Len()
+	var w bytes.Buffer
+	err := SaveToWriter(&w, "png")
+	pythonCmd, backendInfo = oldCmd, nil
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, w.String(), "\x89PNG\r\n\x1a\n")
+	chk.Int(tst, "buffer length", defaultPlotter.bufferPy.Len(), nbuf)
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	if !strings.Contains(string(b), "plt.savefig(payload, format='png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n") {
+		tst.Errorf("script should save figure to bytes:\n%v\n", string(b))
+		return
+	}
+
+	// error
+	if SaveToWriter(&w, "") == nil {
+		tst.Errorf("SaveToWriter should have failed without format\n")
+		return
+	}
+
+	// round trip
+	if chk.Verbose {
+		Reset()
+		Plot([]float64{0, 1, 2}, []float64{0, 1, 0}, &A{C: "r"})
+		w.Reset()
+		err = SaveToWriter(&w, "png")
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		cfg, err := png.DecodeConfig(&w)
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		io.Pf("width = %d, height = %d\n", cfg.Width, cfg.Height)
+	}
+}
+
+func Test_save04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("save04. svg")
+
+	Reset()
+	defer SetRc(nil)
+	SetForSvg(0.75, 300, &A{Fsz: 9})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.rcdefaults()\nplt.rcParams.update({\n    'figure.figsize'  : [4.151100041511,3.1133250311332503],\n    'font.size'       : 9,\n") ||
+		!strings.HasSuffix(defaultPlotter.bufferPy.String(), "    'svg.fonttype'    : 'none'})\n") {
+		tst.Errorf("SetForSvg commands are incorrect:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// text is kept as text when saving svg files
+	SetRc(nil)
+	defaultPlotter.saveFig("a.SVG", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.rcParams['svg.fonttype'] = 'none'\n"+
+		"plt.savefig(r'a.SVG', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n")
+
+	if chk.Verbose {
+		SetForSvg(0.75, 300, nil)
+		Plot([]float64{0, 1, 2}, []float64{0, 1, 0}, &A{C: "r", L: "data"})
+		Gll("x", "y", nil)
+		fn := "/tmp/gosl/t_save04.svg"
+		err := Save(fn)
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		b, err := io.ReadFile(fn)
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		if len(b) == 0 || !strings.Contains(string(b), "<text") {
+			tst.Errorf("svg file should contain text elements\n")
+		}
+	}
+}
+
+func Test_save05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("save05. figure and font sizes kept by Reset")
+
+	defer SetRc(nil)
+	rcPng := "plt.rcdefaults()\nplt.rcParams.update({\n" +
+		"    'figure.figsize'  : [5,4],\n" +
+		"    'savefig.dpi'     : 150,\n" +
+		"    'font.size'       : 9,\n" +
+		"    'axes.labelsize'  : 10,\n" +
+		"    'legend.fontsize' : 9,\n" +
+		"    'xtick.labelsize' : 8,\n" +
+		"    'ytick.labelsize' : 8})\n"
+	rcFonts := "plt.rcParams.update({\n" +
+		"    'font.size'       : 12,\n" +
+		"    'axes.labelsize'  : 11,\n" +
+		"    'legend.fontsize' : 9,\n" +
+		"    'xtick.labelsize' : 8,\n" +
+		"    'ytick.labelsize' : 8})\n"
+
+	// settings are applied again after Reset
+	SetForPng(0.75, 400, 150, &A{Fsz: 9})
+	chk.String(tst, defaultPlotter.bufferPy.String(), rcPng)
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	SetFontSizes(&A{Fsz: 12, FszLbl: 11})
+	Reset()
+	chk.String(tst, defaultPlotter.bufferPy.String(), rcPng+rcFonts)
+	defaultPlotter.saveFig("a.png", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), rcPng+rcFonts+
+		"plt.savefig(r'a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n")
+
+	// idempotent
+	SetForPng(0.75, 400, 150, &A{Fsz: 9})
+	SetForPng(0.75, 400, 150, &A{Fsz: 9})
+	Reset()
+	Reset()
+	chk.String(tst, defaultPlotter.bufferPy.String(), rcPng)
+
+	// SetForEps replaces the settings
+	SetForEps(0.75, 400, nil)
+	Reset()
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "'backend'            : 'ps'") || !strings.Contains(txt, "'savefig.dpi'        : 300,") {
+		tst.Errorf("eps settings should have replaced png settings:\n%v\n", txt)
+		return
+	}
+
+	// defaults
+	SetRc(nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "")
+	Reset()
+	chk.String(tst, defaultPlotter.bufferPy.String(), "")
+
+	// plotters keep their own settings
+	p := NewPlotter()
+	SetForPng(0.75, 400, 150, &A{Fsz: 9})
+	p.Reset()
+	chk.String(tst, p.bufferPy.String(), "")
+}
+
+func Test_save06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("save06. pdf with LaTeX text")
+
+	Reset()
+	defer SetRc(nil)
+	SetForPdfTex(0.75, 300, &A{Fsz: 9, TexFamily: "sans-serif"})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.rcdefaults()\nplt.rcParams.update({\n    'figure.figsize'  : [4.151100041511,3.1133250311332503],\n    'font.size'       : 9,\n") ||
+		!strings.HasSuffix(defaultPlotter.bufferPy.String(), "    'pgf.texsystem'   : 'pdflatex',\n"+
+			"    'pgf.rcfonts'     : False,\n"+
+			"    'font.family'     : 'sans-serif'})\n") {
+		tst.Errorf("SetForPdfTex commands are incorrect:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// pgf backend for pdf files only
+	nbuf := defaultPlotter.bufferPy.Len()
+	defaultPlotter.saveFig("a.PDF", nil)
+	defaultPlotter.saveFig("a.png", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String()[nbuf:], "plt.savefig(r'a.PDF', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()), backend='pgf')\n"+
+		"plt.savefig(r'a.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n")
+
+	// missing LaTeX executable
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	defer restore()
+	os.Remove(dir + "/fakepython.out")
+	SetForPdfTex(0.75, 300, &A{TexSystem: "gosl-missing-latex"})
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err := Save(dir + "/t_save06.pdf")
+	if err == nil || !strings.Contains(err.Error(), "cannot find LaTeX executable \"gosl-missing-latex\"") {
+		tst.Errorf("Save should have failed with a clear message:\n%v\n", err)
+		return
+	}
+	if _, err := os.Stat(dir + "/fakepython.out"); err == nil {
+		tst.Errorf("Python should not have been called\n")
+		return
+	}
+
+	// other settings do not require LaTeX
+	SetRc(nil)
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err = Save(dir + "/t_save06.pdf")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	restore()
+
+	if chk.Verbose {
+		if _, err := exec.LookPath("pdflatex"); err != nil {
+			tst.Skipf("pdflatex is not available:\n%v", err)
+		}
+		SetForPdfTex(0.75, 300, nil)
+		Plot([]float64{0, 1, 2}, []float64{0, 1, 0}, &A{C: "r", L: `$\sigma_{\mathrm{max}}$`})
+		Gll(`$x$`, `$y$`, nil)
+		fn := dir + "/t_save06.pdf"
+		err := Save(fn)
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		b, err := io.ReadFile(fn)
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		if !strings.HasPrefix(string(b), "%PDF") {
+			tst.Errorf("file should be a PDF\n")
+		}
+	}
+}
+
+func Test_show01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("show01. show and save")
+
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	defer restore()
+
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err := ShowAndSave(dir + "/t_show01.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython.out")
+	if !strings.HasSuffix(string(b), "plt.plot(x0,y0)\n"+
+		"plt.savefig(r'/tmp/gosl/t_show01.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n"+
+		"plt.show()\n") {
+		tst.Errorf("savefig should come before show:\n%v\n", string(b))
+		return
+	}
+
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	err = ShowNonBlocking(0.5)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	if !strings.HasSuffix(string(b), "plt.plot(x0,y0)\nplt.show(block=False)\nplt.pause(0.5)\n") {
+		tst.Errorf("non-blocking show is incorrect:\n%v\n", string(b))
+	}
+}
+
+func Test_export01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("export01. export script without running Python")
+
+	oldCmd := pythonCmd
+	defer func() { pythonCmd, backendInfo = oldCmd, nil }()
+	pythonCmd, backendInfo = "/tmp/gosl/python-does-not-exist", nil
+
+	Reset()
+	EaCmds("SETUP=1\n")
+	Plot([]float64{0, 1}, []float64{0, 1}, &A{C: "r"})
+	script := Script()
+	chk.String(tst, script, defaultPlotter.bufferEa.String()+defaultPlotter.bufferPy.String())
+	if !strings.HasSuffix(script, "SETUP=1\nx0=np.array([0,1,],dtype=float)\ny0=np.array([0,1,],dtype=float)\nplt.plot(x0,y0, color='r')\n") {
+		tst.Errorf("script is incorrect:\n%v\n", script)
+		return
+	}
+
+	fn := "/tmp/gosl/t_export01.py"
+	os.MkdirAll("/tmp/gosl", 0777)
+	err := ExportPy(fn)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ := io.ReadFile(fn)
+	chk.String(tst, string(b), script)
+
+	// constrained layout is included as done by run; but Agg does not depend on the machine
+	backendInfo = &BackendInfo{UseAgg: true}
+	SetLayout(&Layout{Constrained: true})
+	defer SetLayout(nil)
+	if !strings.HasPrefix(Script(), "### file generated by Gosl") ||
+		!strings.Contains(Script(), "SETUP=1\nplt.rcParams['figure.constrained_layout.use'] = True\nx0=") {
+		tst.Errorf("script should include constrained layout but not Agg:\n%v\n", Script())
+		return
+	}
+
+	// Agg is added when Python is called, even if CheckBackend was not called before
+	dir := "/tmp/gosl"
+	restore := useFakePython(dir)
+	defer restore()
+	script = Script()
+	err = defaultPlotter.run("")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython.out")
+	chk.String(tst, string(b), pythonAgg+script)
+
+	// error
+	if ExportPy("/tmp/gosl/dir-does-not-exist/a.py") == nil {
+		tst.Errorf("ExportPy should have failed\n")
+	}
+}
+
+func Test_backend03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("backend03. python command and environment")
+
+	// fake interpreter: writes the arguments and the environment
+	dir := "/tmp/gosl"
+	os.MkdirAll(dir, 0777)
+	fake := dir + "/fakepython_env.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"  echo \"$MPLBACKEND\" > "+dir+"/fakepython_env.diag\n"+
+		"else\n"+
+		"  echo \"argv=$1\" > "+dir+"/fakepython_env.out\n"+
+		"  echo \"GOSL_TEST_VAR=$GOSL_TEST_VAR\" >> "+dir+"/fakepython_env.out\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	oldCmd, oldEnv := pythonCmd, pythonEnv
+	defer func() {
+		pythonCmd, pythonEnv, backendInfo = oldCmd, oldEnv, nil
+	}()
+
+	SetPythonCmd(fake)
+	SetPythonEnv([]string{"GOSL_TEST_VAR=hello", "MPLBACKEND=svg"})
+	info, err := CheckBackend()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if info.UseAgg {
+		tst.Errorf("Agg should not be used because MPLBACKEND is given\n")
+		return
+	}
+	b, _ := io.ReadFile(dir + "/fakepython_env.diag")
+	chk.String(tst, string(b), "svg\n")
+
+	Reset()
+	err = Save(dir + "/t_backend03.png")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	b, _ = io.ReadFile(dir + "/fakepython_env.out")
+	if !strings.HasPrefix(string(b), "argv="+filepath.Join(os.TempDir(), "pltgosl-")) || !strings.HasSuffix(string(b), ".py\nGOSL_TEST_VAR=hello\n") {
+		tst.Errorf("arguments or environment are incorrect:\n%v\n", string(b))
+		return
+	}
+
+	// command not found
+	SetPythonCmd(dir + "/python-does-not-exist")
+	_, err = CheckBackend()
+	if err == nil || !strings.Contains(err.Error(), "python-does-not-exist") {
+		tst.Errorf("error should name the command:\n%v\n", err)
+		return
+	}
+	backendInfo = &BackendInfo{}
+	err = defaultPlotter.run("")
+	if err == nil || !strings.Contains(err.Error(), "python-does-not-exist") {
+		tst.Errorf("error should name the command:\n%v\n", err)
+	}
+}
+
+func Test_backend04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("backend04. unique temporary scripts")
+
+	// fake interpreter: waits a little and records the name and contents of the script
+	dir := "/tmp/gosl/backend04"
+	os.RemoveAll(dir)
+	os.MkdirAll(dir+"/tmp", 0777)
+	fake := dir + "/fakepython.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  sleep 0.2\n"+
+		"  cp \"$1\" "+dir+"/$(basename \"$1\").copy\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	oldCmd := pythonCmd
+	defer func() {
+		pythonCmd, backendInfo = oldCmd, nil
+		SetTempDir("")
+		SetKeepTempFiles(false)
+	}()
+	SetPythonCmd(fake)
+	SetTempDir(dir + "/tmp")
+
+	// concurrent runs
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	_, err := CheckBackend()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	script := pythonAgg + Script() // Agg is added when Python is called
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = defaultPlotter.runPy(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	for _, e := range errs {
+		if e != nil {
+			tst.Errorf("%v", e)
+			return
+		}
+	}
+	copies, _ := filepath.Glob(dir + "/pltgosl-*.py.copy")
+	chk.Int(tst, "number of scripts", len(copies), 2)
+	for _, fn := range copies {
+		b, _ := io.ReadFile(fn)
+		chk.String(tst, string(b), script)
+	}
+	left, _ := filepath.Glob(dir + "/tmp/*")
+	chk.Int(tst, "number of temporary files left", len(left), 0)
+
+	// keep files
+	SetKeepTempFiles(true)
+	_, err = defaultPlotter.runPy(context.Background())
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	left, _ = filepath.Glob(dir + "/tmp/pltgosl-*.py")
+	chk.Int(tst, "number of temporary files kept", len(left), 1)
+}
+
+func Test_backend05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("backend05. timeout")
+
+	// fake interpreter: sleeps after printing something to stderr
+	dir := "/tmp/gosl"
+	os.MkdirAll(dir, 0777)
+	fake := dir + "/fakepython_sleep.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  echo 'waiting for window' >&2\n"+
+		"  exec sleep 5\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	oldCmd := pythonCmd
+	defer func() { pythonCmd, backendInfo = oldCmd, nil }()
+	SetPythonCmd(fake)
+
+	Reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	t0 := time.Now()
+	err := SaveCtx(ctx, dir+"/t_backend05.png")
+	if err == nil {
+		tst.Errorf("SaveCtx should have failed\n")
+		return
+	}
+	if time.Since(t0) > 4*time.Second {
+		tst.Errorf("Python should have been killed\n")
+		return
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "deadline exceeded") || !strings.Contains(msg, "waiting for window") {
+		tst.Errorf("error should report the timeout and the end of stderr:\n%v\n", msg)
+	}
+}
+
+func Test_backend06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("backend06. Go origin of lines in Python traceback")
+
+	// fake interpreter: reports an error at the line with an invalid color, as matplotlib does
+	dir := "/tmp/gosl"
+	os.MkdirAll(dir, 0777)
+	fake := dir + "/fakepython_traceback.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  n=$(grep -n 'undefined_color' \"$1\" | cut -d: -f1)\n"+
+		"  echo 'Traceback (most recent call last):' >&2\n"+
+		"  echo \"  File \\\"$1\\\", line $n, in <module>\" >&2\n"+
+		"  echo '  File \"/usr/lib/python3/dist-packages/matplotlib/pyplot.py\", line 2840, in plot' >&2\n"+
+		"  echo \"ValueError: 'undefined_color' is not a valid value for color\" >&2\n"+
+		"  exit 1\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	oldCmd := pythonCmd
+	defer func() { pythonCmd, backendInfo = oldCmd, nil }()
+	SetPythonCmd(fake)
+
+	// figure with an error in the second call
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	_, _, line, _ := runtime.Caller(0)
+	Plot([]float64{0, 1}, []float64{1, 0}, &A{C: "undefined_color"})
+	Text(0.5, 0.5, "hello", nil)
+	err := Save(dir + "/t_backend06.png")
+	if err == nil {
+		tst.Errorf("Save should have failed\n")
+		return
+	}
+	msg := err.Error()
+	io.Pforan("%v\n", msg)
+	origin := io.Sf("generated by plt.Plot called at t_backend_test.go:%d", line+1)
+	if !strings.Contains(msg, origin) {
+		tst.Errorf("error should contain %q:\n%v\n", origin, msg)
+	}
+
+	// lines generated by helpers are reported as generated by the function called from outside plt
+	Reset()
+	AxisOff()
+	_, _, line, _ = runtime.Caller(0)
+	HeatmapAnnotated([][]float64{{1, 2}, {3, 4}}, nil, nil, "", nil)
+	Gll("x", "y", nil)
+	found := ""
+	for i, l := range strings.Split(defaultPlotter.bufferPy.String(), "\n") {
+		if strings.HasPrefix(l, "plt.text(") {
+			found = defaultPlotter.pyOriginAt(i + 1)
+			break
+		}
+	}
+	chk.String(tst, found, io.Sf("plt.HeatmapAnnotated called at t_backend_test.go:%d", line+1))
+	chk.String(tst, defaultPlotter.pyOriginAt(1), io.Sf("plt.AxisOff called at t_backend_test.go:%d", line-1))
+	chk.String(tst, defaultPlotter.pyOriginAt(1000), "")
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_boxplot01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("boxplot01. boxplot from statistics")
+
+	stats := []BoxStats{
+		{Med: 5, Q1: 4, Q3: 6.5, WhisLo: 1, WhisHi: 9},
+		{Med: 3, Q1: 2.5, Q3: 4, WhisLo: 2, WhisHi: 5, Fliers: []float64{0.5, 8}},
+	}
+
+	Reset()
+	err := BoxplotStats(stats, []string{"A", "B"}, &A{Fc: "#ccccff"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferPy.String(), "st0=["+
+		"{'med':5,'q1':4,'q3':6.5,'whislo':1,'whishi':9,'fliers':[],'label':'A'},"+
+		"{'med':3,'q1':2.5,'q3':4,'whislo':2,'whishi':5,'fliers':[0.5,8],'label':'B'},]\n"+
+		"plt.gca().bxp(st0,patch_artist=True,boxprops={'facecolor':'#ccccff'})\n")
+
+	// errors
+	if BoxplotStats(stats, []string{"A"}, nil) == nil {
+		tst.Errorf("BoxplotStats should have failed with wrong number of labels\n")
+		return
+	}
+	if BoxplotStats([]BoxStats{{Med: 1, Q1: 2, Q3: 3, WhisLo: 0, WhisHi: 4}}, nil, nil) == nil {
+		tst.Errorf("BoxplotStats should have failed with inconsistent statistics\n")
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_boxplot01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
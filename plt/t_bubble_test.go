@@ -0,0 +1,66 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_bubble01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("bubble01. bubble chart")
+
+	x := []float64{0, 1, 2}
+	y := []float64{1, 3, 2}
+	s := []float64{25, 100, 400}
+
+	Reset()
+	err := Bubble(x, y, s, &A{Colors: []string{"r", "g", "b"}, Alpha: 0.5, L: "data"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	BubbleLegend(s, "%.0f", &A{LegLoc: "upper left"})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"s0=np.array([25,100,400,],dtype=float)",
+		"plt.scatter(x0,y0,s=s0,c=['r','g','b'], label='data',alpha=0.5)",
+		"lns.Line2D([], [], ls='none', marker='o', ms=5, color='gray', label='25')",
+		"ms=14.577379737113251, color='gray', label='212')",
+		"ms=20, color='gray', label='400')",
+		"loc='upper left'",
+		"plt.gca().add_artist(l",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// errors
+	if Bubble(x, y, s[:2], nil) == nil {
+		tst.Errorf("Bubble should have failed with unequal lengths\n")
+		return
+	}
+	if Bubble(x, y, s, &A{Colors: []string{"r"}}) == nil {
+		tst.Errorf("Bubble should have failed with wrong number of colors\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Bubble(x, y, s, &A{C: "b", Alpha: 0.5, Mec: "k"})
+		BubbleLegend(s, "%.0f", nil)
+		AxisRange(-1, 3, 0, 4)
+		err := SaveD("/tmp/gosl", "t_bubble01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
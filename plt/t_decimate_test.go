@@ -0,0 +1,160 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// checkDecimated checks the indices returned by decimateMinMax
+func checkDecimated(tst *testing.T, idx []int, n, maxPts int) {
+	if len(idx) > maxPts {
+		tst.Errorf("too many points: %d > %d\n", len(idx), maxPts)
+	}
+	if idx[0] != 0 || idx[len(idx)-1] != n-1 {
+		tst.Errorf("first and last points must be kept\n")
+	}
+	if !sort.IntsAreSorted(idx) {
+		tst.Errorf("indices must be sorted\n")
+	}
+	for k := 1; k < len(idx); k++ {
+		if idx[k] == idx[k-1] {
+			tst.Errorf("indices must not be repeated\n")
+			return
+		}
+	}
+}
+
+func Test_decimate01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("decimate01. peaks are preserved")
+
+	// short series
+	if decimateMinMax(nil, []float64{1, 2, 3}, 10) != nil {
+		tst.Errorf("short series should not be decimated\n")
+	}
+	if decimateMinMax([]float64{1, 2}, utl.LinSpace(0, 1, 100), 10) != nil {
+		tst.Errorf("series with wrong number of abscissae should not be decimated\n")
+	}
+
+	// noisy signal with spikes
+	n, maxPts := 100000, 1000
+	x := utl.LinSpace(0, 100, n)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		y[i] = math.Sin(x[i]) + 0.1*math.Sin(37*x[i])
+	}
+	y[54321], y[7777] = 10, -8
+	idx := decimateMinMax(x, y, maxPts)
+	checkDecimated(tst, idx, n, maxPts)
+	found := map[int]bool{}
+	for _, i := range idx {
+		found[i] = true
+	}
+	if !found[54321] || !found[7777] {
+		tst.Errorf("spikes must be kept\n")
+	}
+	ymin, ymax := utl.DblMinMax(y)
+	ydmin, ydmax := utl.DblMinMax(pickPoints(y, idx))
+	chk.Scalar(tst, "min", 1e-15, ydmin, ymin)
+	chk.Scalar(tst, "max", 1e-15, ydmax, ymax)
+
+	// minimum number of points
+	idx = decimateMinMax(nil, y, 1)
+	checkDecimated(tst, idx, n, 4)
+	chk.Ints(tst, "idx", idx, []int{0, 7777, 54321, n - 1})
+}
+
+func Test_decimate02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("decimate02. monotone and non-monotone x")
+
+	// dense samples in [0,1] and sparse samples in [1,10]
+	x := append(utl.LinSpace(0, 1, 9000), utl.LinSpace(1.01, 10, 1000)...)
+	y := make([]float64, len(x))
+	for i := range x {
+		y[i] = math.Cos(3 * x[i])
+	}
+	maxPts := 200
+	idx := decimateMinMax(x, y, maxPts)
+	checkDecimated(tst, idx, len(x), maxPts)
+	var ydmin, ydmax float64
+	nsparse := 0
+	for _, i := range idx {
+		if x[i] > 1 {
+			nsparse++
+		}
+	}
+	if nsparse < 150 {
+		tst.Errorf("buckets should have the same width along x: only %d points in (1,10]\n", nsparse)
+	}
+
+	// decreasing x
+	xr := make([]float64, len(x))
+	for i := range x {
+		xr[i] = -x[i]
+	}
+	chk.Ints(tst, "idx (decreasing x)", decimateMinMax(xr, y, maxPts), idx)
+
+	// non-monotone x: buckets with the same number of points
+	n := 10000
+	xc, yc := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		t := 4 * math.Pi * float64(i) / float64(n-1)
+		xc[i], yc[i] = math.Cos(t), math.Sin(t)
+	}
+	idx = decimateMinMax(xc, yc, maxPts)
+	checkDecimated(tst, idx, n, maxPts)
+	chk.Ints(tst, "idx (non-monotone x)", idx, decimateMinMax(nil, yc, maxPts))
+	ydmin, ydmax = utl.DblMinMax(pickPoints(yc, idx))
+	chk.Scalar(tst, "min", 1e-6, ydmin, -1)
+	chk.Scalar(tst, "max", 1e-6, ydmax, 1)
+
+	// gaps are kept
+	yc[5000] = math.NaN()
+	idx = decimateMinMax(nil, yc, maxPts)
+	checkDecimated(tst, idx, n, maxPts+3)
+	if !math.IsNaN(pickPoints(yc, idx)[sort.SearchInts(idx, 5000)]) {
+		tst.Errorf("NaN must be kept\n")
+	}
+}
+
+func Test_decimate03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("decimate03. Plot and Plot3dLine with MaxPoints")
+
+	n := 1000
+	x := utl.LinSpace(0, 1, n)
+	y := make([]float64, n)
+	y[500] = 1
+	Reset()
+	Plot(x, y, &A{MaxPoints: 10})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "y0=np.array([0,0,0,1,0,0,0,],dtype=float)") {
+		tst.Errorf("ordinates should have been decimated:\n%v\n", txt)
+	}
+
+	Reset()
+	Plot3dLine(x, x, y, true, &A{MaxPoints: 10})
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Count(txt, ",") > 3*10+30 || !strings.Contains(txt, ",1,") {
+		tst.Errorf("3D line should have been decimated:\n%v\n", txt)
+	}
+
+	Reset()
+	Plot(x, y, nil)
+	if strings.Count(defaultPlotter.bufferPy.String(), ",") < 2*n {
+		tst.Errorf("series should not have been decimated without MaxPoints\n")
+	}
+}
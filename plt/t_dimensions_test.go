@@ -0,0 +1,107 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_dims01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dims01. angular dimension")
+
+	s := math.Sqrt(2) / 2
+	ends, dirs, lbl := angleDimGeometry(1, 2, 2, 0, 90)
+	chk.Matrix(tst, "ends", 1e-15, ends, [][]float64{{3, 2}, {1, 4}})
+	chk.Matrix(tst, "dirs", 1e-15, dirs, [][]float64{{0, -1}, {-1, 0}})
+	chk.Vector(tst, "lbl", 1e-15, lbl, []float64{1 + 2.4*s, 2 + 2.4*s})
+
+	ends, dirs, lbl = angleDimGeometry(0, 0, 1, 45, 180)
+	chk.Matrix(tst, "ends", 1e-15, ends, [][]float64{{s, s}, {-1, 0}})
+	chk.Matrix(tst, "dirs", 1e-15, dirs, [][]float64{{s, -s}, {0, -1}})
+	chk.Vector(tst, "lbl", 1e-15, lbl, []float64{1.2 * math.Cos(112.5*math.Pi/180), 1.2 * math.Sin(112.5*math.Pi/180)})
+
+	Reset()
+	AngleDim(1, 2, 2, 0, 90, `$\alpha$`, nil)
+	txt := defaultPlotter.bufferPy.String()
+	chk.Int(tst, "number of arrows", strings.Count(txt, "arrowstyle='-|>'"), 2)
+	for _, cmd := range []string{"theta1=0,theta2=90", `"$\\alpha$"`} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		Polyline([][]float64{{3, 2}, {1, 2}, {1, 4}}, &A{Closed: false, Fc: "none"})
+		AngleDim(1, 2, 1, 0, 90, `$\theta$`, &A{Ec: "r", Fsz: 12})
+		Equal()
+		AxisRange(0, 4, 1, 5)
+		err := SaveD("/tmp/gosl", "t_dims01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_dims02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("dims02. linear dimension")
+
+	// horizontal; dimension below
+	ext1, ext2, dim, mid, angle := linearDimGeometry(0, 0, 4, 0, -1)
+	chk.Matrix(tst, "ext1", 1e-15, ext1, [][]float64{{0, -0.1}, {0, -1.1}})
+	chk.Matrix(tst, "ext2", 1e-15, ext2, [][]float64{{4, -0.1}, {4, -1.1}})
+	chk.Matrix(tst, "dim", 1e-15, dim, [][]float64{{0, -1}, {4, -1}})
+	chk.Vector(tst, "mid", 1e-15, mid, []float64{2, -1})
+	chk.Scalar(tst, "angle", 1e-15, angle, 0)
+
+	// inclined from right to left; dimension on the left-hand side
+	s := math.Sqrt(2) / 2
+	_, _, dim, mid, angle = linearDimGeometry(1, 0, 0, 1, 1)
+	chk.Matrix(tst, "dim", 1e-15, dim, [][]float64{{1 - s, -s}, {-s, 1 - s}})
+	chk.Vector(tst, "mid", 1e-15, mid, []float64{0.5 - s, 0.5 - s})
+	chk.Scalar(tst, "angle", 1e-13, angle, -45)
+
+	// vertical
+	_, _, _, _, angle = linearDimGeometry(0, 1, 0, 0, 1)
+	chk.Scalar(tst, "angle", 1e-13, angle, 90)
+
+	// buffer
+	Reset()
+	LinearDim(0, 0, 3, 4, -1, "", &A{UnumFmt: "%.1f"})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"arrowprops=dict(arrowstyle='<->',color='k',lw=1,shrinkA=0,shrinkB=0)",
+		`"5.0",ha='center',va='center',rotation=53.13010235415598`,
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of extension lines", strings.Count(txt, "plt.plot("), 2)
+
+	if chk.Verbose {
+		P := [][]float64{{0, 0}, {4, 0}, {4, 2}, {1, 3}}
+		Polyline(P, &A{Fc: "#e0e0ff", Ec: "b", Closed: true})
+		LinearDim(0, 0, 4, 0, -0.6, "", nil)
+		LinearDim(4, 0, 4, 2, -0.6, "", nil)
+		LinearDim(4, 2, 1, 3, -0.6, "", &A{UnumFmt: "%.3f", Ec: "r"})
+		LinearDim(1, 3, 0, 0, -0.6, "L", &A{Fsz: 12})
+		Equal()
+		AxisRange(-1.5, 5.5, -1.5, 4.5)
+		err := SaveD("/tmp/gosl", "t_dims02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
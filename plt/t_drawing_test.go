@@ -5,6 +5,8 @@
 package plt
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -44,3 +46,246 @@ func Test_draw01(tst *testing.T) {
 		}
 	}
 }
+
+func Test_draw02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("draw02. ellipse")
+
+	Reset()
+	Circle(0, 0, 1, &A{Fc: "none", Ec: "k"})
+	Ellipse(1, 2, 3, 1.5, 30, &A{Fc: "#dedede", Ec: "r", Lw: 2, Alpha: 0.5, Z: 3})
+	Ellipse(0, 0, 1, 1, 0, nil)
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"= pat.Circle((0,0), 1, facecolor='none',edgecolor='k')",
+		"= pat.Ellipse((1,2), 6, 3, angle=30, lw=2,zorder=3,alpha=0.5,facecolor='#dedede',edgecolor='r')",
+		"= pat.Ellipse((0,0), 2, 2, angle=0)",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of patches", strings.Count(txt, "plt.gca().add_patch(pc"), 3)
+
+	if chk.Verbose {
+		AutoScale([][]float64{{-3, -2}, {5, 5}})
+		Equal()
+		err := SaveD("/tmp/gosl", "t_draw02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_draw03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("draw03. wedge")
+
+	Reset()
+	bbox := Wedge(1, 2, 2, 0, 90, &A{Fc: "#dedede", Ec: "k", Alpha: 0.5, Z: 2})
+	chk.Matrix(tst, "bbox: first quadrant", 1e-15, bbox, [][]float64{{1, 2}, {3, 4}})
+	bbox = Wedge(0, 0, 1, 350, 10, nil) // crosses 0°
+	chk.Matrix(tst, "bbox: across 0°", 1e-15, bbox, [][]float64{{0, -math.Sin(10 * math.Pi / 180)}, {1, math.Sin(10 * math.Pi / 180)}})
+	bbox = Wedge(0, 0, 2, -90, 90, &A{Rin: 1}) // annular
+	chk.Matrix(tst, "bbox: annular", 1e-15, bbox, [][]float64{{0, -2}, {2, 2}})
+	bbox = Wedge(0, 0, 1, 45, 45+720, nil) // full circle
+	chk.Matrix(tst, "bbox: full", 1e-15, bbox, [][]float64{{-1, -1}, {1, 1}})
+
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"= pat.Wedge((1,2), 2, 0, 90, zorder=2,alpha=0.5,facecolor='#dedede',edgecolor='k')",
+		"= pat.Wedge((0,0), 1, 350, 370)",
+		"= pat.Wedge((0,0), 2, 270, 450, width=1)",
+		"= pat.Wedge((0,0), 1, 45, 405)",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		AutoScale([][]float64{{-2, -2}, {3, 4}})
+		Equal()
+		err := SaveD("/tmp/gosl", "t_draw03.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_draw04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("draw04. Bezier curves")
+
+	Reset()
+	err := BezierCurve([][]float64{{0, 0}, {1, 2}, {2, 0}}, &A{Ec: "r", Lw: 2})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	err = BezierCurve([][]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {2, -1}, {2, 0}}, &A{Style: "-|>", Ec: "b"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	err = BezierCurve([][]float64{{0, 0}, {1, 1}, {2, 0}, {1, -1}, {0, 0}}, &A{Fc: "#dedede", Closed: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"= [[pth.Path.MOVETO, [0, 0]], [pth.Path.CURVE3, [1, 2]], [pth.Path.CURVE3, [2, 0]]]\n",
+		"= pat.PathPatch(ph", ", lw=2,facecolor='none',edgecolor='r')",
+		"= [[pth.Path.MOVETO, [0, 0]], [pth.Path.CURVE4, [0, 1]], [pth.Path.CURVE4, [1, 1]], [pth.Path.CURVE4, [1, 0]], [pth.Path.CURVE4, [1, -1]], [pth.Path.CURVE4, [2, -1]], [pth.Path.CURVE4, [2, 0]]]\n",
+		"= pat.FancyArrowPatch(path=ph", ",arrowstyle='-|>',mutation_scale=20, facecolor='none',edgecolor='b')",
+		"[pth.Path.CURVE3, [1, -1]], [pth.Path.CURVE3, [0, 0]], [pth.Path.CLOSEPOLY, [0, 0]]]\n",
+		", facecolor='#dedede')",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of patches", strings.Count(txt, "plt.gca().add_patch(pc"), 3)
+
+	// errors
+	for _, P := range [][][]float64{{{0, 0}, {1, 1}}, {{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}}} {
+		if BezierCurve(P, nil) == nil {
+			tst.Errorf("BezierCurve should have failed with %d control points\n", len(P))
+			return
+		}
+	}
+
+	if chk.Verbose {
+		AutoScale([][]float64{{-0.5, -1.5}, {2.5, 2.5}})
+		Equal()
+		err := SaveD("/tmp/gosl", "t_draw04.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_draw05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("draw05. rounded rectangles")
+
+	Reset()
+	RoundedRect(0, 0, 2, 1, 0.1, &A{Fc: "#dedede", Ec: "k", Lw: 1.5, Alpha: 0.8, Z: 1})
+	Text(1, 0.5, "input", &A{Ha: "center", Va: "center", Z: 2})
+	RoundedRect(4, 0, 2, 1, 0.1, nil)
+	Text(5, 0.5, "output", &A{Ha: "center", Va: "center"})
+	Arrow(2.1, 0.5, 3.9, 0.5, &A{Style: "->", Ec: "k"})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"= pat.FancyBboxPatch((0,0), 2, 1, boxstyle='round,pad=0.1', lw=1.5,zorder=1,alpha=0.8,facecolor='#dedede',edgecolor='k')",
+		"= pat.FancyBboxPatch((4,0), 2, 1, boxstyle='round,pad=0.1')",
+		`plt.text(1,0.5,"input", zorder=2,ha='center',va='center')`,
+		"arrowstyle='->'",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of patches", strings.Count(txt, "plt.gca().add_patch(pc"), 3)
+
+	if chk.Verbose {
+		AutoScale([][]float64{{-0.5, -0.5}, {6.5, 1.5}})
+		Equal()
+		err := SaveD("/tmp/gosl", "t_draw05.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_draw06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("draw06. legend with line and patch entries")
+
+	// lines and patches
+	Reset()
+	LegendX([]*A{
+		{C: "r", Ls: "-", L: "curve"},
+		{Fc: "#dedede", Ec: "k", L: "band"},
+		{Fc: "b", Alpha: 0.5, Lw: 2, Ls: "--", L: "bars"},
+	}, nil)
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"lns.Line2D([], [], color='r',ls='-',label='curve'),\n",
+		"pat.Patch(facecolor='#dedede',edgecolor='k',label='band'),\n",
+		"pat.Patch(facecolor='b',lw=2,ls='--',alpha=0.5,label='bars')]\n",
+		"fontsize=9, loc='best')\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// contour levels
+	Reset()
+	x := [][]float64{{0, 1}, {0, 1}}
+	y := [][]float64{{0, 0}, {1, 1}}
+	z := [][]float64{{0, 1}, {1, 2}}
+	ContourF(x, y, z, &A{UnoCbar: true, UnoLines: true})
+	cname := defaultPlotter.lastContour
+	LegendX([]*A{{C: "k", L: "boundary"}}, &A{LegLevels: []float64{0.5, 1.5}, UnumFmt: "%.1f", LegLoc: "upper left"})
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"lns.Line2D([], [], color='k',label='boundary'),\n",
+		"pat.Patch(facecolor=" + cname + ".to_rgba(0.5),label='0.5'),\n",
+		"pat.Patch(facecolor=" + cname + ".to_rgba(1.5),label='1.5')]\n",
+		"loc='upper left'",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	if cname == "" || !strings.Contains(txt, cname+" = plt.contourf(") {
+		tst.Errorf("last contour should be %q:\n%v\n", cname, txt)
+		return
+	}
+
+	// only levels
+	Reset()
+	ContourL(x, y, z, &A{UnoLabels: true})
+	LegendX(nil, &A{LegLevels: []float64{1}})
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "[pat.Patch(facecolor="+defaultPlotter.lastContour+".to_rgba(1),label='1')]\n") {
+		tst.Errorf("legend should have one patch:\n%v\n", txt)
+		return
+	}
+
+	// levels without contour
+	Reset()
+	func() {
+		defer func() {
+			if recover() == nil {
+				tst.Errorf("LegendX should have panicked without contour\n")
+			}
+		}()
+		LegendX(nil, &A{LegLevels: []float64{1}})
+	}()
+
+	if chk.Verbose {
+		Reset()
+		ContourF(x, y, z, &A{UnoCbar: true, UnoLines: true})
+		Plot([]float64{0, 1}, []float64{1, 0}, &A{C: "k"})
+		LegendX([]*A{{C: "k", L: "line"}, {Fc: "none", Ec: "r", L: "patch"}}, &A{LegLevels: []float64{0.5, 1, 1.5}})
+		err := SaveD("/tmp/gosl", "t_draw06.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
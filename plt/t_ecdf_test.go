@@ -0,0 +1,105 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_ecdf01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ecdf01. empirical CDF")
+
+	// steps
+	x, F := ecdfSteps([]float64{3, 1, 2, 2, 5})
+	chk.Vector(tst, "x", 1e-15, x, []float64{1, 2, 3, 5})
+	chk.Vector(tst, "F", 1e-15, F, []float64{0.2, 0.6, 0.8, 1.0})
+	x, F = ecdfSteps(nil)
+	if len(x) != 0 || len(F) != 0 {
+		tst.Errorf("steps of empty data should be empty\n")
+		return
+	}
+
+	// uniform data
+	data := make([]float64, 100)
+	for i := 0; i < len(data); i++ {
+		data[i] = float64(len(data)-1-i) / 100.0
+	}
+	Reset()
+	x, F = EcdfRef(data, func(x float64) float64 { return math.Min(math.Max(x, 0), 1) }, 11, &A{C: "r", L: "data"}, nil)
+	chk.Int(tst, "len(x)", len(x), 100)
+	chk.Scalar(tst, "x[0]", 1e-15, x[0], 0)
+	chk.Scalar(tst, "F[0]", 1e-15, F[0], 0.01)
+	chk.Scalar(tst, "x[49]", 1e-15, x[49], 0.49)
+	chk.Scalar(tst, "F[49]", 1e-15, F[49], 0.5)
+	chk.Scalar(tst, "F[99]", 1e-15, F[99], 1)
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"=np.array([0,0,0.01,0.02,", "=np.array([0,0.01,0.02,", ",drawstyle='steps-post', color='r',label='data')", ", color='k',ls='--')"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		Gll("$x$", "$F(x)$", nil)
+		err := SaveD("/tmp/gosl", "t_ecdf01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_qqplot01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("qqplot01. QQ-plot")
+
+	chk.Vector(tst, "positions", 1e-15, qqPositions(4), []float64{0.125, 0.375, 0.625, 0.875})
+
+	// uniform
+	Reset()
+	xt, xs := QQplot([]float64{0.9, 0.1, 0.5, 0.3, 0.7}, func(p float64) float64 { return p }, nil)
+	chk.Vector(tst, "xt", 1e-15, xt, []float64{0.1, 0.3, 0.5, 0.7, 0.9})
+	chk.Vector(tst, "xs", 1e-15, xs, []float64{0.1, 0.3, 0.5, 0.7, 0.9})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"plt.plot(x0,y0, color='b',marker='o',ls='none')\n",
+		"plt.plot([0.1,0.9],[0.1,0.9], color='black', linestyle='dashed', linewidth=1.2, zorder=0)\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// points are drawn with markers when only the color is given
+	Reset()
+	QQplot([]float64{0.9, 0.1, 0.5}, func(p float64) float64 { return p }, &A{C: "r"})
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "plt.plot(x0,y0, color='r',marker='o',ls='none')\n") {
+		tst.Errorf("points should be drawn with markers:\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		sample := make([]float64, 50)
+		for i := 0; i < len(sample); i++ {
+			sample[i] = -2 * math.Log(1-(float64(i)+0.3)/50) // exponential with mean 2
+		}
+		QQplot(sample, func(p float64) float64 { return -2 * math.Log(1-p) }, &A{C: "r", M: "."})
+		Gll("theoretical quantiles", "sample quantiles", nil)
+		err := SaveD("/tmp/gosl", "t_qqplot01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
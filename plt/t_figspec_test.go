@@ -0,0 +1,113 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_figspec01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("figspec01")
+
+	// table file
+	io.WriteFileSD("/tmp/gosl", "t_figspec01.dat", "time  disp\n0 0\n1 0.5\n2 0.8\n3 0.9\n")
+
+	// specifications
+	specs := []FigSpec{
+		{
+			Series: []*SeriesSpec{
+				{X: []float64{0, 1, 2}, Y: []float64{0, 1, 4}, Args: &A{C: "r", L: "inline"}},
+			},
+			Xlabel: "x",
+			Ylabel: "y",
+			Lims:   []float64{0, 2, 0, 4},
+			Fname:  "t_figspec01a.png",
+		},
+		{
+			Series: []*SeriesSpec{
+				{File: "/tmp/gosl/t_figspec01.dat", Xkey: "time", Ykey: "disp", Args: &A{M: "o"}},
+			},
+			Xlabel: "time",
+			Ylabel: "displacement",
+			Title:  "from table",
+			Fname:  "t_figspec01b.png",
+		},
+	}
+
+	// json round-trip
+	b, err := json.Marshal(specs)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	io.WriteBytesToFileD("/tmp/gosl", "t_figspec01.json", b)
+	specs, err = ReadFigSpecs("/tmp/gosl/t_figspec01.json")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Int(tst, "len(specs)", len(specs), 2)
+	chk.String(tst, specs[0].Series[0].Args.C, "r")
+	chk.String(tst, specs[1].Series[0].Ykey, "disp")
+
+	// generate commands
+	fnames, err := defaultPlotter.genSpecs(specs, &SaveOpts{Dirout: "/tmp/gosl"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Strings(tst, "fnames", fnames, []string{"/tmp/gosl/t_figspec01a.png", "/tmp/gosl/t_figspec01b.png"})
+	chk.Vector(tst, "disp", 1e-15, specs[1].Series[0].Y, []float64{0, 0.5, 0.8, 0.9})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"plt.figure(1)", "plt.figure(2)", "plt.savefig(r'/tmp/gosl/t_figspec01a.png'", "plt.savefig(r'/tmp/gosl/t_figspec01b.png'"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q\n", cmd)
+			return
+		}
+	}
+
+	// render
+	if chk.Verbose {
+		err = RenderSpecs(specs, &SaveOpts{Dirout: "/tmp/gosl"})
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		for _, fn := range fnames {
+			if _, err = os.Stat(fn); err != nil {
+				tst.Errorf("figure file was not created:\n%v", err)
+				return
+			}
+		}
+	}
+}
+
+func Test_figspec02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("figspec02. validation")
+
+	specs := []FigSpec{
+		{Series: []*SeriesSpec{{X: []float64{0, 1}, Y: []float64{0, 1}}}, Fname: "ok.png"},
+		{Series: []*SeriesSpec{{X: []float64{0, 1, 2}, Y: []float64{0, 1}}}, Fname: "wrong.png"},
+	}
+	err := RenderSpecs(specs, nil)
+	if err == nil {
+		tst.Errorf("RenderSpecs should have failed due to mismatched lengths\n")
+		return
+	}
+	io.Pforan("err = %v\n", err)
+	if !strings.Contains(err.Error(), "figure spec 1") || !strings.Contains(err.Error(), "3 != 2") {
+		tst.Errorf("error message is incorrect: %v\n", err)
+	}
+}
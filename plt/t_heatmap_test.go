@@ -0,0 +1,269 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_heatmap01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("heatmap01. annotated heatmap")
+
+	// correlation matrix
+	z := [][]float64{
+		{1.0, 0.3, -0.8},
+		{0.3, 1.0, 0.1},
+		{-0.8, 0.1, 1.0},
+	}
+	lbls := []string{"a", "b", "c"}
+
+	Reset()
+	err := HeatmapAnnotated(z, lbls, lbls, "%.1f", &A{UcmapIdx: 1, VminVmax: []float64{-1, 1}, UcbarLbl: "ρ"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"plt.imshow(z", ",cmap=getCmap(1),vmin=-1,vmax=1,", "plt.colorbar(p", ".set_ylabel('ρ')",
+		`=["a","b","c",]`, "plt.xticks(range(3),xl", "plt.yticks(range(3),yl",
+		`plt.text(0,0,"1.0", color='white',ha='center',va='center')`,
+		`plt.text(2,0,"-0.8", color='black',ha='center',va='center')`,
+		`plt.text(1,2,"0.1", color='white',ha='center',va='center')`,
+		`plt.text(2,1,"0.1", color='white',ha='center',va='center')`,
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of texts", strings.Count(txt, "plt.text("), 9)
+
+	// without labels; midpoint computed from data
+	Reset()
+	err = HeatmapAnnotated([][]float64{{0, 1}, {2, 3}}, nil, nil, "", &A{UnoCbar: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Contains(txt, "ticks(") || strings.Contains(txt, "colorbar") ||
+		!strings.Contains(txt, `plt.text(1,0,"1", color='black'`) || !strings.Contains(txt, `plt.text(0,1,"2", color='white'`) {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// errors
+	if HeatmapAnnotated([][]float64{{0, 1}, {2}}, nil, nil, "", nil) == nil {
+		tst.Errorf("HeatmapAnnotated should have failed with ragged matrix\n")
+		return
+	}
+	if HeatmapAnnotated(z, lbls[:2], nil, "", nil) == nil {
+		tst.Errorf("HeatmapAnnotated should have failed with wrong number of labels\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		HeatmapAnnotated(z, lbls, lbls, "%.1f", &A{UcmapIdx: 1, VminVmax: []float64{-1, 1}})
+		err := SaveD("/tmp/gosl", "t_heatmap01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_image01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("image01. image file")
+
+	// image with spaces in the path
+	dir := "/tmp/gosl/dir with spaces"
+	os.MkdirAll(dir, 0777)
+	fn := dir + "/it's.png"
+	f, err := os.Create(fn)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	png.Encode(f, img)
+	f.Close()
+
+	Reset()
+	err = ImageFile(fn, []float64{0, 4, 0, 3}, &A{Z: 1, Alpha: 0.5})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, `= plt.imread("/tmp/gosl/dir with spaces/it's.png")`) ||
+		!strings.Contains(txt, ",extent=[0,4,0,3],zorder=1,alpha=0.5)") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// pixel coordinates
+	Reset()
+	ImageFile(fn, nil, nil)
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Contains(txt, "extent") || !strings.Contains(txt, "plt.imshow(img") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// errors
+	if ImageFile("/tmp/gosl/nonexistent.png", nil, nil) == nil {
+		tst.Errorf("ImageFile should have failed for missing file\n")
+		return
+	}
+	if ImageFile(fn, []float64{0, 1}, nil) == nil {
+		tst.Errorf("ImageFile should have failed for wrong extent\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		ImageFile(fn, []float64{0, 4, 0, 3}, nil)
+		Plot([]float64{0, 4}, []float64{0, 3}, &A{C: "r", Z: 2})
+		err := SaveD("/tmp/gosl", "t_image01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_cbar01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cbar01. standalone colorbar")
+
+	Reset()
+	name := ColorbarOnly(3, -1, 2.5, "stress", &A{UcbarOrient: "horizontal", UnumFmt: "%.1f"})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"= plt.cm.ScalarMappable(cmap=getCmap(3),norm=plt.Normalize(vmin=-1,vmax=2.5))",
+		".set_array([])",
+		name + " = plt.colorbar(sm",
+		",ax=plt.gca(), orientation='horizontal', format='%.1f')",
+		name + ".set_label('stress')",
+		"addToEA(" + name + ".ax)",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	Reset()
+	name = ColorbarOnly(0, 0, 1, "", nil)
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, ",ax=plt.gca())\n") || strings.Contains(txt, "set_label") || !strings.Contains(txt, "addToEA("+name+".ax)") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Polyline([][]float64{{0, 0}, {1, 0}, {1, 1}}, &A{Fc: "none", Ec: "r", Closed: false})
+		ColorbarOnly(3, -1, 2.5, "stress", nil)
+		err := SaveD("/tmp/gosl", "t_cbar01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_cbar02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("cbar02. colorbar options")
+
+	// all options
+	args := &A{UcbarOrient: "horizontal", UcbarShrink: 0.8, UcbarAspect: 30, UcbarPad: 0.15, UcbarTicks: []float64{0, 0.5, 1}, UcbarExtend: "both"}
+	chk.String(tst, argsCbar(args), ", orientation='horizontal', shrink=0.8, aspect=30, pad=0.15, ticks=[0,0.5,1], extend='both'")
+
+	// no options
+	chk.String(tst, argsCbar(nil), "")
+	chk.String(tst, argsCbar(&A{}), "")
+	chk.String(tst, argsCbar(&A{UcbarExtend: "max"}), ", extend='max'")
+
+	// explicit ticks replace the boundaries of classes
+	chk.String(tst, argsCbar(&A{Ubounds: []float64{0, 1, 2}}), ", ticks=[0,1,2]")
+	chk.String(tst, argsCbar(&A{Ubounds: []float64{0, 1, 2}, UcbarTicks: []float64{1}}), ", ticks=[1]")
+
+	// all colorbars
+	x := [][]float64{{0, 1}, {0, 1}}
+	y := [][]float64{{0, 0}, {1, 1}}
+	z := [][]float64{{0, 1}, {1, 2}}
+	a := *args
+	a.UcbarLbl, a.UnoLines = "z", true
+	Reset()
+	ContourF(x, y, z, &a)
+	TricontourF([]float64{0, 1, 0}, []float64{0, 0, 1}, []float64{0, 1, 2}, nil, &a)
+	HeatmapAnnotated(z, nil, nil, "", &a)
+	a.QbyMag = true
+	Quiver(x, y, z, z, &a)
+	Plot3dPointsC([]float64{0, 1}, []float64{0, 1}, []float64{0, 1}, []float64{0, 1}, true, &a)
+	ColorbarOnly(0, 0, 1, "", &a)
+	txt := defaultPlotter.bufferPy.String()
+	chk.Int(tst, "number of colorbars", strings.Count(txt, "plt.colorbar("), 6)
+	chk.Int(tst, "number of colorbars with options", strings.Count(txt, argsCbar(args)), 6)
+	chk.Int(tst, "number of horizontal labels", strings.Count(txt, ".ax.set_xlabel('z')\n"), 5)
+	if strings.Contains(txt, ".ax.set_ylabel") {
+		tst.Errorf("labels of horizontal colorbars should be along x:\n%v\n", txt)
+		return
+	}
+
+	// default colorbars
+	Reset()
+	ContourF(x, y, z, &A{UnoLines: true, UcbarLbl: "z"})
+	HeatmapAnnotated(z, nil, nil, "", nil)
+	Surface(x, y, z, true, &A{UcmapIdx: 1})
+	txt = defaultPlotter.bufferPy.String()
+	for _, kw := range []string{"orientation=", "pad=", "ticks=", "extend=", ".ax.set_xlabel"} {
+		if strings.Contains(txt, kw) {
+			tst.Errorf("buffer should not contain %q:\n%v\n", kw, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of shrink", strings.Count(txt, "shrink="), 1)
+	if !strings.Contains(txt, ", shrink=0.5, aspect=10)\n") || !strings.Contains(txt, ".ax.set_ylabel('z')\n") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// surface colorbar with options
+	Reset()
+	Surface(x, y, z, true, &A{UcmapIdx: 1, UcbarShrink: 0.9, UcbarPad: 0.1})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ", shrink=0.9, aspect=10, pad=0.1)\n") {
+		tst.Errorf("buffer is incorrect:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		SetForPng(0.4, 500, 150, nil)
+		X, Y, Z := utl.MeshGrid2dF(0, 4, 0, 1, 81, 21, func(x, y float64) float64 { return math.Sin(3*x) * y })
+		ContourF(X, Y, Z, &A{UnoLines: true, UcbarOrient: "horizontal", UcbarShrink: 0.8, UcbarAspect: 40, UcbarPad: 0.2,
+			UcbarTicks: []float64{-1, 0, 1}, UcbarExtend: "both", UcbarLbl: "wide map", Ulevels: []float64{-0.8, -0.4, 0, 0.4, 0.8}})
+		Equal()
+		err := SaveD("/tmp/gosl", "t_cbar02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_inset01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("inset01. zoom inset")
+
+	Reset()
+	activate, deactivate := ZoomInset(0.55, 0.55, 0.3, 0.25, 1, 2, -0.5, 0.5, &A{C: "r"})
+	activate()
+	deactivate()
+	chk.String(tst, defaultPlotter.bufferPy.String(), "axp0 = plt.gca()\n"+
+		"axi0 = plt.gcf().add_axes([0.55,0.55,0.3,0.25])\n"+
+		"axi0.set_xlim(1,2)\n"+
+		"axi0.set_ylim(-0.5,0.5)\n"+
+		"from mpl_toolkits.axes_grid1.inset_locator import mark_inset\n"+
+		"mark_inset(axp0,axi0,loc1=2,loc2=4,fc='none',ec='r',lw=1)\n"+
+		"plt.sca(axp0)\n"+
+		"plt.sca(axi0)\n"+
+		"axi0.set_autoscale_on(False)\n"+
+		"plt.sca(axp0)\n")
+
+	// errors
+	defer func() {
+		if err := recover(); err == nil {
+			tst.Errorf("ZoomInset should have panicked with invalid zoom window\n")
+		}
+	}()
+	ZoomInset(0.5, 0.5, 0.3, 0.3, 2, 1, 0, 1, nil)
+}
+
+func Test_inset02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("inset02. zoomed figure")
+
+	if chk.Verbose {
+		x := utl.LinSpace(0, 10, 501)
+		y := make([]float64, len(x))
+		for i := 0; i < len(x); i++ {
+			y[i] = math.Sin(x[i]) + 0.05*math.Sin(20*x[i])
+		}
+		Reset()
+		Plot(x, y, &A{C: "b"})
+		Gll("x", "y", nil)
+		activate, deactivate := ZoomInset(0.55, 0.6, 0.3, 0.25, 1, 2, 0.7, 1.1, nil)
+		activate()
+		Plot(x, y, &A{C: "b"})
+		deactivate()
+		err := SaveD("/tmp/gosl", "t_inset02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
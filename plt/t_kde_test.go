@@ -0,0 +1,73 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_kde01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("kde01. kernel density estimate")
+
+	// one point => standard normal density
+	c := 1.0 / math.Sqrt(2.0*math.Pi)
+	f := kdeGauss([]float64{0}, []float64{-1, 0, 1, 2}, 1)
+	chk.Vector(tst, "f(one point)", 1e-15, f, []float64{c * math.Exp(-0.5), c, c * math.Exp(-0.5), c * math.Exp(-2)})
+
+	// two points and bandwidth 0.5
+	f = kdeGauss([]float64{-1, 1}, []float64{0, 1}, 0.5)
+	chk.Vector(tst, "f(two points)", 1e-15, f, []float64{2 * c * math.Exp(-2), c * (1 + math.Exp(-8))})
+
+	// Silverman's rule: σ = √2.5, IQR = 2
+	chk.Scalar(tst, "h", 1e-15, kdeSilverman([]float64{3, 1, 5, 2, 4}), 0.9*(2/1.34)*math.Pow(5, -0.2))
+	chk.Scalar(tst, "h(equal values)", 1e-15, kdeSilverman([]float64{2, 2, 2}), 1)
+
+	// plot
+	Reset()
+	x, f := Kde([]float64{1, 2, 3}, 11, 0.5, &A{C: "r"})
+	chk.Scalar(tst, "xmin", 1e-15, x[0], -0.5)
+	chk.Scalar(tst, "xmax", 1e-15, x[10], 4.5)
+	area := 0.0
+	for i := 1; i < len(x); i++ {
+		area += (f[i] + f[i-1]) * (x[i] - x[i-1]) / 2.0
+	}
+	chk.Scalar(tst, "area", 1e-2, area, 1)
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "plt.plot(x0,y0, color='r')") {
+		tst.Errorf("Kde should have called Plot:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// overlay
+	Reset()
+	KdeHist([]float64{1, 2, 3}, 11, 0, &A{Hnbins: 5}, nil)
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "bins=5,normed=1)") {
+		tst.Errorf("KdeHist should have drawn a normed histogram:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		rand.Seed(1234)
+		data := make([]float64, 500)
+		for i := 0; i < len(data); i++ {
+			data[i] = rand.NormFloat64()
+			if i%3 == 0 {
+				data[i] += 4
+			}
+		}
+		KdeHist(data, 201, 0, &A{Hnbins: 30, C: "#d8d8d8"}, &A{C: "r", Lw: 2})
+		err := SaveD("/tmp/gosl", "t_kde01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -5,10 +5,17 @@
 package plt
 
 import (
+	"bytes"
+	"image/png"
 	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/utl"
 )
 
@@ -32,18 +39,46 @@ func Test_args01(tst *testing.T) {
 	a.Mew = 0.3
 	a.Void = true
 	a.NoClip = true
+	a.Alpha = 0.5
+	a.Dashes = []float64{5, 2} // ignored because of Ls
+	a.Rasterized = true
 
 	// shapes
 	a.Ha = "center"
 	a.Va = "center"
 	a.Fc = "magenta"
 	a.Ec = "yellow"
+	a.Hatch = "///"
 
 	// text and extra arguments
 	a.Fsz = 7
+	a.Rot = 30
+	a.TextBbox = true
+	a.TextBboxEc = "none"
+	a.TextBboxPad = 2
 
 	l := a.String(false)
-	chk.String(tst, l, "color='red',marker='o',ls='--',lw=1.2,label='gosl',markevery=2,zorder=123,markeredgecolor='blue',mew=0.3,markerfacecolor='none',clip_on=0,facecolor='magenta',edgecolor='yellow',ha='center',va='center',fontsize=7")
+	chk.String(tst, l, "color='red',marker='o',ls='--',lw=1.2,label='gosl',markevery=2,zorder=123,markeredgecolor='blue',mew=0.3,markerfacecolor='none',clip_on=0,alpha=0.5,rasterized=True,facecolor='magenta',edgecolor='yellow',hatch='///',ha='center',va='center',fontsize=7,rotation=30,bbox=dict(facecolor='white',edgecolor='none',pad=2)")
+
+	// text with default bounding box
+	l = A{Rot: -45, TextBbox: true}.String(false)
+	chk.String(tst, l, "rotation=-45,bbox=dict(facecolor='white',edgecolor='black',pad=4)")
+
+	// transparency: 0 => not set (instead of fully transparent)
+	l = A{C: "k", Alpha: 1}.String(false)
+	chk.String(tst, l, "color='k',alpha=1")
+	l = A{C: "k", Alpha: 0}.String(false)
+	chk.String(tst, l, "color='k'")
+	l = A{C: "k", Alpha: 1.5}.String(false)
+	chk.String(tst, l, "color='k'")
+
+	// custom dashes
+	l = A{Dashes: []float64{5, 2, 1, 2}}.String(false)
+	chk.String(tst, l, "dashes=[5,2,1,2]")
+	l = A{Ls: "-", Dashes: []float64{5, 2}}.String(false)
+	chk.String(tst, l, "ls='-',dashes=[5,2]")
+	l = A{Ls: ":", Dashes: []float64{5, 2}}.String(false)
+	chk.String(tst, l, "ls=':'")
 }
 
 func Test_args02(tst *testing.T) {
@@ -58,12 +93,55 @@ func Test_args02(tst *testing.T) {
 		Hvoid:    true,
 		Hnbins:   10,
 		Hnormed:  true,
+		Alpha:    0.7,
+		Hatch:    "//",
 	}
 
 	l := a.String(true)
+	chk.String(tst, l, "alpha=0.7,hatch='//',color=['red','tan','lime'],histtype='bar',stacked=1,fill=0,bins=10,normed=1")
+
+	// transparency and hatch not set
+	a.Alpha, a.Hatch = 0, ""
+	l = a.String(true)
 	chk.String(tst, l, "color=['red','tan','lime'],histtype='bar',stacked=1,fill=0,bins=10,normed=1")
 }
 
+func Test_args03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("args03. frame borders")
+
+	// hide list
+	chk.String(tst, getHideList(nil), "")
+	chk.String(tst, getHideList(&A{}), "")
+	chk.String(tst, getHideList(&A{HideR: true, HideT: true}), "['right','top']")
+	chk.String(tst, getHideList(&A{HideAll: true}), "['left','right','bottom','top']")
+	chk.String(tst, getHideList(&A{HideAll: true, ShowL: true, ShowB: true}), "['right','top']")
+	chk.String(tst, getHideList(&A{HideAll: true, ShowL: true, ShowR: true, ShowB: true, ShowT: true}), "")
+	chk.String(tst, getHideList(&A{HideAll: true, HideL: true, ShowL: true}), "['left','right','bottom','top']")
+	chk.String(tst, getHideList(&A{HideT: true, ShowT: true}), "['top']")
+	chk.String(tst, getHideList(&A{ShowL: true}), "")
+
+	// styles
+	chk.String(tst, getBorderStyles(nil), "")
+	chk.String(tst, getBorderStyles(&A{}), "")
+	chk.String(tst, getBorderStyles(&A{BorderCL: "r", BorderCB: "b", BorderLwB: 2}),
+		"plt.gca().spines['left'].set_color('r')\n"+
+			"plt.gca().spines['bottom'].set_color('b')\n"+
+			"plt.gca().spines['bottom'].set_linewidth(2)\n")
+
+	// keep only left and bottom borders in a custom color
+	Reset()
+	Gll("x", "y", &A{HideAll: true, ShowL: true, ShowB: true, BorderCL: "#555555", BorderCB: "#555555", NoGrid: true})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.HasPrefix(txt, "for spine in ['right','top']: plt.gca().spines[spine].set_visible(False)\n"+
+		"plt.gca().spines['left'].set_color('#555555')\n"+
+		"plt.gca().spines['bottom'].set_color('#555555')\n"+
+		"plt.xlabel(r'x')\n") {
+		tst.Errorf("Gll should hide and style the frame borders:\n%v\n", txt)
+	}
+}
+
 func Test_plot01(tst *testing.T) {
 
 	//verbose()
@@ -294,3 +372,1427 @@ func Test_plot06(tst *testing.T) {
 		}
 	}
 }
+
+func Test_gen01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("gen01")
+
+	var buf bytes.Buffer
+	genIntMat(&buf, "tri", [][]int{{0, 1, 2}, {2, 3, 0}})
+	chk.String(tst, buf.String(), "tri=np.array([[0,1,2,],[2,3,0,],],dtype=int)\n")
+
+	buf.Reset()
+	genIntMat(&buf, "empty", nil)
+	chk.String(tst, buf.String(), "empty=np.array([],dtype=int)\n")
+
+	buf.Reset()
+	faces := [][][]float64{{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}}, {{0, 0, 1}, {1, 0, 1.5}, {1, 1, 2}, {0, -1, 1}}}
+	genPoints3(&buf, "faces", faces)
+	chk.String(tst, buf.String(), "faces=[[[0,0,0,],[1,0,0,],[0,1,0,],],[[0,0,1,],[1,0,1.5,],[1,1,2,],[0,-1,1,],],]\n")
+	lims, ok := points3Limits(faces)
+	if !ok {
+		tst.Errorf("limits should have been computed\n")
+		return
+	}
+	chk.Vector(tst, "lims", 1e-15, lims, []float64{0, 1, -1, 1, 0, 2})
+
+	buf.Reset()
+	genPoints3(&buf, "empty", nil)
+	chk.String(tst, buf.String(), "empty=[]\n")
+	if _, ok = points3Limits(nil); ok {
+		tst.Errorf("limits of empty list should not have been computed\n")
+	}
+}
+
+func Test_plot07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot07")
+
+	x := []float64{0, 1, 1, 0, 0.5}
+	y := []float64{0, 0, 1, 1, 0.5}
+
+	// explicit connectivity
+	Reset()
+	Triplot(x, y, [][]int{{0, 1, 4}, {1, 2, 4}, {2, 3, 4}, {3, 0, 4}}, &A{C: "b", Lw: 2, L: "mesh"})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "plt.triplot(x") || !strings.Contains(txt, ",tri") || !strings.Contains(txt, ", color='b',lw=2,label='mesh')") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// Delaunay
+	Reset()
+	Triplot(x, y, nil, nil)
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Contains(txt, ",tri") || !strings.Contains(txt, "plt.triplot(") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+	Equal()
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot07.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot08. query limits")
+
+	x := utl.LinSpace(0, 2, 11)
+	y := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		y[i] = x[i] * x[i]
+	}
+	Reset()
+	Plot(x, y, nil)
+	before := defaultPlotter.bufferPy.String()
+
+	xmin, xmax, ymin, ymax, err := QueryLimits()
+	if defaultPlotter.bufferPy.String() != before {
+		tst.Errorf("buffer should have been restored\n")
+		return
+	}
+
+	// the following requires matplotlib
+	if chk.Verbose {
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		io.Pforan("limits = %v, %v, %v, %v\n", xmin, xmax, ymin, ymax)
+		chk.Scalar(tst, "xmin", 1e-12, xmin, -0.1) // default margins are 5% of the data range
+		chk.Scalar(tst, "xmax", 1e-12, xmax, 2.1)
+		chk.Scalar(tst, "ymin", 1e-12, ymin, -0.2)
+		chk.Scalar(tst, "ymax", 1e-12, ymax, 4.2)
+		err = SaveD("/tmp/gosl", "t_plot08.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_levels01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("levels01. levels from percentiles")
+
+	// field with one huge spike and one NaN
+	x, y := utl.MeshGrid2d(0, 1, 0, 1, 11, 11)
+	z := make([][]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		z[i] = make([]float64, len(x[i]))
+		for j := 0; j < len(x[i]); j++ {
+			z[i][j] = x[i][j] + y[i][j]
+		}
+	}
+	z[5][5] = 1e6
+	z[0][0] = math.NaN()
+
+	levels := LevelsFromPercentiles(z, []float64{0, 50, 90, 100})
+	io.Pforan("levels = %v\n", levels)
+	chk.Scalar(tst, "min", 1e-15, levels[0], 0.1)
+	chk.Scalar(tst, "median", 1e-15, levels[1], 1.0)
+	if levels[2] > 2 {
+		tst.Errorf("90th percentile should be dominated by the bulk distribution. %g > 2 is incorrect\n", levels[2])
+		return
+	}
+	chk.Scalar(tst, "max", 1e-15, levels[3], 1e6)
+
+	// emitted levels
+	Reset()
+	ContourF(x, y, z, &A{UlevelsPercentile: true, Unlevels: 5})
+	txt := defaultPlotter.bufferPy.String()
+	correct := ",levels=" + floats2list(LevelsFromPercentiles(z, []float64{0, 25, 50, 75, 100}))
+	if strings.Count(txt, correct) != 2 { // contourf and contour
+		tst.Errorf("buffer does not contain %q twice:\n%v\n", correct, txt)
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_levels01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_extra01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("extra01. extra artists")
+
+	Reset()
+	EaCmds("SETUP = 1\n")
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	PyCmds("txt = plt.text(1.2, 0.5, 'outside', transform=plt.gca().transAxes)\n")
+	RegisterExtraArtist("txt")
+
+	script := defaultPlotter.bufferEa.String() + defaultPlotter.bufferPy.String()
+	idx := []int{
+		strings.Index(script, "def addToEA"),
+		strings.Index(script, "SETUP = 1"),
+		strings.Index(script, "plt.plot("),
+		strings.Index(script, "txt = plt.text("),
+		strings.Index(script, "addToEA(txt)"),
+	}
+	for i := 0; i < len(idx); i++ {
+		if idx[i] < 0 || (i > 0 && idx[i] < idx[i-1]) {
+			tst.Errorf("commands are in the wrong order: %v\n%v\n", idx, script)
+			return
+		}
+	}
+
+	// the registered text widens the saved figure
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_extra01a.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		Reset()
+		Plot([]float64{0, 1}, []float64{0, 1}, nil)
+		PyCmds("txt = plt.text(1.2, 0.5, 'outside', transform=plt.gca().transAxes)\n")
+		err = SaveD("/tmp/gosl", "t_extra01b.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		wa, wb := pngWidth(tst, "/tmp/gosl/t_extra01a.png"), pngWidth(tst, "/tmp/gosl/t_extra01b.png")
+		if wa <= wb {
+			tst.Errorf("figure with registered artist should be wider: %d <= %d\n", wa, wb)
+		}
+	}
+}
+
+func pngWidth(tst *testing.T, fname string) int {
+	f, err := os.Open(fname)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return 0
+	}
+	defer f.Close()
+	cfg, err := png.DecodeConfig(f)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return 0
+	}
+	return cfg.Width
+}
+
+func Test_plot09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot09. curve with band")
+
+	x := []float64{0, 1, 2}
+	y := []float64{1, 2, 3}
+	std := []float64{0.1, 0.2, 0.3}
+
+	Reset()
+	err := PlotWithStd(x, y, std, 2, &A{C: "r", L: "mean"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"ylo0=np.array([0.8,1.6,2.4,],dtype=float)",
+		"yhi0=np.array([1.2,2.4,3.6,],dtype=float)",
+		"l0, = plt.plot(x0,y0, color='r',label='mean')",
+		"plt.fill_between(x0,ylo0,yhi0,color=l0.get_color(),alpha=0.3,linewidth=0)",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	if strings.Count(txt, "label=") != 1 {
+		tst.Errorf("there should be only one legend entry:\n%v\n", txt)
+		return
+	}
+
+	// transparency of the band only
+	Reset()
+	PlotWithBand(x, y, y, y, &A{C: "b", Alpha: 0.5})
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "l0, = plt.plot(x0,y0, color='b')\n") || strings.Count(txt, "alpha=0.5") != 1 {
+		tst.Errorf("only the band should be transparent:\n%v\n", txt)
+		return
+	}
+
+	// errors
+	if PlotWithBand(x, y, y[:2], y, nil) == nil {
+		tst.Errorf("PlotWithBand should have failed with unequal lengths\n")
+		return
+	}
+	if PlotWithStd(x, y, std[:1], 1, nil) == nil {
+		tst.Errorf("PlotWithStd should have failed with unequal lengths\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		xx := utl.LinSpace(0, 6, 61)
+		yy := make([]float64, len(xx))
+		ss := make([]float64, len(xx))
+		for i, t := range xx {
+			yy[i], ss[i] = math.Sin(t), 0.1+0.05*t
+		}
+		PlotWithStd(xx, yy, ss, 2, &A{C: "b", L: "mean ± 2σ"})
+		Gll("x", "y", nil)
+		err := SaveD("/tmp/gosl", "t_plot09.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot10(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot10. shaded bands")
+
+	Reset()
+	AxVspan(1, 2, &A{Fc: "y", Alpha: 0.3, Z: 1, L: "loading"})
+	AxHspan(-0.5, 0.5, &A{Fc: "g", Alpha: 0.2})
+	AxHspan(0, 1, nil)
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"plt.axvspan(1,2, label='loading',zorder=1,alpha=0.3,facecolor='y')\n",
+		"plt.axhspan(-0.5,0.5, alpha=0.2,facecolor='g')\n",
+		"plt.axhspan(0,1)\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		x := utl.LinSpace(0, 3, 31)
+		y := make([]float64, len(x))
+		for i := 0; i < len(x); i++ {
+			y[i] = math.Sin(2 * x[i])
+		}
+		Plot(x, y, &A{C: "b", L: "response"})
+		Gll("t", "u", nil)
+		err := SaveD("/tmp/gosl", "t_plot10.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot11(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot11. weighted histogram")
+
+	x := [][]float64{{1, 2, 2, 3}, {2, 3}}
+	w := [][]float64{{0.5, 1, 1, 0.5}, {2, 1}}
+
+	// without weights
+	Reset()
+	Hist(x, []string{"a", "b"}, &A{Hstacked: true})
+	chk.String(tst, defaultPlotter.bufferPy.String(), "x0=[[1,2,2,3,],[2,3,],]\ny0=[\"a\",\"b\",]\nplt.hist(x0,label=y0, stacked=1)\n")
+
+	// with weights
+	Reset()
+	err := HistW(x, w, []string{"a", "b"}, &A{Hstacked: true, Hnormed: true, Hnbins: 3})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferPy.String(), "x0=[[1,2,2,3,],[2,3,],]\nw0=[[0.5,1,1,0.5,],[2,1,],]\ny0=[\"a\",\"b\",]\nplt.hist(x0,weights=w0,label=y0, stacked=1,bins=3,normed=1)\n")
+
+	// with hatches cycled across series
+	Reset()
+	Hist(append(x, x[0]), []string{"a", "b", "c"}, &A{Hstacked: true, Hhatches: []string{"//", ".."}})
+	chk.String(tst, defaultPlotter.bufferPy.String(), "x0=[[1,2,2,3,],[2,3,],[1,2,2,3,],]\ny0=[\"a\",\"b\",\"c\",]\n"+
+		"h0 = plt.hist(x0,label=y0, stacked=1)\n"+
+		"for p in h0[2][0]: p.set_hatch('//')\n"+
+		"for p in h0[2][1]: p.set_hatch('..')\n"+
+		"for p in h0[2][2]: p.set_hatch('//')\n")
+
+	// one series
+	Reset()
+	Hist(x[:1], nil, &A{Hhatches: []string{"xx"}})
+	if !strings.HasSuffix(defaultPlotter.bufferPy.String(), "h0 = plt.hist(x0,label=y0)\nfor p in h0[2]: p.set_hatch('xx')\n") {
+		tst.Errorf("the bars of the only series should be hatched:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// errors
+	if HistW(x, w[:1], nil, nil) == nil {
+		tst.Errorf("HistW should have failed with wrong number of series\n")
+		return
+	}
+	if HistW(x, [][]float64{{1}, {1, 2}}, nil, nil) == nil {
+		tst.Errorf("HistW should have failed with wrong number of weights\n")
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot11.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_tricontour01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("tricontour01. contours of scattered data")
+
+	x := []float64{0, 1, 1, 0, 0.5}
+	y := []float64{0, 0, 1, 1, 0.5}
+	z := []float64{0, 1, 2, 1, 1}
+	tri := [][]int{{0, 1, 4}, {1, 2, 4}, {2, 3, 4}, {3, 0, 4}}
+
+	// filled
+	Reset()
+	TricontourF(x, y, z, nil, &A{UcmapIdx: 1, Unlevels: 4, UcbarLbl: "z"})
+	chk.String(tst, defaultPlotter.bufferPy.String(), "x0=np.array([0,1,1,0,0.5,],dtype=float)\n"+
+		"y0=np.array([0,0,1,1,0.5,],dtype=float)\n"+
+		"z0=np.array([0,1,2,1,1,],dtype=float)\n"+
+		"c0 = plt.tricontourf(x0,y0,z0,cmap=getCmap(1),levels=4)\n"+
+		"cc0 = plt.tricontour(x0,y0,z0,colors=['k'],levels=4,linewidths=[1])\n"+
+		"plt.clabel(cc0,inline=1,fontsize=10)\n"+
+		"cb0 = plt.colorbar(c0, format='%g')\n"+
+		"cb0.ax.set_ylabel('z')\n")
+
+	// lines with triangulation
+	Reset()
+	TricontourL(x, y, z, tri, &A{Colors: []string{"r"}, UnoLabels: true, UselectC: "b", UselectV: 1.5})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"tri0=np.array([[0,1,4,],[1,2,4,],[2,3,4,],[3,0,4,],],dtype=int)\n",
+		"c0 = plt.tricontour(x0,y0,z0,triangles=tri0,colors=['r'])\n",
+		"cc0 = plt.tricontour(x0,y0,z0,triangles=tri0,colors=['b'],levels=[1.5],linewidths=[3],linestyles=['-'])\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_tricontour01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_quiver01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quiver01. arrows colored by magnitude")
+
+	x, y := utl.MeshGrid2d(-1, 1, -1, 1, 5, 5)
+	gx, gy := utl.DblsAlloc(5, 5), utl.DblsAlloc(5, 5)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			gx[i][j], gy[i][j] = -y[i][j], x[i][j]
+		}
+	}
+
+	// monochrome
+	Reset()
+	Quiver(x, y, gx, gy, &A{C: "r"})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "q0 = plt.quiver(x0,y0,gx0,gy0, color='r')\n") || strings.Contains(txt, "colorbar") {
+		tst.Errorf("monochrome quiver is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// by magnitude
+	Reset()
+	Quiver(x, y, gx, gy, &A{C: "r", QbyMag: true, UcmapIdx: 3, Qscale: 20, Qwidth: 0.005, UcbarLbl: "speed"})
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"m0 = np.sqrt(gx0**2+gy0**2)\n",
+		"q0 = plt.quiver(x0,y0,gx0,gy0,m0,cmap=getCmap(3),scale=20,width=0.005)\n",
+		"cb0 = plt.colorbar(q0)\n",
+		"cb0.ax.set_ylabel('speed')\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		Equal()
+		QuiverKey(1, "1 m/s", 0.85, 1.03, nil)
+		err := SaveD("/tmp/gosl", "t_quiver01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_quiver02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quiver02. quiver key")
+
+	Reset()
+	if QuiverKey(1, "1 m/s", 0.9, 1.05, nil) == nil {
+		tst.Errorf("QuiverKey should have failed without quiver\n")
+		return
+	}
+
+	x, y := utl.MeshGrid2d(0, 1, 0, 1, 3, 3)
+	name := Quiver(x, y, x, y, nil)
+	chk.String(tst, name, "q0")
+	err := QuiverKey(0.5, "0.5 m/s", 0.9, 1.05, &A{C: "b", Fsz: 8})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	cmd := "plt.quiverkey(q0,0.9,1.05,0.5,r'0.5 m/s',coordinates='axes',color='b',fontproperties={'size':8})\n"
+	if !strings.HasSuffix(defaultPlotter.bufferPy.String(), cmd) {
+		tst.Errorf("buffer does not end with %q:\n%v\n", cmd, defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// key refers to the last quiver
+	name = Quiver(x, y, y, x, nil)
+	QuiverKey(1, "1", 0.1, 0.1, nil)
+	if !strings.HasSuffix(defaultPlotter.bufferPy.String(), io.Sf("plt.quiverkey(%s,", name)+"0.1,0.1,1,r'1',coordinates='axes')\n") {
+		tst.Errorf("quiver key should refer to %s:\n%v\n", name, defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_quiver02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot12(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot12. contour from function")
+
+	f := func(x, y float64) float64 {
+		return -math.Pow(math.Pow(math.Cos(x), 2.0)+math.Pow(math.Cos(y), 2.0), 2.0)
+	}
+
+	Reset()
+	X, Y, F, err := ContourFfromFunc(0, 1, 0, 2, 2, 3, f, &A{UnoCbar: true, UnoLines: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Matrix(tst, "X", 1e-15, X, [][]float64{{0, 1}, {0, 1}, {0, 1}})
+	chk.Matrix(tst, "Y", 1e-15, Y, [][]float64{{0, 0}, {1, 1}, {2, 2}})
+	chk.Scalar(tst, "F(1,2)", 1e-15, F[2][1], f(1, 2))
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "c0 = plt.contourf(x0,y0,z0,cmap=getCmap(0))\n") {
+		tst.Errorf("ContourFfromFunc should have called ContourF:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	Reset()
+	_, _, _, err = ContourLfromFunc(0, 1, 0, 1, 3, 3, f, &A{UnoLabels: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "c0 = plt.contour(x0,y0,z0,cmap=getCmap(0))\n") {
+		tst.Errorf("ContourLfromFunc should have called ContourL:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// errors
+	if _, _, _, err = ContourFfromFunc(0, 1, 0, 1, 1, 3, f, nil); err == nil {
+		tst.Errorf("ContourFfromFunc should have failed with nx < 2\n")
+		return
+	}
+	if _, _, _, err = ContourLfromFunc(0, 1, 0, 1, 3, 0, f, nil); err == nil {
+		tst.Errorf("ContourLfromFunc should have failed with ny < 2\n")
+		return
+	}
+
+	// same as plot03
+	if chk.Verbose {
+		Reset()
+		Equal()
+		xmin, xmax := -math.Pi/2.0+0.1, math.Pi/2.0-0.1
+		ContourFfromFunc(xmin, xmax, xmin, xmax, 21, 21, f, &A{UnumFmt: "%.1f", Lw: 1.5, UcbarLbl: "NICE", UselectC: "yellow", UselectV: -2.5})
+		err = SaveD("/tmp/gosl", "t_plot12.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot13(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot13. gridspec with width and height ratios")
+
+	Reset()
+	gs := GridSpec(2, 2, []float64{4, 1}, []float64{1, 4}, 0.05, 0.05)
+	chk.String(tst, gs, "gs0")
+	err := SubplotGS(gs, 1, 2, 0, 1)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	err = SubplotGS(gs, 0, 2, 1, 2)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferPy.String(), "gs0 = plt.GridSpec(2,2,width_ratios=[4,1],height_ratios=[1,4],wspace=0.05,hspace=0.05)\n"+
+		"plt.gcf().add_subplot(gs0[1:2,0:1])\n"+
+		"plt.gcf().add_subplot(gs0[0:2,1:2])\n")
+
+	// default spaces
+	Reset()
+	gs = GridSpec(1, 3, nil, nil, 0, 0)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "gs0 = plt.GridSpec(1,3)\n")
+
+	// errors
+	if SubplotGS("gs123", 0, 1, 0, 1) == nil {
+		tst.Errorf("SubplotGS should have failed with unknown grid\n")
+		return
+	}
+	if SubplotGS(gs, 0, 2, 0, 1) == nil {
+		tst.Errorf("SubplotGS should have failed with rowEnd > nrows\n")
+		return
+	}
+	if SubplotGS(gs, 0, 1, 2, 2) == nil {
+		tst.Errorf("SubplotGS should have failed with empty column span\n")
+		return
+	}
+	Reset()
+	if SubplotGS(gs, 0, 1, 0, 1) == nil {
+		tst.Errorf("SubplotGS should have failed after Reset\n")
+		return
+	}
+
+	// main panel with marginal histograms
+	if chk.Verbose {
+		Reset()
+		x := []float64{0.1, 0.5, 0.7, 1.2, 1.5, 1.6, 2.0, 2.4, 2.5, 3.1}
+		y := []float64{1.0, 0.8, 1.5, 2.1, 1.9, 2.5, 2.2, 3.0, 2.7, 3.5}
+		gs = GridSpec(2, 2, []float64{4, 1}, []float64{1, 4}, 0.05, 0.05)
+		SubplotGS(gs, 0, 1, 0, 1)
+		Hist([][]float64{x}, []string{"x"}, nil)
+		SubplotGS(gs, 1, 2, 0, 1)
+		Plot(x, y, &A{C: "r", M: "o", Ls: "none"})
+		SubplotGS(gs, 1, 2, 1, 2)
+		Hist([][]float64{y}, []string{"y"}, nil)
+		err = SaveD("/tmp/gosl", "t_plot13.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot14(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot14. subplot2grid with spans")
+
+	Reset()
+	err := SubplotSpan(2, 2, 0, 0, 1, 2)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	err = SubplotSpan(2, 2, 1, 1, 1, 1)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	SplotGap(0.3, 0.4)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.subplot2grid((2,2),(0,0),rowspan=1,colspan=2)\n"+
+		"plt.subplot2grid((2,2),(1,1),rowspan=1,colspan=1)\n"+
+		"plt.subplots_adjust(wspace=0.3, hspace=0.4)\n")
+
+	// errors
+	for _, c := range [][]int{
+		{0, 2, 0, 0, 1, 1}, // empty grid
+		{2, 2, 2, 0, 1, 1}, // row outside
+		{2, 2, 0, -1, 1, 1},
+		{2, 2, 0, 1, 1, 2}, // colspan too large
+		{2, 2, 1, 0, 2, 1}, // rowspan too large
+		{2, 2, 0, 0, 0, 1}, // empty span
+	} {
+		if SubplotSpan(c[0], c[1], c[2], c[3], c[4], c[5]) == nil {
+			tst.Errorf("SubplotSpan%v should have failed\n", c)
+			return
+		}
+	}
+
+	// 2x2 grid with top panel spanning two columns
+	if chk.Verbose {
+		Reset()
+		x := utl.LinSpace(0, 2*math.Pi, 41)
+		s, c := make([]float64, len(x)), make([]float64, len(x))
+		for i := 0; i < len(x); i++ {
+			s[i], c[i] = math.Sin(x[i]), math.Cos(x[i])
+		}
+		SubplotSpan(2, 2, 0, 0, 1, 2)
+		Plot(x, s, &A{C: "r", L: "sin"})
+		Gll("x", "y", nil)
+		SubplotSpan(2, 2, 1, 0, 1, 1)
+		Plot(x, c, &A{C: "b", L: "cos"})
+		Gll("x", "y", nil)
+		SubplotSpan(2, 2, 1, 1, 1, 1)
+		Plot(s, c, &A{C: "k"})
+		Gll("sin", "cos", nil)
+		SplotGap(0.3, 0.3)
+		err = SaveD("/tmp/gosl", "t_plot14.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot15(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot15. subplots with shared axes")
+
+	Reset()
+	SubplotShared(2, 2, 1, true, true)
+	n1 := defaultPlotter.bufferPy.Len()
+	SubplotShared(2, 2, 2, true, true)
+	n2 := defaultPlotter.bufferPy.Len()
+	SubplotShared(2, 2, 3, true, false)
+	n3 := defaultPlotter.bufferPy.Len()
+	SubplotShared(2, 2, 4, false, true)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "axs0 = plt.subplot(2,2,1)\n"+
+		"plt.setp(axs0.get_xticklabels(),visible=False)\n"+
+		io.Sf("axs%d = plt.subplot(2,2,2,sharex=axs0,sharey=axs0)\n", n1)+
+		io.Sf("plt.setp(axs%d.get_xticklabels(),visible=False)\n", n1)+
+		io.Sf("plt.setp(axs%d.get_yticklabels(),visible=False)\n", n1)+
+		io.Sf("axs%d = plt.subplot(2,2,3,sharex=axs0)\n", n2)+
+		io.Sf("axs%d = plt.subplot(2,2,4,sharey=axs0)\n", n3)+
+		io.Sf("plt.setp(axs%d.get_yticklabels(),visible=False)\n", n3))
+
+	// other grid and Reset
+	Reset()
+	SubplotShared(3, 1, 1, true, false)
+	n1 = defaultPlotter.bufferPy.Len()
+	SubplotShared(1, 2, 1, true, false)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "axs0 = plt.subplot(3,1,1)\n"+
+		"plt.setp(axs0.get_xticklabels(),visible=False)\n"+
+		io.Sf("axs%d = plt.subplot(1,2,1)\n", n1))
+
+	if chk.Verbose {
+		Reset()
+		x := utl.LinSpace(0, 2*math.Pi, 41)
+		for k := 1; k <= 4; k++ {
+			y := make([]float64, len(x))
+			for i := 0; i < len(x); i++ {
+				y[i] = float64(k) * math.Sin(float64(k)*x[i])
+			}
+			SubplotShared(2, 2, k, true, true)
+			Plot(x, y, &A{C: "b"})
+			Grid(nil)
+		}
+		err := SaveD("/tmp/gosl", "t_plot15.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot16(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot16. double x-scale and combined legend")
+
+	Reset()
+	DoubleXscale("normalised depth")
+	DoubleYscale("")
+	n := defaultPlotter.bufferPy.Len()
+	LegendCombined(&A{LegLoc: "upper left", LegNcol: 2})
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.sca(plt.gca().twiny())\n"+
+		"plt.gca().set_xlabel('normalised depth')\n"+
+		"plt.gca().twinx()\n"+
+		io.Sf("h%d, l%d = [], []\n", n, n)+
+		io.Sf("for a%d in plt.gcf().get_axes():\n", n)+
+		io.Sf("    if a%d.get_position().bounds == plt.gca().get_position().bounds:\n", n)+
+		io.Sf("        hh%d, ll%d = a%d.get_legend_handles_labels()\n", n, n, n)+
+		io.Sf("        h%d += hh%d; l%d += ll%d\n", n, n, n, n)+
+		io.Sf("if len(h%d) > 0 and len(l%d) > 0:\n", n, n)+
+		io.Sf("    l%d = plt.legend(h%d, l%d, loc='upper left', ncol=2, handlelength=3, prop={'size':8})\n", n, n, n)+
+		io.Sf("    addToEA(l%d)\n", n)+
+		io.Sf("    l%d.get_frame().set_linewidth(0.0)\n", n))
+
+	if chk.Verbose {
+		Reset()
+		depth := utl.LinSpace(0, 10, 11)
+		norm := make([]float64, len(depth))
+		sig := make([]float64, len(depth))
+		for i := 0; i < len(depth); i++ {
+			norm[i] = depth[i] / 10
+			sig[i] = 20 * depth[i]
+		}
+		Plot(depth, sig, &A{C: "r", L: "stress"})
+		Gll("depth [m]", "stress [kPa]", nil)
+		DoubleXscale("normalised depth")
+		Plot(norm, sig, &A{C: "b", Ls: "none", M: "o", L: "normalised"})
+		LegendCombined(nil)
+		err := SaveD("/tmp/gosl", "t_plot16.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot17(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot17. aspect ratio")
+
+	Reset()
+	ax1 := Subplot(1, 2, 1)
+	SetAspect(2.5)
+	ax2 := Subplot(1, 2, 2)
+	SetAspect(0)
+	chk.String(tst, defaultPlotter.bufferPy.String(), ax1+" = plt.subplot(1,2,1)\n"+
+		"plt.gca().set_aspect(2.5)\n"+
+		ax2+" = plt.subplot(1,2,2)\n"+
+		"plt.gca().set_aspect('auto')\n")
+
+	if chk.Verbose {
+		Reset()
+		x := utl.LinSpace(0, 1, 11)
+		Subplot(1, 2, 1)
+		Plot(x, x, &A{C: "r"})
+		SetAspect(2)
+		Subplot(1, 2, 2)
+		Plot(x, x, &A{C: "b"})
+		SetAspect(0.5)
+		err := SaveD("/tmp/gosl", "t_plot17.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot18(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot18. inverted axes")
+
+	Reset()
+	InvertXaxis()
+	InvertYaxis()
+	AxisYrange(0, 10)
+	AxisYrange(10, 0)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.gca().invert_xaxis()\n"+
+		"plt.gca().invert_yaxis()\n"+
+		"plt.axis([plt.axis()[0], plt.axis()[1], 0, 10])\n"+
+		"plt.gca().set_ylim(bottom=10, top=0)\n")
+
+	if chk.Verbose {
+		Reset()
+		depth := utl.LinSpace(0, 10, 11)
+		sig := make([]float64, len(depth))
+		for i := 0; i < len(depth); i++ {
+			sig[i] = 18 * depth[i]
+		}
+		Subplot(1, 2, 1)
+		Plot(sig, depth, &A{C: "r", M: "o"})
+		InvertYaxis()
+		Gll("stress [kPa]", "depth [m]", nil)
+		Subplot(1, 2, 2)
+		Plot(sig, depth, &A{C: "b", M: "s"})
+		AxisYrange(12, 0)
+		Gll("stress [kPa]", "depth [m]", nil)
+		err := SaveD("/tmp/gosl", "t_plot18.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot19(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot19. minor grid and grid styling")
+
+	Reset()
+	GridMinor(&A{Ls: "--", Alpha: 0.4})
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.minorticks_on()\n"+
+		"plt.grid(which='minor', color='grey', linestyle='--', linewidth=0.5, alpha=0.4, zorder=-1000)\n")
+
+	// override
+	Reset()
+	Gll("x", "y", &A{GridC: "k", GridLs: ":"})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.grid(color='k', linestyle=':', zorder=-1000)\nplt.xlabel(r'x')\n") {
+		tst.Errorf("Gll should have used the grid color and line style:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// dashed light grid
+	Reset()
+	Gll("x", "y", &A{GridC: "#cccccc", GridLs: "--", GridLw: 0.5, GridAlpha: 0.7})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.grid(color='#cccccc', linestyle='--', linewidth=0.5, alpha=0.7, zorder=-1000)\nplt.xlabel(r'x')\n") {
+		tst.Errorf("Gll should have used the grid color, line style, linewidth and transparency:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+	Reset()
+	Gll("x", "y", &A{GridLw: 2, GridAlpha: 1.5})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.grid(color='grey', linewidth=2, zorder=-1000)\n") {
+		tst.Errorf("Gll should have ignored the transparency out of range:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// empty labels
+	Reset()
+	Gll("", "y", &A{NoGrid: true})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.ylabel(r'y')\n") {
+		tst.Errorf("Gll should have skipped the empty xlabel:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+	Reset()
+	Gll("x", "", &A{NoGrid: true})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.xlabel(r'x')\nh") {
+		tst.Errorf("Gll should have skipped the empty ylabel:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+	Reset()
+	Gll("", "", nil)
+	if strings.Contains(defaultPlotter.bufferPy.String(), "label(") {
+		tst.Errorf("Gll should have skipped the empty labels:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// disable
+	Reset()
+	Gll("x", "y", &A{NoGrid: true})
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.xlabel(r'x')\n") {
+		tst.Errorf("Gll should not have drawn the grid:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// default
+	Reset()
+	Gll("x", "y", nil)
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String(), "plt.grid(color='grey', zorder=-1000)\n") {
+		tst.Errorf("Gll should have drawn the default grid:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		x := utl.LinSpace(1, 100, 100)
+		Plot(x, x, &A{C: "r"})
+		SetXlog()
+		SetYlog()
+		Gll("x", "y", &A{GridC: "k"})
+		GridMinor(&A{Alpha: 0.5})
+		err := SaveD("/tmp/gosl", "t_plot19.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot20(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot20. custom tick labels")
+
+	Reset()
+	err := SetXticksLabels([]float64{0, 1, 2}, []string{`$\sigma$`, `it's`, `"q"`}, 45, nil)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferPy.String(), "tp0=np.array([0,1,2,],dtype=float)\n"+
+		`tl0=["$\\sigma$","it's","\"q\"",]`+"\n"+
+		"plt.xticks(tp0,tl0,rotation=45,ha='right')\n")
+
+	Reset()
+	err = SetYticksLabels([]float64{0.5, 1.5}, []string{"A", "B"}, 0, &A{Fsz: 7})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferPy.String(), "tp0=np.array([0.5,1.5,],dtype=float)\n"+
+		`tl0=["A","B",]`+"\n"+
+		"plt.yticks(tp0,tl0,fontsize=7)\n")
+
+	Reset()
+	SetXticksLabels([]float64{0}, []string{"A"}, 30, &A{Ha: "center"})
+	if !strings.HasSuffix(defaultPlotter.bufferPy.String(), "plt.xticks(tp0,tl0,rotation=30,ha='center')\n") {
+		tst.Errorf("alignment should have been given by args:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// error
+	if SetXticksLabels([]float64{0, 1}, []string{"A"}, 0, nil) == nil {
+		tst.Errorf("SetXticksLabels should have failed with wrong number of labels\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Plot([]float64{0, 1, 2, 3}, []float64{1, 3, 2, 4}, &A{C: "r", M: "o"})
+		SetXticksLabels([]float64{0, 1, 2, 3}, []string{"first", "second", `$\sigma_{max}$`, "last one"}, 30, nil)
+		SetYticksLabels([]float64{1, 2, 3, 4}, []string{"low", "medium", "high", `$\infty$`}, 0, nil)
+		err = SaveD("/tmp/gosl", "t_plot20.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot21(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot21. logarithmic and symlog color normalisation")
+
+	x, y, z := utl.MeshGrid2dF(0, 1, 0, 1, 3, 3, func(x, y float64) float64 { return math.Pow(10, 4*x*y) })
+
+	// log
+	Reset()
+	err := ContourF(x, y, z, &A{UnormLog: true, UnoLines: true, UnoCbar: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "c0 = plt.contourf(x0,y0,z0,cmap=getCmap(0),norm=mcl.LogNorm(vmin=1,vmax=10000),locator=tck.LogLocator())\n") {
+		tst.Errorf("contourf should have used LogNorm:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// log with given limits and levels
+	Reset()
+	err = ContourL(x, y, z, &A{UnormLog: true, Uvmin: 0.1, Uvmax: 1e5, Ulevels: []float64{1, 10, 100}, UnoLabels: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "c0 = plt.contour(x0,y0,z0,cmap=getCmap(0),norm=mcl.LogNorm(vmin=0.1,vmax=100000),levels=[1,10,100])\n") {
+		tst.Errorf("contour should have used LogNorm with given limits:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// symlog
+	w := [][]float64{{-100, -1, 0}, {0, 1, 1000}}
+	Reset()
+	err = TricontourF([]float64{0, 1, 0, 1}, []float64{0, 0, 1, 1}, []float64{-100, 0, 1, 1000}, nil, &A{UnormSymLog: true, UnoLines: true, UnoCbar: true})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ",cmap=getCmap(0),norm=mcl.SymLogNorm(linthresh=10,vmin=-100,vmax=1000,base=10))\n") {
+		tst.Errorf("tricontourf should have used SymLogNorm:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// errors
+	Reset()
+	if ContourF(w, w, w, &A{UnormLog: true}) == nil {
+		tst.Errorf("LogNorm should have failed with non-positive values\n")
+		return
+	}
+	if ContourF(x, y, z, &A{UnormLog: true, Uvmin: -1, Uvmax: 10}) == nil {
+		tst.Errorf("LogNorm should have failed with non-positive vmin\n")
+		return
+	}
+	if ContourF(x, y, z, &A{UnormSymLog: true, Uvmin: 10, Uvmax: 1}) == nil {
+		tst.Errorf("SymLogNorm should have failed with vmin > vmax\n")
+		return
+	}
+	if ContourF(x, y, z, &A{UnormLog: true, UnormSymLog: true}) == nil {
+		tst.Errorf("norms should not be used together\n")
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferPy.String(), "")
+
+	if chk.Verbose {
+		Reset()
+		X, Y, Z := utl.MeshGrid2dF(0, 1, 0, 1, 41, 41, func(x, y float64) float64 { return math.Pow(10, 4*x*y) })
+		Subplot(1, 2, 1)
+		ContourF(X, Y, Z, &A{UnormLog: true, UnoLines: true})
+		_, _, W := utl.MeshGrid2dF(-1, 1, -1, 1, 41, 41, func(x, y float64) float64 { return 1000 * x * x * x * y })
+		Subplot(1, 2, 2)
+		ContourF(X, Y, W, &A{UnormSymLog: true, UcmapIdx: 1, Unlevels: 20, UnoLines: true})
+		err = SaveD("/tmp/gosl", "t_plot21.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot22(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot22. discrete colorbar with boundaries")
+
+	x, y, z := utl.MeshGrid2dF(0, 1, 0, 1, 3, 3, func(x, y float64) float64 { return 2 * (x + y) })
+	args := &A{Ubounds: []float64{0, 1, 1.5, 2, 4}, Colors: []string{"red", "orange", "yellow", "green"}, UnoLines: true, UnumFmt: "%.1f"}
+
+	Reset()
+	err := ContourF(x, y, z, args)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"c0 = plt.contourf(x0,y0,z0,cmap=mcl.ListedColormap(['red','orange','yellow','green']),norm=mcl.BoundaryNorm([0,1,1.5,2,4],4),levels=[0,1,1.5,2,4])\n",
+		"cb0 = plt.colorbar(c0, format='%.1f', ticks=[0,1,1.5,2,4])\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// error
+	Reset()
+	args.Colors = args.Colors[:3]
+	if ContourF(x, y, z, args) == nil {
+		tst.Errorf("ContourF should have failed with wrong number of colors\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		X, Y, Z := utl.MeshGrid2dF(0, 1, 0, 1, 41, 41, func(x, y float64) float64 { return 2 * (x + y) })
+		ContourF(X, Y, Z, &A{Ubounds: []float64{0, 1, 1.5, 2, 4}, Colors: []string{"red", "orange", "yellow", "green"}, UnoLines: true, UcbarLbl: "safety factor"})
+		err = SaveD("/tmp/gosl", "t_plot22.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot23(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot23. custom colormaps")
+
+	Reset()
+	header := defaultPlotter.bufferEa.String()
+	i1, err := DefineColormap("bwr2", []string{"blue", "white", "red"}, nil)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	i2, err := DefineColormap("stops", []string{"black", "#ff8800", "yellow"}, []float64{0, 0.2, 1})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Int(tst, "i1", i1, 7)
+	chk.Int(tst, "i2", i2, 8)
+	chk.String(tst, strings.TrimPrefix(defaultPlotter.bufferEa.String(), header), `COLORMAPS.append(mcl.LinearSegmentedColormap.from_list("bwr2",['blue','white','red',]))`+"\n"+
+		`COLORMAPS.append(mcl.LinearSegmentedColormap.from_list("stops",[(0,'black'),(0.2,'#ff8800'),(1,'yellow'),]))`+"\n")
+
+	// use in contour
+	x, y, z := utl.MeshGrid2dF(-1, 1, -1, 1, 3, 3, func(x, y float64) float64 { return x * y })
+	ContourF(x, y, z, &A{UcmapIdx: i2, UnoLines: true, UnoCbar: true})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "c0 = plt.contourf(x0,y0,z0,cmap=getCmap(8))\n") {
+		tst.Errorf("contourf should have used the custom colormap:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// Reset
+	Reset()
+	i1, _ = DefineColormap("bwr2", []string{"blue", "white", "red"}, nil)
+	chk.Int(tst, "i1 after Reset", i1, 7)
+
+	// errors
+	Reset()
+	if _, err = DefineColormap("a", []string{"blue"}, nil); err == nil {
+		tst.Errorf("DefineColormap should have failed with one color\n")
+		return
+	}
+	if _, err = DefineColormap("a", []string{"blue", "red"}, []float64{0}); err == nil {
+		tst.Errorf("DefineColormap should have failed with wrong number of positions\n")
+		return
+	}
+	if _, err = DefineColormap("a", []string{"blue", "red"}, []float64{0.1, 1}); err == nil {
+		tst.Errorf("DefineColormap should have failed with position not starting at 0\n")
+		return
+	}
+	if _, err = DefineColormap("a", []string{"blue", "red", "k"}, []float64{0, 0.5, 0.5}); err == nil {
+		tst.Errorf("DefineColormap should have failed with non-increasing positions\n")
+		return
+	}
+	chk.String(tst, defaultPlotter.bufferEa.String(), header)
+
+	if chk.Verbose {
+		Reset()
+		X, Y, Z := utl.MeshGrid2dF(-1, 1, -1, 1, 41, 41, func(x, y float64) float64 { return x*x + y*y })
+		idx, _ := DefineColormap("fire", []string{"black", "red", "yellow", "white"}, []float64{0, 0.3, 0.8, 1})
+		ContourF(X, Y, Z, &A{UcmapIdx: idx})
+		err = SaveD("/tmp/gosl", "t_plot23.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot24(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot24. precision of floating point numbers")
+
+	// round-trip
+	defer SetFloatFmt("")
+	vals := []float64{1e-300, math.Pi, -0.1, 1.0 / 3.0, 123456789.123456789, 1e300}
+	for _, format := range []string{"", "%.17g"} {
+		SetFloatFmt(format)
+		Reset()
+		genArray(defaultPlotter.pyBuf(), "x", vals)
+		txt := strings.TrimSuffix(strings.TrimPrefix(defaultPlotter.bufferPy.String(), "x=np.array(["), ",],dtype=float)\n")
+		res := strings.Split(txt, ",")
+		chk.Int(tst, "number of values", len(res), len(vals))
+		for i, r := range res {
+			v, err := strconv.ParseFloat(r, 64)
+			if err != nil {
+				tst.Errorf("%v", err)
+				return
+			}
+			if v != vals[i] {
+				tst.Errorf("format %q: %s should be %v\n", format, r, vals[i])
+				return
+			}
+		}
+	}
+
+	// NaN and Inf
+	SetFloatFmt("")
+	Reset()
+	genMat(defaultPlotter.pyBuf(), "a", [][]float64{{math.NaN(), math.Inf(1)}, {math.Inf(-1), 0}})
+	chk.String(tst, defaultPlotter.bufferPy.String(), "a=np.array([[np.nan,np.inf,],[-np.inf,0,],],dtype=float)\n")
+
+	// scalars
+	Reset()
+	Text(math.Pi, 1e-300, "pi", nil)
+	AxHline(1.0/3.0, nil)
+	AxisRange(0, math.Pi, math.Inf(-1), math.Inf(1))
+	PlotOne(0.1, math.Pi, nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.text(3.141592653589793,1e-300,\"pi\")\n"+
+		"plt.axhline(0.3333333333333333)\n"+
+		"plt.axis([0, 3.141592653589793, -np.inf, np.inf])\n"+
+		"plt.plot(0.1,3.141592653589793)\n")
+
+	// short numbers
+	SetFloatFmt("%.3g")
+	Reset()
+	genArray(defaultPlotter.pyBuf(), "x", []float64{math.Pi, math.NaN()})
+	Text(math.Pi, 1, "pi", nil)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "x=np.array([3.14,np.nan,],dtype=float)\nplt.text(3.14,1,\"pi\")\n")
+}
+
+func Test_plot25(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot25. clear and re-activate axes")
+
+	// handles
+	Reset()
+	ax1 := Subplot(1, 2, 1)
+	ax2 := SubplotI([]int{1, 2, 2})
+	chk.String(tst, ax1, "ax0")
+	if ax2 == ax1 || !strings.HasPrefix(ax2, "ax") {
+		tst.Errorf("handles of axes should be different: %q and %q\n", ax1, ax2)
+		return
+	}
+	chk.String(tst, SubplotI([]int{1, 2}), "")
+	axs := SubplotShared(2, 1, 1, true, false)
+	if !strings.HasPrefix(defaultPlotter.bufferPy.String()[strings.Index(defaultPlotter.bufferPy.String(), axs):], axs+" = plt.subplot(2,1,1)") {
+		tst.Errorf("SubplotShared should return the name of the axes:\n%s\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// two panels: the first one is cleared and redrawn after drawing the second one
+	x := []float64{0, 1, 2}
+	Reset()
+	ax1 = Subplot(1, 2, 1)
+	Plot(x, x, &A{C: "r", L: "first"})
+	ax2 = Subplot(1, 2, 2)
+	Plot(x, x, &A{C: "b", L: "second"})
+	SetCurrentAxes(ax1)
+	Cla()
+	Plot(x, []float64{2, 1, 0}, &A{C: "g", L: "redrawn"})
+	Legend(nil)
+	SetCurrentAxes(ax2)
+	Legend(nil)
+	txt := defaultPlotter.bufferPy.String()
+	i := strings.Index(txt, "plt.sca("+ax1+")\nplt.cla()\n")
+	j := strings.Index(txt, "plt.sca("+ax2+")\n")
+	if i < 0 || j < i || strings.Index(txt, "color='b'") > i || strings.Index(txt, "color='g'") < i {
+		tst.Errorf("first panel should be cleared and redrawn after drawing the second one:\n%s\n", txt)
+		return
+	}
+	chk.Int(tst, "number of legends", strings.Count(txt, "plt.legend("), 2)
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot25.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot26(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot26. hatch patterns")
+
+	// band, polyline and circle
+	x := []float64{0, 1, 2}
+	Reset()
+	PlotWithBand(x, x, []float64{-0.5, 0.5, 1.5}, []float64{0.5, 1.5, 2.5}, &A{C: "k", Hatch: "\\\\"})
+	Polyline([][]float64{{0, 1}, {1, 2}, {0, 2}}, &A{Fc: "none", Ec: "k", Hatch: "xx", Closed: true})
+	Circle(1.5, 0.5, 0.3, &A{Fc: "w", Ec: "k", Hatch: ".."})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"l0, = plt.plot(x0,y0, color='k')\n",
+		",color=l0.get_color(),alpha=0.3,linewidth=0,hatch='\\\\')\n",
+		"pat.PathPatch(ph", ", facecolor='none',edgecolor='k',hatch='xx')\n",
+		"pat.Circle((1.5,0.5), 0.3, facecolor='w',edgecolor='k',hatch='..')\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// stacked bars
+	Reset()
+	Hist([][]float64{{1, 2, 2, 3, 3, 3}, {2, 3, 3, 4}, {1, 4, 4, 4}}, []string{"a", "b", "c"}, &A{
+		Colors:   []string{"w", "w", "w"},
+		Ec:       "k",
+		Htype:    "bar",
+		Hstacked: true,
+		Hhatches: []string{"///", "..", "xx"},
+	})
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Count(txt, ".set_hatch(") != 3 {
+		tst.Errorf("the bars of the three series should be hatched:\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		Legend(nil)
+		err := SaveD("/tmp/gosl", "t_plot26.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot27(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot27. custom dashes")
+
+	x := []float64{0, 1, 2}
+	Reset()
+	for i, d := range [][]float64{{5, 2}, {5, 2, 1, 2}, {1, 1}, {8, 2, 2, 2, 2, 2}, {2, 4}, {10, 3}} {
+		Plot(x, []float64{float64(i), float64(i) + 1, float64(i)}, &A{C: "k", Dashes: d, L: io.Sf("%v", d)})
+	}
+	AxHline(3, &A{C: "grey", Dashes: []float64{1, 3}})
+	AxVline(1, &A{C: "grey", Ls: "-", Dashes: []float64{1, 3}})
+	Polyline([][]float64{{0, 0}, {2, 0}, {2, 6}, {0, 6}}, &A{Fc: "none", Ec: "k", Dashes: []float64{4, 4}, Closed: true})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		" color='k',label='[5 2 1 2]',dashes=[5,2,1,2])\n",
+		"plt.axhline(3, color='grey',dashes=[1,3])\n",
+		"plt.axvline(1, color='grey',ls='-',dashes=[1,3])\n",
+		",ls=(0,[4,4]), facecolor='none',edgecolor='k')\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		Legend(&A{LegOut: true})
+		err := SaveD("/tmp/gosl", "t_plot27.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot28(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot28. rasterized artists")
+
+	x := []float64{0, 1, 2}
+	z := [][]float64{{0, 1}, {1, 2}}
+	Reset()
+	Plot(x, x, &A{C: "r", Rasterized: true})
+	Bubble(x, x, x, &A{Rasterized: true})
+	ContourF(z, z, z, &A{UnoLines: true, UnoCbar: true, Rasterized: true})
+	ContourF(z, z, z, &A{UnoLines: true, UnoCbar: true})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		",y0, color='r',rasterized=True)\n",
+		"plt.scatter(x", ",s=s", " rasterized=True)\n",
+		",z", ",rasterized=True)\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	if strings.Count(txt, "rasterized=True") != 3 {
+		tst.Errorf("only three artists should be rasterized:\n%v\n", txt)
+		return
+	}
+
+	// size of EPS figures with and without rasterized scatter
+	if chk.Verbose {
+		rnd := rand.New(rand.NewSource(1))
+		n := 100000
+		X, Y, S := make([]float64, n), make([]float64, n), make([]float64, n)
+		for i := 0; i < n; i++ {
+			X[i], Y[i], S[i] = rnd.NormFloat64(), rnd.NormFloat64(), 4
+		}
+		var sizes [2]int64
+		for i, rasterized := range []bool{false, true} {
+			SetForEps(0.75, 300, nil)
+			Bubble(X, Y, S, &A{C: "k", Rasterized: rasterized})
+			fn := io.Sf("t_plot28_%v.eps", rasterized)
+			err := SaveD("/tmp/gosl", fn)
+			if err != nil {
+				tst.Errorf("%v", err)
+				return
+			}
+			info, err := os.Stat("/tmp/gosl/" + fn)
+			if err != nil {
+				tst.Errorf("%v", err)
+				return
+			}
+			sizes[i] = info.Size()
+		}
+		SetRc(nil)
+		io.Pforan("size of EPS: %d bytes (vector) and %d bytes (rasterized)\n", sizes[0], sizes[1])
+		if sizes[1] >= sizes[0] {
+			tst.Errorf("rasterized figure should be smaller\n")
+		}
+	}
+}
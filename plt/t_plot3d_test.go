@@ -0,0 +1,452 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_plot3d01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d01. quiver 3d")
+
+	// radial field on paraboloid
+	X, Y, Z := utl.MeshGrid2dF(-1, 1, -1, 1, 5, 5, func(x, y float64) float64 {
+		return x*x + y*y
+	})
+
+	Reset()
+	Quiver3d(X, Y, Z, X, Y, Z, true, &A{C: "r", Qlength: 0.3, Qnormalize: true})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ".quiver(") || !strings.Contains(defaultPlotter.bufferPy.String(), "length=0.3,normalize=True, color='r')") {
+		tst.Errorf("buffer does not contain the quiver command:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d02. surface with projections")
+
+	// two gaussian bumps (similar to matplotlib's test data)
+	X, Y, Z := utl.MeshGrid2dF(-3, 3, -3, 3, 31, 31, func(x, y float64) float64 {
+		z1 := math.Exp(-(x*x + y*y) / 2.0)
+		z2 := math.Exp(-((x-1)*(x-1) + (y-1)*(y-1)) / 1.5)
+		return 10.0 * (z2 - z1)
+	})
+
+	Reset()
+	SurfaceWithProjections(X, Y, Z, true, &A{UcmapIdx: 0, Unlevels: 10, SprojX: true, SprojY: true})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"zdir='z',offset=", "zdir='x',offset=-3.6,", "zdir='y',offset=3.6,", ",levels=10)"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q\n", cmd)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d03. trisurf")
+
+	x := []float64{0, 1, 1, 0, 0.5}
+	y := []float64{0, 0, 1, 1, 0.5}
+	z := []float64{0, 0, 0, 0, 1}
+
+	// Delaunay
+	Reset()
+	Trisurf(x, y, z, nil, true, &A{UcmapIdx: 3, Lw: 0.5})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, ".plot_trisurf(") || strings.Contains(txt, "triangles=") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+	if !strings.Contains(txt, ",cmap=getCmap(3),antialiased=1, lw=0.5)") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// explicit connectivity
+	tri := [][]int{{0, 1, 4}, {1, 2, 4}, {2, 3, 4}, {3, 0, 4}}
+	Reset()
+	Trisurf(x, y, z, tri, true, &A{C: "r", SnoAa: true})
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "=np.array([[0,1,4,],[1,2,4,],[2,3,4,],[3,0,4,],],dtype=int)\n") {
+		tst.Errorf("buffer does not contain connectivity:\n%v\n", txt)
+		return
+	}
+	if !strings.Contains(txt, ",antialiased=0, color='r')") || strings.Contains(txt, "cmap") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+	if !strings.Contains(txt, ",triangles=tri") {
+		tst.Errorf("buffer does not contain triangles:\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d03.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d04. bar3d")
+
+	xpos := []float64{0, 1, 2, 0, 1, 2}
+	ypos := []float64{0, 0, 0, 1, 1, 1}
+	heights := []float64{1, 3, 2, 4, 2, 1}
+
+	Reset()
+	Bar3d(xpos, ypos, heights, 0.5, 0.5, true, &A{Colors: []string{"r", "g"}, Ec: "k"})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, ".bar3d(x") || !strings.Contains(txt, ",0.5,0.5,h") {
+		tst.Errorf("buffer does not contain the bar3d command:\n%v\n", txt)
+		return
+	}
+	if !strings.Contains(txt, ",shade=True,color=['r','g','r','g','r','g'], edgecolor='k')") {
+		tst.Errorf("buffer does not contain the colors:\n%v\n", txt)
+		return
+	}
+
+	// Colors replace C
+	Reset()
+	Bar3d(xpos, ypos, heights, 0.5, 0.5, true, &A{C: "b", Colors: []string{"r"}})
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, ",shade=True,color=['r','r','r','r','r','r'])\n") || strings.Count(txt, "color=") != 1 {
+		tst.Errorf("color should be given only once:\n%v\n", txt)
+		return
+	}
+
+	// single color
+	Reset()
+	Bar3d(xpos, ypos, heights, 0.5, 0.5, true, &A{C: "b"})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ",shade=True, color='b')\n") {
+		tst.Errorf("buffer does not contain the color:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d04.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d05. surface and wireframe with colormaps")
+
+	X, Y, Z := utl.MeshGrid2dF(-1, 1, -1, 1, 11, 11, func(x, y float64) float64 {
+		return x*x - y*y
+	})
+
+	// plain
+	Reset()
+	Surface(X, Y, Z, true, &A{C: "b"})
+	Wireframe(X, Y, Z, false, nil)
+	txt := defaultPlotter.bufferPy.String()
+	if strings.Contains(txt, "cmap") || strings.Contains(txt, "colorbar") {
+		tst.Errorf("plain surface should not have colormap:\n%v\n", txt)
+		return
+	}
+	if !strings.Contains(txt, ".plot_surface(x") || !strings.Contains(txt, ", color='b')") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// colormapped
+	Reset()
+	Surface(X, Y, Z, true, &A{UcmapIdx: 3, VminVmax: []float64{-1, 1}, UcbarLbl: "z"})
+	Wireframe(X, Y, Z, false, &A{UcmapIdx: 2, UnoCbar: true})
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{",cmap=getCmap(3),vmin=-1,vmax=1)", "= plt.colorbar(p", ", shrink=0.5, aspect=10)", ".ax.set_ylabel('z')", ".plot_wireframe(", ",cmap=getCmap(2))", ".set_array("} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	if strings.Count(txt, "plt.colorbar(") != 1 {
+		tst.Errorf("there should be only one colorbar\n")
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d05.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d06. scatter with colors")
+
+	x := utl.LinSpace(0, 1, 11)
+	y := make([]float64, len(x))
+	z := make([]float64, len(x))
+	v := make([]float64, len(x))
+	for i := 0; i < len(x); i++ {
+		y[i] = math.Cos(4 * x[i])
+		z[i] = math.Sin(4 * x[i])
+		v[i] = x[i] * x[i]
+	}
+
+	Reset()
+	name := Plot3dPointsC(x, y, z, v, true, &A{M: "s", Ms: 5, Alpha: 0.5, C: "r", UcmapIdx: 1, VminVmax: []float64{0, 1}, UcbarLbl: "v"})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{name + " = ax", ".scatter(x", ",c=v", ",cmap=getCmap(1),vmin=0,vmax=1,s=25, marker='s',alpha=0.5)", "plt.colorbar(" + name, ".set_ylabel('v')"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	if strings.Contains(txt, "ms=") || strings.Contains(txt, "color='r'") {
+		tst.Errorf("scatter should not have 'ms' or 'color':\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d06.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d07. 3D text")
+
+	Reset()
+	Plot3dPoints([]float64{0, 1}, []float64{0, 1}, []float64{0, 1}, true, &A{C: "b"})
+	Text3d(1, 1, 1, "node \"A\" 'b' $\\alpha$\nα", &A{C: "r", Fsz: 8, Ha: "left", Zdir: "x"})
+	txt := defaultPlotter.bufferPy.String()
+	cmd := ".text(1,1,1,\"node \\\"A\\\" 'b' $\\\\alpha$\\nα\",zdir='x', color='r',ha='left',fontsize=8)\n"
+	if !strings.HasSuffix(txt, cmd) {
+		tst.Errorf("buffer does not end with %q:\n%v\n", cmd, txt)
+		return
+	}
+	if !strings.Contains(txt, "= plt.gca()\n") {
+		tst.Errorf("Text3d should use the current axes:\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d07.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d08. polygons")
+
+	// tetrahedron
+	P := [][]float64{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	faces := [][][]float64{{P[0], P[2], P[1]}, {P[0], P[1], P[3]}, {P[0], P[3], P[2]}, {P[1], P[2], P[3]}}
+
+	Reset()
+	Polygons3d(faces, true, &A{Fc: "cyan", Ec: "k", Lw: 0.5, Alpha: 0.4})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"f0=[[[0,0,0,],[0,1,0,],[1,0,0,],],",
+		"pc0 = m3d.art3d.Poly3DCollection(f0, lw=0.5,alpha=0.4,facecolor='cyan',edgecolor='k')\n",
+		"ax0.add_collection3d(pc0)\n",
+		"ax0.auto_scale_xyz([0,1],[0,1],[0,1])\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plot3d08.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d09. primitive shapes")
+
+	Reset()
+	X, Y, Z := Sphere(1, 2, 3, 2, 5, 3, true, &A{C: "r", Alpha: 0.5})
+	chk.Int(tst, "nv", len(X), 3)
+	chk.Int(tst, "nu", len(X[0]), 5)
+	chk.Vector(tst, "south pole", 1e-15, []float64{X[0][0], Y[0][0], Z[0][0]}, []float64{1, 2, 1})
+	chk.Vector(tst, "equator (u=π/2)", 1e-15, []float64{X[1][1], Y[1][1], Z[1][1]}, []float64{1, 4, 3})
+	chk.Vector(tst, "north pole", 1e-15, []float64{X[2][3], Y[2][3], Z[2][3]}, []float64{1, 2, 5})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ".plot_surface(x0,y0,z0, color='r',alpha=0.5)\n") {
+		tst.Errorf("Sphere should have called Surface:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	Reset()
+	X, Y, Z = Cylinder(0, 0, 1, 1, 4, 5, 2, true, &A{Swire: true})
+	chk.Vector(tst, "base (u=π)", 1e-15, []float64{X[0][2], Y[0][2], Z[0][2]}, []float64{-1, 0, 1})
+	chk.Vector(tst, "top (u=3π/2)", 1e-15, []float64{X[1][3], Y[1][3], Z[1][3]}, []float64{0, -1, 5})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ".plot_wireframe(") {
+		tst.Errorf("Cylinder should have called Wireframe:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	X, Y, Z = Cone(0, 0, 0, 2, 3, 5, 3, false, nil)
+	chk.Vector(tst, "base (u=0)", 1e-15, []float64{X[0][0], Y[0][0], Z[0][0]}, []float64{2, 0, 0})
+	chk.Vector(tst, "middle (u=π/2)", 1e-15, []float64{X[1][1], Y[1][1], Z[1][1]}, []float64{0, 1, 1.5})
+	chk.Vector(tst, "apex", 1e-15, []float64{X[2][4], Y[2][4], Z[2][4]}, []float64{0, 0, 3})
+
+	if chk.Verbose {
+		Reset()
+		Cylinder(0, 0, 0, 1, 2, 31, 5, true, &A{C: "c", Alpha: 0.3})
+		Sphere(0, 0, 2.5, 0.5, 31, 16, false, &A{C: "r"})
+		Cone(2, 2, 0, 0.8, 1.5, 31, 7, false, &A{C: "k", Swire: true})
+		err := SaveD("/tmp/gosl", "t_plot3d09.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d10(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d10. parametric surface")
+
+	// saddle
+	Reset()
+	X, Y, Z, err := SurfaceF(-1, 1, 0, 2, 3, 2, func(u, v float64) (x, y, z float64) {
+		return u, v, u*u - v*v
+	}, true, &A{UcmapIdx: 1})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Matrix(tst, "X", 1e-15, X, [][]float64{{-1, 0, 1}, {-1, 0, 1}})
+	chk.Matrix(tst, "Y", 1e-15, Y, [][]float64{{0, 0, 0}, {2, 2, 2}})
+	chk.Matrix(tst, "Z", 1e-15, Z, [][]float64{{1, 0, 1}, {-3, -4, -3}})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ".plot_surface(x0,y0,z0,cmap=getCmap(1))\n") {
+		tst.Errorf("SurfaceF should have called Surface:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// errors
+	_, _, _, err = SurfaceF(0, 1, 0, 1, 1, 5, func(u, v float64) (x, y, z float64) { return }, true, nil)
+	if err == nil {
+		tst.Errorf("SurfaceF should have failed with nu < 2\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		SurfaceF(0, 2*math.Pi, 0, 2*math.Pi, 41, 21, func(u, v float64) (x, y, z float64) { // torus
+			return (2 + math.Cos(v)) * math.Cos(u), (2 + math.Cos(v)) * math.Sin(u), math.Sin(v)
+		}, true, &A{C: "y", Alpha: 0.8})
+		err = SaveD("/tmp/gosl", "t_plot3d10.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d11(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d11. surface with contour on floor")
+
+	x, y, z := utl.MeshGrid2dF(-1, 1, -1, 1, 3, 3, func(x, y float64) float64 { return x*x + y*y })
+
+	Reset()
+	SurfaceContourFloor(x, y, z, true, &A{UcmapIdx: 2, Unlevels: 5, Smargin: 0.5, UnoCbar: true})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		".plot_surface(x0,y0,z0,cmap=getCmap(2))\n",
+		".contourf(x0,y0,z0,zdir=",
+		"zdir='z',offset=-1,cmap=getCmap(2),levels=5)\n",
+		".set_zlim3d(-1,2)\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// default margin
+	Reset()
+	SurfaceContourFloor(x, y, z, true, nil)
+	if !strings.Contains(defaultPlotter.bufferPy.String(), ".set_zlim3d(-0.2,2)\n") {
+		tst.Errorf("default margin is incorrect:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		X, Y, Z := utl.MeshGrid2dF(-2, 2, -2, 2, 31, 31, func(x, y float64) float64 { return math.Sin(x) * math.Cos(y) })
+		SurfaceContourFloor(X, Y, Z, true, &A{UcmapIdx: 4, Unlevels: 11, Smargin: 0.3})
+		err := SaveD("/tmp/gosl", "t_plot3d11.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plot3d12(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plot3d12. box aspect")
+
+	Reset()
+	SetBoxAspect3d(1, 2, 0.5)
+	chk.String(tst, defaultPlotter.bufferPy.String(), "plt.gca().set_box_aspect((1,2,0.5))\n")
+
+	if chk.Verbose {
+		Reset()
+		X, Y, Z := utl.MeshGrid2dF(-2, 2, -4, 4, 21, 41, func(x, y float64) float64 { return math.Sin(x) * math.Cos(y) })
+		Surface(X, Y, Z, true, &A{UcmapIdx: 4})
+		SetBoxAspect3d(1, 2, 0.5)
+		err := SaveD("/tmp/gosl", "t_plot3d12.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_plotlog01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plotlog01")
+
+	x := []float64{-1, 0, 1, 2, 3, 4}
+	y := []float64{1, 2, 0, -3, 5, 6}
+
+	// drop
+	xx, yy, ndropped := filterLog(x, y, true, false, nil)
+	chk.Int(tst, "ndropped", ndropped, 2)
+	chk.Vector(tst, "logx: xx", 1e-17, xx, []float64{1, 2, 3, 4})
+	chk.Vector(tst, "logx: yy", 1e-17, yy, []float64{0, -3, 5, 6})
+
+	xx, yy, ndropped = filterLog(x, y, false, true, nil)
+	chk.Int(tst, "ndropped", ndropped, 2)
+	chk.Vector(tst, "logy: xx", 1e-17, xx, []float64{-1, 0, 3, 4})
+	chk.Vector(tst, "logy: yy", 1e-17, yy, []float64{1, 2, 5, 6})
+
+	xx, yy, ndropped = filterLog(x, y, true, true, nil)
+	chk.Int(tst, "ndropped", ndropped, 4)
+	chk.Vector(tst, "loglog: xx", 1e-17, xx, []float64{3, 4})
+	chk.Vector(tst, "loglog: yy", 1e-17, yy, []float64{5, 6})
+
+	// clip
+	xx, yy, ndropped = filterLog(x, y, true, true, &A{LogFloor: 0.1})
+	chk.Int(tst, "ndropped", ndropped, 4)
+	chk.Vector(tst, "clip: xx", 1e-17, xx, []float64{0.1, 0.1, 1, 2, 3, 4})
+	chk.Vector(tst, "clip: yy", 1e-17, yy, []float64{1, 2, 0.1, 0.1, 5, 6})
+
+	// buffer
+	Reset()
+	_, _, ndropped = PlotLogLog(x, y, nil)
+	chk.Int(tst, "ndropped", ndropped, 4)
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_plotlog01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plotlog02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plotlog02. slope indicator")
+
+	Reset()
+	P := SlopeIndicator(1, 10, 1, 2, false, nil)
+	chk.Matrix(tst, "P", 1e-12, P, [][]float64{{1, 10}, {10, 10}, {10, 1000}})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{`"1", color='k',ha='center',va='top'`, `"2", color='k',ha='left',va='center'`} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	Reset()
+	P = SlopeIndicator(1, 10, 1, -1, true, nil)
+	chk.Matrix(tst, "P (flip)", 1e-12, P, [][]float64{{1, 10}, {1, 1}, {10, 1}})
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{`"1", color='k',ha='center',va='top'`, `"-1", color='k',ha='right',va='center'`} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// defaults are kept with a custom color
+	Reset()
+	SlopeIndicator(1, 10, 1, 2, false, &A{Ec: "r"})
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"facecolor='none',edgecolor='r'", `"1", color='r',`, `"2", color='r',`} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	Reset()
+	SlopeIndicator(1, 10, 1, 2, false, &A{Lw: 2})
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"facecolor='none',edgecolor='k'", `"1", color='k',`} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// below last segment
+	h := []float64{1, 0.1, 0.01, 0.001}
+	e := []float64{1, 1e-2, 1e-4, 1e-6}
+	Reset()
+	P, err := SlopeIndicatorLast(h, e, 0.5, 2, nil)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Matrix(tst, "P (last)", 1e-12, P, [][]float64{
+		{math.Pow(10, -2.75), math.Pow(10, -5.625)},
+		{math.Pow(10, -2.25), math.Pow(10, -5.625)},
+		{math.Pow(10, -2.25), math.Pow(10, -4.625)},
+	})
+	_, err = SlopeIndicatorLast(h[:1], e[:1], 0.5, 2, nil)
+	if err == nil {
+		tst.Errorf("SlopeIndicatorLast should have failed with a single point\n")
+		return
+	}
+
+	if chk.Verbose {
+		PlotLogLog(h, e, &A{C: "b", M: "o"})
+		SlopeIndicator(0.1, 1e-5, 0.5, -1, true, &A{Ec: "r"})
+		err := SaveD("/tmp/gosl", "t_plotlog02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_plotlog03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plotlog03. histogram with log bins")
+
+	chk.Vector(tst, "edges", 1e-13, logBins(0.01, 100, 4), []float64{0.01, 0.1, 1, 10, 100})
+	chk.Vector(tst, "edges (single value)", 1e-13, logBins(10, 10, 2), []float64{math.Pow(10, 0.5), 10, math.Pow(10, 1.5)})
+
+	x := [][]float64{{0.5, 1, 2, 50}, {-1, 0, 8, 1000}}
+	Reset()
+	edges, err := HistLog(x, []string{"a", "b"}, &A{Hnbins: 3})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Vector(tst, "edges", 1e-13, edges, []float64{0.5, 0.5 * math.Pow(2000, 1.0/3.0), 0.5 * math.Pow(2000, 2.0/3.0), 1000})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"x0=[[0.5,1,2,50,],[8,1000,],]\n",
+		"plt.hist(x0,bins=e0,label=y0)\n",
+		"plt.gca().set_xscale('log')\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// strict
+	_, err = HistLog(x, []string{"a", "b"}, &A{Hstrict: true})
+	if err == nil {
+		tst.Errorf("HistLog should have failed with non-positive samples\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		y := make([]float64, 200)
+		for i := 0; i < len(y); i++ {
+			y[i] = math.Pow(10, 3*math.Sin(float64(i)))
+		}
+		HistLog([][]float64{y}, []string{"y"}, &A{Hnbins: 20})
+		err = SaveD("/tmp/gosl", "t_plotlog03.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
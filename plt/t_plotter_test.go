@@ -0,0 +1,128 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// drawPlotter01 draws a figure with lines or contours on p
+func drawPlotter01(p *Plotter, contours bool) {
+	if contours {
+		x := [][]float64{{0, 1}, {0, 1}}
+		y := [][]float64{{0, 0}, {1, 1}}
+		z := [][]float64{{0, 1}, {1, 2}}
+		p.ContourF(x, y, z, &A{UcmapIdx: 1, UcbarLbl: "z"})
+		p.Gll("x", "y", nil)
+		return
+	}
+	p.Subplot(2, 1, 1)
+	p.Plot([]float64{0, 1, 2}, []float64{0, 1, 4}, &A{C: "r", L: "a"})
+	p.Subplot(2, 1, 2)
+	p.Plot([]float64{0, 1, 2}, []float64{4, 1, 0}, &A{C: "b", L: "b"})
+	p.Legend(nil)
+}
+
+func Test_plotter01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plotter01. independent plotters")
+
+	// new plotters are independent of the default one
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	p := NewPlotter()
+	chk.String(tst, p.bufferPy.String(), "")
+	chk.String(tst, p.bufferEa.String(), defaultPlotter.bufferEa.String())
+	p.Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	chk.String(tst, p.bufferPy.String(), defaultPlotter.bufferPy.String())
+	p.Text(0, 0, "hello", nil)
+	if strings.Contains(defaultPlotter.bufferPy.String(), "hello") {
+		tst.Errorf("default plotter should not have been changed\n")
+	}
+
+	// settings are independent too
+	p.SetTempDir("/tmp/gosl/plotter")
+	p.SetLayout(&Layout{Tight: true})
+	chk.String(tst, defaultPlotter.tempDir, "")
+	if defaultPlotter.layout.Tight {
+		tst.Errorf("layout of default plotter should not have been changed\n")
+	}
+}
+
+func Test_plotter02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("plotter02. concurrent plotters")
+
+	// fake interpreter
+	dir := "/tmp/gosl/plotter02"
+	os.RemoveAll(dir)
+	os.MkdirAll(dir, 0777)
+	fake := dir + "/fakepython.sh"
+	io.WriteFileS(fake, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"fi\n")
+	os.Chmod(fake, 0755)
+	oldCmd := pythonCmd
+	defer func() { pythonCmd, backendInfo = oldCmd, nil }()
+	SetPythonCmd(fake)
+	_, err := CheckBackend()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+
+	// reference scripts
+	refs := make([]string, 2)
+	for i := 0; i < 2; i++ {
+		p := NewPlotter()
+		drawPlotter01(p, i == 1)
+		refs[i] = p.Script()
+	}
+	if refs[0] == refs[1] {
+		tst.Errorf("figures should be different\n")
+		return
+	}
+
+	// build and save figures in parallel
+	nrep := 10
+	scripts := make([]string, 2*nrep)
+	errs := make([]error, 2*nrep)
+	var wg sync.WaitGroup
+	for k := 0; k < 2*nrep; k++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			p := NewPlotter()
+			p.SetTempDir(dir)
+			drawPlotter01(p, k%2 == 1)
+			errs[k] = p.Save(io.Sf("%s/fig%d.png", dir, k))
+			scripts[k] = p.Script()
+		}(k)
+	}
+	wg.Wait()
+	for k := 0; k < 2*nrep; k++ {
+		if errs[k] != nil {
+			tst.Errorf("%v", errs[k])
+			return
+		}
+		if !strings.HasPrefix(scripts[k], refs[k%2]) {
+			tst.Errorf("script %d is incorrect:\n%v\n", k, scripts[k])
+			return
+		}
+		if !strings.HasSuffix(scripts[k], io.Sf("plt.savefig(r'%s/fig%d.png', bbox_inches='tight', bbox_extra_artists=eaOf(plt.gcf()))\n", dir, k)) {
+			tst.Errorf("script %d should end with savefig:\n%v\n", k, scripts[k])
+			return
+		}
+	}
+}
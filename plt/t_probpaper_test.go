@@ -0,0 +1,91 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_probpaper01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("probpaper01. inverse of standard normal CDF")
+
+	// known values
+	chk.Scalar(tst, "Φ⁻¹(0.5)", 1e-15, stdNormalInv(0.5), 0)
+	chk.Scalar(tst, "Φ⁻¹(0.975)", 1e-8, stdNormalInv(0.975), 1.959963984540054)
+	chk.Scalar(tst, "Φ⁻¹(0.01)", 1e-8, stdNormalInv(0.01), -2.326347874040841)
+	chk.Scalar(tst, "Φ⁻¹(0.999)", 1e-8, stdNormalInv(0.999), 3.090232306167814)
+	chk.Scalar(tst, "Φ⁻¹(1e-10)", 1e-8, stdNormalInv(1e-10), -6.361340902404056)
+
+	// round trip
+	for _, p := range []float64{1e-6, 0.001, 0.02, 0.02425, 0.1, 0.3, 0.7, 0.9, 0.98, 0.999, 1 - 1e-6} {
+		x := stdNormalInv(p)
+		chk.Scalar(tst, "Φ(Φ⁻¹(p))", 1e-8*p, 0.5*math.Erfc(-x/math.Sqrt2), p)
+	}
+}
+
+func Test_probpaper02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("probpaper02. normal probability paper")
+
+	// sample at the exact quantiles of N(10, 2²)
+	n := 20
+	sample := make([]float64, n)
+	for i, p := range qqPositions(n) {
+		sample[n-1-i] = 10 + 2*stdNormalInv(p)
+	}
+
+	Reset()
+	mean, std, err := ProbPaperFit(sample, nil, nil)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Scalar(tst, "mean", 1e-12, mean, 10)
+	chk.Scalar(tst, "std", 1e-12, std, 2)
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		`=["0.1%","1%","5%","10%","20%","30%","50%","70%","80%","90%","95%","99%","99.9%",]`,
+		"plt.yticks(zt",
+		"plt.ylim(-3.090232306167",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// points are drawn with markers when only the color is given
+	Reset()
+	_, _, err = ProbPaperFit(sample, &A{C: "r"}, nil)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, " color='r',marker='o',ls='none')\n") {
+		tst.Errorf("points should be drawn with markers:\n%v\n", txt)
+		return
+	}
+
+	if _, _, err = ProbPaperFit([]float64{1}, nil, nil); err == nil {
+		tst.Errorf("ProbPaperFit should have failed with one sample\n")
+		return
+	}
+
+	if chk.Verbose {
+		Gll("x", "probability", nil)
+		err = SaveD("/tmp/gosl", "t_probpaper02.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
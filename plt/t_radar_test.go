@@ -0,0 +1,77 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_radar01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("radar01. radar chart")
+
+	chk.Vector(tst, "angles", 1e-15, radarAngles(4), []float64{0, math.Pi / 2, math.Pi, 3 * math.Pi / 2, 0})
+
+	cats := []string{"cost", "mass", "stiffness", "strength"}
+	series := [][]float64{
+		{1, 2, 3, 4},
+		{4, 3, 2, 1},
+	}
+	Reset()
+	err := Radar(cats, series, []string{"A", "B"}, &A{Colors: []string{"r", "b"}, Alpha: 0.25})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		".add_subplot(111, projection='polar')",
+		"=np.array([1,2,3,4,1,],dtype=float)",
+		"=np.array([4,3,2,1,4,],dtype=float)",
+		", color='r',label='A')", ", color='b',label='B')",
+		".get_color(),alpha=0.25)",
+		".set_xticklabels(['cost','mass','stiffness','strength'])",
+		".legend(loc='upper left'",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of fills", strings.Count(txt, ".fill("), 2)
+
+	// no fill and no legend
+	Reset()
+	Radar(cats, series[:1], nil, nil)
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Contains(txt, ".fill(") || strings.Contains(txt, ".legend(") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// errors
+	if Radar(cats, [][]float64{{1, 2, 3}}, nil, nil) == nil {
+		tst.Errorf("Radar should have failed with wrong number of values\n")
+		return
+	}
+	if Radar(cats, series, []string{"A"}, nil) == nil {
+		tst.Errorf("Radar should have failed with wrong number of labels\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Radar(cats, series, []string{"A", "B"}, &A{Alpha: 0.25, M: "o"})
+		err := SaveD("/tmp/gosl", "t_radar01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_scattermatrix01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("scattermatrix01. pairs plot")
+
+	// three correlated variables
+	rand.Seed(1234)
+	ns := 200
+	data := [][]float64{make([]float64, ns), make([]float64, ns), make([]float64, ns)}
+	for k := 0; k < ns; k++ {
+		z0, z1, z2 := rand.NormFloat64(), rand.NormFloat64(), rand.NormFloat64()
+		data[0][k] = z0
+		data[1][k] = 0.8*z0 + 0.6*z1
+		data[2][k] = -0.5*z0 + 0.3*z1 + 0.81*z2
+	}
+
+	Reset()
+	err := ScatterMatrix(data, []string{"a", "b", "c"}, &A{Hnbins: 20})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	chk.Int(tst, "number of subplots", strings.Count(txt, "plt.subplot(3,3,"), 9)
+	chk.Int(tst, "number of histograms", strings.Count(txt, "plt.hist("), 3)
+	chk.Int(tst, "number of scatter plots", strings.Count(txt, "plt.plot("), 6)
+	chk.Int(tst, "number of xlabels", strings.Count(txt, "plt.xlabel("), 3)
+	chk.Int(tst, "number of ylabels", strings.Count(txt, "plt.ylabel("), 3)
+	chk.Int(tst, "hidden xticklabels", strings.Count(txt, "get_xticklabels(),visible=False"), 6)
+	chk.Int(tst, "hidden yticklabels", strings.Count(txt, "get_yticklabels(),visible=False"), 6)
+	for _, cmd := range []string{
+		"plt.subplot(3,3,4)\nplt.plot(d0_0,d0_1, color='b',marker='.',ls='none')\n",
+		"plt.subplot(3,3,9)\nplt.hist(d0_2, color='b',bins=20)\nplt.xlabel(r'c')\n",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// errors
+	if ScatterMatrix([][]float64{{1, 2}, {1}}, nil, nil) == nil {
+		tst.Errorf("ScatterMatrix should have failed with unequal lengths\n")
+		return
+	}
+	if ScatterMatrix(data, []string{"a"}, nil) == nil {
+		tst.Errorf("ScatterMatrix should have failed with wrong number of names\n")
+		return
+	}
+
+	if chk.Verbose {
+		err := SaveD("/tmp/gosl", "t_scattermatrix01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_spy01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("spy01. sparsity pattern")
+
+	a := [][]float64{
+		{4, 1e-12, 0},
+		{1, 4, 0},
+		{0, 0, -2},
+	}
+
+	// dense
+	Reset()
+	Spy(a, 1e-10, &A{C: "b"})
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "=np.array([[4,1e-12,0,],[1,4,0,],[0,0,-2,],],dtype=float)") ||
+		!strings.Contains(txt, ",precision=1e-10,markersize=2, color='b')") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// triplet with repeated entry
+	Reset()
+	err := SpyTriplet([]int{0, 0, 1, 1, 2, 2}, []int{0, 1, 0, 1, 2, 2}, []float64{4, 1e-12, 1, 4, -1, -1}, 3, 3, 1e-10, &A{Ms: 5})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"=np.array([0,0,1,2,],dtype=float)", "=np.array([0,-1,-1,-2,],dtype=float)", "ms=5", "marker='s'", "ls='none'", "plt.axis([-0.5, 2.5, -2.5, 0.5])"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// errors
+	err = SpyTriplet([]int{0, 3}, []int{0, 0}, []float64{1, 1}, 3, 3, 0, nil)
+	if err == nil {
+		tst.Errorf("SpyTriplet should have failed with entry outside matrix\n")
+		return
+	}
+	err = SpyTriplet([]int{0}, []int{0, 0}, []float64{1, 1}, 3, 3, 0, nil)
+	if err == nil {
+		tst.Errorf("SpyTriplet should have failed with wrong lengths\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Subplot(1, 2, 1)
+		Spy(a, 1e-10, nil)
+		Subplot(1, 2, 2)
+		SpyTriplet([]int{0, 0, 1, 1, 2}, []int{0, 1, 0, 1, 2}, []float64{4, 1e-12, 1, 4, -2}, 3, 3, 1e-10, nil)
+		err := SaveD("/tmp/gosl", "t_spy01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
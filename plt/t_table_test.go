@@ -0,0 +1,66 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_table01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("table01. table artist")
+
+	cells := [][]string{
+		{"1e-3", "12"},
+		{"1e-6", "25"},
+	}
+
+	// all options
+	Reset()
+	Plot([]float64{1, 2, 3}, []float64{1, 0.1, 0.01}, nil)
+	name := TableArtist(cells, []string{"A", "B"}, []string{"tol", "iters"}, "right", &A{
+		Fsz:         8,
+		TcolWidths:  []float64{0.2, 0.1},
+		TcellColors: [][]string{{"w", "w"}, {"#dedede", "#dedede"}},
+	})
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		`=[["1e-3","12",],["1e-6","25",],]`,
+		name + " = plt.table(cellText=cells",
+		",loc='right',rowLabels=['A','B'],colLabels=['tol','iters'],colWidths=[0.2,0.1],cellColours=[['w','w'],['#dedede','#dedede'],])",
+		name + ".auto_set_font_size(False)",
+		name + ".set_fontsize(8)",
+		"addToEA(" + name + ")",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// defaults
+	Reset()
+	name = TableArtist(cells, nil, nil, "", nil)
+	txt = defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, name+" = plt.table(cellText=cells") || !strings.Contains(txt, ",loc='bottom')\n") ||
+		strings.Contains(txt, "Labels") || strings.Contains(txt, "set_fontsize") || !strings.Contains(txt, "addToEA("+name+")") {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Plot([]float64{1, 2, 3}, []float64{1, 0.1, 0.01}, nil)
+		TableArtist(cells, []string{"A", "B"}, []string{"tol", "iters"}, "right", &A{TcolWidths: []float64{0.2, 0.2}})
+		err := SaveD("/tmp/gosl", "t_table01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
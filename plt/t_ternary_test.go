@@ -0,0 +1,68 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_ternary01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ternary01")
+
+	// conversion
+	x, y, err := TernaryToXY(2, 1, 1)
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.Scalar(tst, "x", 1e-15, x, 0.25+0.125)
+	chk.Scalar(tst, "y", 1e-15, y, 0.25*math.Sqrt(3.0)/2.0)
+
+	// vertices
+	x, y, _ = TernaryToXY(0, 0, 5)
+	chk.Scalar(tst, "x", 1e-15, x, 0.5)
+	chk.Scalar(tst, "y", 1e-15, y, math.Sqrt(3.0)/2.0)
+
+	// invalid triples
+	_, _, err = TernaryToXY(0, 0, 0)
+	if err == nil {
+		tst.Errorf("zero sum should fail\n")
+		return
+	}
+	_, _, err = TernaryToXY(1, -2, 0.5)
+	if err == nil {
+		tst.Errorf("negative component should fail\n")
+		return
+	}
+	Reset()
+	err = Ternary([]float64{1, 0}, []float64{0, 0}, []float64{0, 0}, [3]string{"sand", "silt", "clay"}, nil)
+	if err == nil {
+		tst.Errorf("Ternary should have failed with zero sum\n")
+		return
+	}
+
+	// soil samples
+	sand := []float64{70, 20, 40, 10, 33}
+	silt := []float64{20, 60, 40, 30, 33}
+	clay := []float64{10, 20, 20, 60, 34}
+	Reset()
+	err = Ternary(sand, silt, clay, [3]string{"sand", "silt", "clay"}, &A{C: "r"})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+
+	if chk.Verbose {
+		err = SaveD("/tmp/gosl", "t_ternary01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_time01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("time01. plot time series")
+
+	// hourly values across the end of daylight saving time in New York:
+	// the local clock reads 00:00, 01:00, 01:00, 02:00
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.FixedZone("EDT", -4*3600)
+	}
+	t0 := time.Date(2016, 11, 6, 0, 0, 0, 0, loc)
+	t := make([]time.Time, 4)
+	y := make([]float64, len(t))
+	for i := 0; i < len(t); i++ {
+		t[i] = t0.Add(time.Duration(i) * time.Hour)
+		y[i] = float64(i)
+	}
+
+	Reset()
+	st, sy := PlotTime(t, y, &A{C: "r", M: "o"})
+	err = SetTimeTicksX("%H:%M", "hours")
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	times := "['2016-11-06T04:00:00.000','2016-11-06T05:00:00.000','2016-11-06T06:00:00.000','2016-11-06T07:00:00.000',]"
+	for _, cmd := range []string{st + "=np.array(" + times + ",dtype='datetime64[ms]')", "plt.plot(" + st + "," + sy + ", color='r',marker='o')", "mdt.HourLocator(interval=1)", "mdt.DateFormatter('%H:%M')"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+
+	// intervals
+	Reset()
+	for _, interval := range []string{"6hours", "years", "2months", "days", "15minutes"} {
+		err = SetTimeTicksX("", interval)
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+	}
+	txt = defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"mdt.HourLocator(interval=6)", "mdt.YearLocator(base=1)", "mdt.MonthLocator(interval=2)", "mdt.DayLocator(interval=1)", "mdt.MinuteLocator(interval=15)", "mdt.AutoDateFormatter("} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	for _, interval := range []string{"weeks", "0days", ""} {
+		if SetTimeTicksX("", interval) == nil {
+			tst.Errorf("interval %q should have failed\n", interval)
+			return
+		}
+	}
+
+	if chk.Verbose {
+		Reset()
+		PlotTime(t, y, &A{C: "r", M: "o"})
+		SetTimeTicksX("%H:%M", "hours")
+		err := SaveD("/tmp/gosl", "t_time01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
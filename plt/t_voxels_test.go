@@ -0,0 +1,73 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func Test_voxels01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("voxels01. occupancy grid")
+
+	filled := [][][]bool{
+		{{true, false}, {false, false}},
+		{{true, true}, {false, true}},
+	}
+	colors := [][][]string{
+		{{"red", ""}, {"", ""}},
+		{{"#00ff00", "blue"}, {"", "yellow"}},
+	}
+
+	var buf bytes.Buffer
+	genBoolMat3(&buf, "f", filled)
+	chk.String(tst, buf.String(), "f=np.array([[[1,0,],[0,0,],],[[1,1,],[0,1,],],],dtype=bool)\n")
+	buf.Reset()
+	genStrMat3(&buf, "c", colors[:1])
+	chk.String(tst, buf.String(), "c=np.array([[[\"red\",\"\",],[\"\",\"\",],],],dtype=object)\n")
+
+	Reset()
+	err := Voxels(filled, true, &A{VoxColors: colors, Ec: "k", Alpha: 0.8})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	if !strings.Contains(txt, "ax0.voxels(v0,facecolors=vc0,edgecolors='k',alpha=0.8)\n") {
+		tst.Errorf("voxels command is incorrect:\n%v\n", txt)
+		return
+	}
+
+	Reset()
+	Voxels(filled, true, &A{Fc: "cyan"})
+	if !strings.Contains(defaultPlotter.bufferPy.String(), "ax0.voxels(v0,facecolors='cyan')\n") {
+		tst.Errorf("voxels command is incorrect:\n%v\n", defaultPlotter.bufferPy.String())
+		return
+	}
+
+	// error
+	if Voxels(filled, true, &A{VoxColors: colors[:1]}) == nil {
+		tst.Errorf("Voxels should have failed with wrong shape of colors\n")
+		return
+	}
+	if Voxels(filled, true, &A{VoxColors: [][][]string{colors[0], {{"r"}, {"g", "b"}}}}) == nil {
+		tst.Errorf("Voxels should have failed with wrong shape of colors\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Voxels(filled, true, &A{VoxColors: colors, Ec: "k"})
+		err := SaveD("/tmp/gosl", "t_voxels01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_waterfall01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("waterfall01. stacked 3D curves")
+
+	// decaying sinusoids
+	x := utl.LinSpace(0, 10, 101)
+	ys := make([][]float64, 5)
+	for k := 0; k < len(ys); k++ {
+		ys[k] = make([]float64, len(x))
+		for i := 0; i < len(x); i++ {
+			ys[k][i] = math.Exp(-0.1*float64(k+1)*x[i]) * math.Sin(x[i])
+		}
+	}
+
+	// colormap
+	Reset()
+	err := Waterfall(x, ys, []float64{0, 1, 2, 3, 4}, true, &A{UcmapIdx: 3, Lw: 2})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{"projection='3d'", ",color=getCmap(3)(0), lw=2)", ",color=getCmap(3)(0.5), lw=2)", ",color=getCmap(3)(1), lw=2)", "=np.array([2,2,2,"} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of lines", strings.Count(txt, ".plot(x"), 5)
+
+	// colors
+	Reset()
+	err = Waterfall(x, ys[:3], nil, true, &A{Colors: []string{"r", "b"}})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt = defaultPlotter.bufferPy.String()
+	if strings.Count(txt, ",color='r')") != 2 || strings.Count(txt, ",color='b')") != 1 {
+		tst.Errorf("buffer is incorrect:\n%v\n", txt)
+		return
+	}
+
+	// errors
+	if Waterfall(x, [][]float64{ys[0], ys[1][:50]}, nil, true, nil) == nil {
+		tst.Errorf("Waterfall should have failed with unequal curve lengths\n")
+		return
+	}
+	if Waterfall(x, ys, []float64{0, 1}, true, nil) == nil {
+		tst.Errorf("Waterfall should have failed with wrong number of offsets\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Waterfall(x, ys, nil, true, &A{UcmapIdx: 3})
+		err := SaveD("/tmp/gosl", "t_waterfall01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
+
+func Test_ribbon01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ribbon01. ribbon plot")
+
+	x := []float64{0, 1, 2}
+	ys := [][]float64{{0, 1, 4}, {0, -1, -2}}
+
+	// by series index
+	Reset()
+	err := Ribbon(x, ys, 0.5, true, &A{UcmapIdx: 2})
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	txt := defaultPlotter.bufferPy.String()
+	for _, cmd := range []string{
+		"=np.array([[0,1,2,],[0,1,2,],],dtype=float)",
+		"=np.array([[0.75,0.75,0.75,],[1.25,1.25,1.25,],],dtype=float)",
+		"=np.array([[1.75,1.75,1.75,],[2.25,2.25,2.25,],],dtype=float)",
+		"=np.array([[0,1,4,],[0,1,4,],],dtype=float)",
+		"=np.array([[0,-1,-2,],[0,-1,-2,],],dtype=float)",
+		",color=getCmap(2)(0))", ",color=getCmap(2)(1))",
+	} {
+		if !strings.Contains(txt, cmd) {
+			tst.Errorf("buffer does not contain %q:\n%v\n", cmd, txt)
+			return
+		}
+	}
+	chk.Int(tst, "number of surfaces", strings.Count(txt, ".plot_surface("), 2)
+
+	// by z-value
+	Reset()
+	Ribbon(x, ys, 0.5, true, &A{UcmapIdx: 2, SbyZ: true})
+	txt = defaultPlotter.bufferPy.String()
+	chk.Int(tst, "number of colormaps", strings.Count(txt, ",cmap=getCmap(2),vmin=-2,vmax=4)"), 2)
+
+	// errors
+	if Ribbon(x, [][]float64{{1, 2}}, 0.5, true, nil) == nil {
+		tst.Errorf("Ribbon should have failed with unequal lengths\n")
+		return
+	}
+
+	if chk.Verbose {
+		Reset()
+		Ribbon(x, ys, 0.5, true, &A{UcmapIdx: 3, SbyZ: true})
+		err := SaveD("/tmp/gosl", "t_ribbon01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// useFakeWorker sets pythonCmd to a fake interpreter that understands the protocol of the worker.
+// Scripts with "undefined_color" fail with a traceback pointing to this line; "crash_worker" kills
+// the worker; and "sleep_worker" makes the worker hang. Scripts run without the worker print
+// "one-shot". It returns a function to stop the worker and restore the previous interpreter
+func useFakeWorker(dir string) (restore func()) {
+	os.MkdirAll(dir, 0777)
+	fn := dir + "/fakepython_worker.sh"
+	io.WriteFileS(fn, `#!/bin/sh
+if [ "$1" = "-c" ]; then
+  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg
+elif [ "$1" = "-u" ]; then
+  printf '%s{"ok": true, "out": "", "err": ""}\n' '`+workerDone+`'
+  n=0; bad=0; count=0
+  while IFS= read -r line; do
+    if [ "$line" = "`+workerEnd+`" ]; then
+      count=$((count+1))
+      if [ $bad -gt 0 ]; then
+        printf '%s{"ok": false, "out": "", "err": "Traceback (most recent call last):\\n  File \\"<gosl>\\", line %d, in <module>\\nValueError: bad color\\n"}\n' '`+workerDone+`' $bad
+      else
+        printf '%s{"ok": true, "out": "script %d\\n", "err": ""}\n' '`+workerDone+`' $count
+      fi
+      n=0; bad=0
+      continue
+    fi
+    n=$((n+1))
+    case "$line" in
+      *undefined_color*) bad=$n ;;
+      *crash_worker*) exit 1 ;;
+      *sleep_worker*) exec sleep 5 ;;
+    esac
+  done
+else
+  echo one-shot
+fi
+`)
+	os.Chmod(fn, 0755)
+	oldCmd := pythonCmd
+	pythonCmd, backendInfo = fn, nil
+	return func() {
+		StopWorker()
+		pythonCmd, backendInfo = oldCmd, nil
+	}
+}
+
+func Test_worker01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("worker01. scripts run by the worker")
+
+	restore := useFakeWorker("/tmp/gosl")
+	defer restore()
+
+	err := StartWorker()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	if worker == nil {
+		tst.Errorf("worker should have been started\n")
+		return
+	}
+	err = StartWorker() // no effect
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+
+	// the same process runs all scripts
+	for i := 1; i <= 3; i++ {
+		Reset()
+		Plot([]float64{0, 1}, []float64{0, 1}, nil)
+		out, err := defaultPlotter.runPy(context.Background())
+		if err != nil {
+			tst.Errorf("%v", err)
+			return
+		}
+		chk.String(tst, out, io.Sf("script %d\n", i))
+	}
+
+	// stop
+	StopWorker()
+	if worker != nil {
+		tst.Errorf("worker should have been stopped\n")
+		return
+	}
+	StopWorker() // no effect
+	out, err := defaultPlotter.runPy(context.Background())
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, out, "one-shot\n")
+}
+
+func Test_worker02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("worker02. errors reported by the worker")
+
+	dir := "/tmp/gosl"
+	restore := useFakeWorker(dir)
+	defer restore()
+
+	err := StartWorker()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+
+	// error in the script: the worker keeps running
+	Reset()
+	Plot([]float64{0, 1}, []float64{0, 1}, nil)
+	_, _, line, _ := runtime.Caller(0)
+	Plot([]float64{0, 1}, []float64{1, 0}, &A{C: "undefined_color"})
+	err = Save(dir + "/t_worker02.png")
+	if err == nil {
+		tst.Errorf("Save should have failed\n")
+		return
+	}
+	msg := err.Error()
+	io.Pforan("%v\n", msg)
+	origin := io.Sf("generated by plt.Plot called at t_worker_test.go:%d", line+1)
+	if !strings.Contains(msg, "ValueError: bad color") || !strings.Contains(msg, origin) {
+		tst.Errorf("error should contain the traceback and %q:\n%v\n", origin, msg)
+		return
+	}
+	if worker == nil {
+		tst.Errorf("worker should be running after an error in the script\n")
+		return
+	}
+	Reset()
+	out, err := defaultPlotter.runPy(context.Background())
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, out, "script 2\n")
+
+	// worker dies: fall back to one Python process per script
+	Reset()
+	PyCmds("crash_worker\n")
+	out, err = defaultPlotter.runPy(context.Background())
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	chk.String(tst, out, "one-shot\n")
+	if worker != nil {
+		tst.Errorf("worker should have been stopped after failing\n")
+	}
+}
+
+func Test_worker03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("worker03. worker timeout and start failure")
+
+	dir := "/tmp/gosl"
+	restore := useFakeWorker(dir)
+	defer restore()
+
+	// timeout
+	err := StartWorker()
+	if err != nil {
+		tst.Errorf("%v", err)
+		return
+	}
+	Reset()
+	PyCmds("sleep_worker\n")
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	t0 := time.Now()
+	err = SaveCtx(ctx, dir+"/t_worker03.png")
+	if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		tst.Errorf("SaveCtx should have failed with timeout:\n%v\n", err)
+		return
+	}
+	if time.Since(t0) > 4*time.Second {
+		tst.Errorf("worker should have been killed\n")
+		return
+	}
+	if worker != nil {
+		tst.Errorf("worker should have been stopped after timeout\n")
+		return
+	}
+
+	// start failure
+	fn := dir + "/fakepython_noworker.sh"
+	io.WriteFileS(fn, "#!/bin/sh\n"+
+		"if [ \"$1\" = \"-c\" ]; then\n"+
+		"  echo python=3.9.1; echo numpy=1.20.0; echo matplotlib=3.3.4; echo backend=agg\n"+
+		"else\n"+
+		"  echo 'ImportError: cannot import name mplot3d' >&2\n"+
+		"  exit 1\n"+
+		"fi\n")
+	os.Chmod(fn, 0755)
+	SetPythonCmd(fn)
+	err = StartWorker()
+	if err == nil || !strings.Contains(err.Error(), "cannot import name mplot3d") {
+		tst.Errorf("StartWorker should have failed with the message of Python:\n%v\n", err)
+		return
+	}
+	if worker != nil {
+		tst.Errorf("worker should not have been started\n")
+	}
+}
+
+// benchSave saves b.N simple figures
+func benchSave(b *testing.B) {
+	x := []float64{0, 1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		Reset()
+		Plot(x, x, &A{C: "r", M: "o"})
+		Gll("x", "y", nil)
+		err := Save("/tmp/gosl/bench_save.png")
+		if err != nil {
+			b.Fatalf("%v", err)
+		}
+	}
+}
+
+// Benchmark_save_oneshot measures the time to save one figure with a new Python process
+func Benchmark_save_oneshot(b *testing.B) {
+	if _, err := CheckBackend(); err != nil {
+		b.Skipf("matplotlib is not available:\n%v", err)
+	}
+	os.MkdirAll("/tmp/gosl", 0777)
+	benchSave(b)
+}
+
+// Benchmark_save_worker measures the time to save one figure with the worker
+func Benchmark_save_worker(b *testing.B) {
+	if _, err := CheckBackend(); err != nil {
+		b.Skipf("matplotlib is not available:\n%v", err)
+	}
+	os.MkdirAll("/tmp/gosl", 0777)
+	err := StartWorker()
+	if err != nil {
+		b.Fatalf("%v", err)
+	}
+	defer StopWorker()
+	b.ResetTimer()
+	benchSave(b)
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+
+	"github.com/cpmech/gosl/io"
+)
+
+// TableArtist draws a table with the given cells and registers it as an extra artist; thus the
+// saved figure includes the whole table even if it is outside the axes
+//  Input:
+//   rowLabels, colLabels -- labels of rows and columns; nil => no labels
+//   loc                  -- location; e.g. "bottom", "right", "upper left"; "" => "bottom"
+//   args                 -- font size (Fsz), widths of columns (TcolWidths) and colors of cells (TcellColors)
+//  Output:
+//   name -- name of the Python variable holding the table
+func (o *Plotter) TableArtist(cells [][]string, rowLabels, colLabels []string, loc string, args *A) (name string) {
+	n := o.bufferPy.Len()
+	name = io.Sf("tbl%d", n)
+	if loc == "" {
+		loc = "bottom"
+	}
+	genStrMat(o.pyBuf(), io.Sf("cells%d", n), cells)
+	io.Ff(o.pyBuf(), "%s = plt.table(cellText=cells%d,loc='%s'", name, n, loc)
+	if rowLabels != nil {
+		io.Ff(o.pyBuf(), ",rowLabels=%s", strings2list(rowLabels))
+	}
+	if colLabels != nil {
+		io.Ff(o.pyBuf(), ",colLabels=%s", strings2list(colLabels))
+	}
+	if args != nil {
+		if len(args.TcolWidths) > 0 {
+			io.Ff(o.pyBuf(), ",colWidths=%s", floats2list(args.TcolWidths))
+		}
+		if len(args.TcellColors) > 0 {
+			io.Ff(o.pyBuf(), ",cellColours=[")
+			for _, row := range args.TcellColors {
+				io.Ff(o.pyBuf(), "%s,", strings2list(row))
+			}
+			io.Ff(o.pyBuf(), "]")
+		}
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	if args != nil && args.Fsz > 0 {
+		io.Ff(o.pyBuf(), "%s.auto_set_font_size(False)\n", name)
+		io.Ff(o.pyBuf(), "%s.set_fontsize(%g)\n", name, args.Fsz)
+	}
+	o.RegisterExtraArtist(name)
+	return
+}
+
+// genStrMat generates a Python list of lists of strings
+func genStrMat(buf *bytes.Buffer, name string, a [][]string) {
+	io.Ff(buf, "%s=[", name)
+	for i, _ := range a {
+		io.Ff(buf, "[")
+		for j, _ := range a[i] {
+			io.Ff(buf, "%q,", a[i][j])
+		}
+		io.Ff(buf, "],")
+	}
+	io.Ff(buf, "]\n")
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// TernaryToXY converts the composition (a,b,c) to Cartesian coordinates in the ternary diagram
+// where the vertices corresponding to a=1, b=1 and c=1 are at (0,0), (1,0) and (1/2,√3/2).
+// The triple is normalised first
+func TernaryToXY(a, b, c float64) (x, y float64, err error) {
+	sum := a + b + c
+	if !(sum > 0) || a < 0 || b < 0 || c < 0 {
+		return 0, 0, chk.Err("ternary composition must be non-negative with positive sum. a=%g, b=%g, c=%g", a, b, c)
+	}
+	b /= sum
+	c /= sum
+	x = b + c/2.0
+	y = c * math.Sqrt(3.0) / 2.0
+	return
+}
+
+// Ternary draws a ternary diagram with frame, grid lines at 10% intervals, vertex labels, and the
+// points corresponding to the compositions (a,b,c). Default marker is "o" without lines
+func (o *Plotter) Ternary(a, b, c []float64, labels [3]string, args *A) (err error) {
+
+	// points
+	if len(b) != len(a) || len(c) != len(a) {
+		return chk.Err("the lengths of a, b and c must be the same. %d, %d, %d", len(a), len(b), len(c))
+	}
+	x := make([]float64, len(a))
+	y := make([]float64, len(a))
+	for i := 0; i < len(a); i++ {
+		x[i], y[i], err = TernaryToXY(a[i], b[i], c[i])
+		if err != nil {
+			return
+		}
+	}
+
+	// vertices
+	h := math.Sqrt(3.0) / 2.0
+	V := [][]float64{{0, 0}, {1, 0}, {0.5, h}}
+
+	// grid lines and tick labels
+	grid := &A{Ec: "#c7c7c7", Fc: "none", Lw: 0.5, Closed: false}
+	for k := 1; k < 10; k++ {
+		f := float64(k) / 10.0
+		g := 1.0 - f
+		xa0, ya0, _ := TernaryToXY(f, g, 0) // a constant
+		xa1, ya1, _ := TernaryToXY(f, 0, g)
+		xb0, yb0, _ := TernaryToXY(0, f, g) // b constant
+		xb1, yb1, _ := TernaryToXY(g, f, 0)
+		xc0, yc0, _ := TernaryToXY(g, 0, f) // c constant
+		xc1, yc1, _ := TernaryToXY(0, g, f)
+		o.Polyline([][]float64{{xa0, ya0}, {xa1, ya1}}, grid)
+		o.Polyline([][]float64{{xb0, yb0}, {xb1, yb1}}, grid)
+		o.Polyline([][]float64{{xc0, yc0}, {xc1, yc1}}, grid)
+		lbl := io.Sf("%d", k*10)
+		o.Text(xa0, ya0-0.03, lbl, &A{Fsz: 7, Ha: "center", Va: "top"})
+		o.Text(xb0+0.03, yb0, lbl, &A{Fsz: 7, Ha: "left", Va: "center"})
+		o.Text(xc0-0.03, yc0, lbl, &A{Fsz: 7, Ha: "right", Va: "center"})
+	}
+
+	// frame and vertex labels
+	o.Polyline(V, &A{Ec: "black", Fc: "none", Lw: 1, Closed: true})
+	o.Text(V[0][0]-0.02, V[0][1]-0.02, labels[0], &A{Ha: "right", Va: "top"})
+	o.Text(V[1][0]+0.02, V[1][1]-0.02, labels[1], &A{Ha: "left", Va: "top"})
+	o.Text(V[2][0], V[2][1]+0.03, labels[2], &A{Ha: "center", Va: "bottom"})
+
+	// points
+	sty := &A{M: "o", Ls: "none"}
+	if args != nil {
+		sty = new(A)
+		*sty = *args
+		if sty.M == "" {
+			sty.M = "o"
+		}
+		if sty.Ls == "" {
+			sty.Ls = "none"
+		}
+	}
+	o.Plot(x, y, sty)
+	o.Equal()
+	o.AxisOff()
+	return
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// PlotTime plots y versus time values. The times are converted to UTC; thus the ordering is
+// preserved across daylight saving time boundaries. It returns the names of the Python arrays
+func (o *Plotter) PlotTime(t []time.Time, y []float64, args *A) (st, sy string) {
+	n := o.bufferPy.Len()
+	st = io.Sf("t%d", n)
+	sy = io.Sf("y%d", n)
+	genTimeArray(o.pyBuf(), st, t)
+	genArray(o.pyBuf(), sy, y)
+	io.Ff(o.pyBuf(), "plt.plot(%s,%s", st, sy)
+	updateBufferAndClose(o.pyBuf(), args, false)
+	return
+}
+
+// SetTimeTicksX sets the spacing and format of time ticks along x
+//  format   -- strftime format of tick labels; e.g. "%Y-%m-%d %H:%M"; "" => automatic
+//  interval -- spacing of major ticks: [number]unit where unit is "years", "months", "days",
+//              "hours" or "minutes"; e.g. "days" or "6hours"
+func (o *Plotter) SetTimeTicksX(format string, interval string) (err error) {
+	num, unit := 1, strings.TrimLeft(interval, "0123456789")
+	if len(unit) < len(interval) {
+		num, err = strconv.Atoi(interval[:len(interval)-len(unit)])
+		if err != nil || num < 1 {
+			return chk.Err("number of units in time interval %q is invalid", interval)
+		}
+	}
+	var loc string
+	switch strings.TrimSpace(unit) {
+	case "years":
+		loc = io.Sf("mdt.YearLocator(base=%d)", num)
+	case "months":
+		loc = io.Sf("mdt.MonthLocator(interval=%d)", num)
+	case "days":
+		loc = io.Sf("mdt.DayLocator(interval=%d)", num)
+	case "hours":
+		loc = io.Sf("mdt.HourLocator(interval=%d)", num)
+	case "minutes":
+		loc = io.Sf("mdt.MinuteLocator(interval=%d)", num)
+	default:
+		return chk.Err("time interval %q is invalid. units are \"years\", \"months\", \"days\", \"hours\" or \"minutes\"", interval)
+	}
+	n := o.bufferPy.Len()
+	io.Ff(o.pyBuf(), "majorLocator%d = %s\n", n, loc)
+	io.Ff(o.pyBuf(), "plt.gca().xaxis.set_major_locator(majorLocator%d)\n", n)
+	if format == "" {
+		io.Ff(o.pyBuf(), "plt.gca().xaxis.set_major_formatter(mdt.AutoDateFormatter(majorLocator%d))\n", n)
+	} else {
+		io.Ff(o.pyBuf(), "plt.gca().xaxis.set_major_formatter(mdt.DateFormatter('%s'))\n", format)
+	}
+	io.Ff(o.pyBuf(), "plt.gcf().autofmt_xdate()\n")
+	return
+}
+
+// genTimeArray generates array of numpy datetime64 (UTC; milliseconds)
+func genTimeArray(buf *bytes.Buffer, name string, t []time.Time) {
+	io.Ff(buf, "%s=np.array([", name)
+	for i, _ := range t {
+		io.Ff(buf, "'%s',", t[i].UTC().Format("2006-01-02T15:04:05.000"))
+	}
+	io.Ff(buf, "],dtype='datetime64[ms]')\n")
+}
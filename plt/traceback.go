@@ -0,0 +1,111 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/cpmech/gosl/io"
+)
+
+// pyOrigin holds the Go call that generated the commands starting at pos in bufferPy
+type pyOrigin struct {
+	pos    int    // position in bufferPy
+	fcn    string // plt function; e.g. "plt.ContourF"
+	caller string // location of the call to fcn; e.g. "main.go:123"
+}
+
+// pyBuf returns the buffer of Python commands after recording the Go call that is about to write
+// to it; see pyOriginAt
+func (o *Plotter) pyBuf() *bytes.Buffer {
+	pos := o.bufferPy.Len()
+	for len(o.pyOrigins) > 0 && o.pyOrigins[len(o.pyOrigins)-1].pos > pos { // buffer was truncated
+		o.pyOrigins = o.pyOrigins[:len(o.pyOrigins)-1]
+	}
+	fcn, caller := pltCaller()
+	if len(o.pyOrigins) > 0 {
+		last := o.pyOrigins[len(o.pyOrigins)-1]
+		if last.fcn == fcn && last.caller == caller {
+			return &o.bufferPy
+		}
+		if last.pos == pos {
+			o.pyOrigins = o.pyOrigins[:len(o.pyOrigins)-1]
+		}
+	}
+	o.pyOrigins = append(o.pyOrigins, pyOrigin{pos, fcn, caller})
+	return &o.bufferPy
+}
+
+// pltCaller returns the outermost plt function in the call stack of pyBuf and the location
+// where it was called from. Calls from test files are regarded as calls from outside plt
+func pltCaller() (fcn, caller string) {
+	pcs := make([]uintptr, 32)
+	npc := runtime.Callers(3, pcs) // skip Callers, pltCaller and pyBuf
+	frames := runtime.CallersFrames(pcs[:npc])
+	pkg := ""
+	for {
+		frame, more := frames.Next()
+		if pkg == "" { // the first frame is in plt
+			i := strings.LastIndex(frame.Function, "/") + 1
+			pkg = frame.Function[:i+strings.Index(frame.Function[i:], ".")+1]
+		}
+		if !strings.HasPrefix(frame.Function, pkg) || strings.HasSuffix(frame.File, "_test.go") {
+			caller = io.Sf("%s:%d", filepath.Base(frame.File), frame.Line)
+			return
+		}
+		fcn = frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+		if !more {
+			return
+		}
+	}
+}
+
+// pyOriginAt returns a description of the Go call that generated the line of bufferPy with the
+// given number (1-based); e.g. "plt.ContourF called at main.go:123". It returns "" if not found
+func (o *Plotter) pyOriginAt(line int) string {
+	if line < 1 {
+		return ""
+	}
+	b := o.bufferPy.Bytes()
+	pos := 0
+	for l := 1; l < line; l++ {
+		i := bytes.IndexByte(b[pos:], '\n')
+		if i < 0 {
+			return ""
+		}
+		pos += i + 1
+	}
+	if pos >= len(b) {
+		return ""
+	}
+	for k := len(o.pyOrigins) - 1; k >= 0; k-- {
+		if o.pyOrigins[k].pos <= pos {
+			return io.Sf("%s called at %s", o.pyOrigins[k].fcn, o.pyOrigins[k].caller)
+		}
+	}
+	return ""
+}
+
+// pyTracebackOrigin finds the Go call that generated the innermost line of bufferPy mentioned in
+// the traceback of Python (stderr) after running the script in file fn. nskip is the number of
+// lines of the script before the contents of bufferPy. It returns "" if not found
+func (o *Plotter) pyTracebackOrigin(stderr, fn string, nskip int) (origin string) {
+	re := regexp.MustCompile(`File "` + regexp.QuoteMeta(fn) + `", line (\d+)`)
+	for _, m := range re.FindAllStringSubmatch(stderr, -1) {
+		line, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		if s := o.pyOriginAt(line - nskip); s != "" {
+			origin = s
+		}
+	}
+	return
+}
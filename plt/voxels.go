@@ -0,0 +1,95 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bytes"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Voxels draws the cubes of a 3D occupancy grid; cube (i,j,k) spans [i,i+1]×[j,j+1]×[k,k+1]
+// and is drawn if filled[i][j][k] is true. The colors are given by args.VoxColors (one per
+// voxel; same shape as filled) or by args.Fc. The edge color is given by args.Ec
+func (o *Plotter) Voxels(filled [][][]bool, doInit bool, args *A) (err error) {
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	if len(a.VoxColors) > 0 {
+		if len(a.VoxColors) != len(filled) {
+			return chk.Err("colors of voxels must have the same shape as filled. %d != %d", len(a.VoxColors), len(filled))
+		}
+		for i := range filled {
+			if len(a.VoxColors[i]) != len(filled[i]) {
+				return chk.Err("colors of voxels must have the same shape as filled. %d != %d at [%d]", len(a.VoxColors[i]), len(filled[i]), i)
+			}
+			for j := range filled[i] {
+				if len(a.VoxColors[i][j]) != len(filled[i][j]) {
+					return chk.Err("colors of voxels must have the same shape as filled. %d != %d at [%d][%d]", len(a.VoxColors[i][j]), len(filled[i][j]), i, j)
+				}
+			}
+		}
+	}
+	n := o.get3daxes(doInit)
+	sf := io.Sf("v%d", n)
+	sc := io.Sf("vc%d", n)
+	genBoolMat3(o.pyBuf(), sf, filled)
+	if len(a.VoxColors) > 0 {
+		genStrMat3(o.pyBuf(), sc, a.VoxColors)
+	}
+	io.Ff(o.pyBuf(), "ax%d.voxels(%s", n, sf)
+	if len(a.VoxColors) > 0 {
+		io.Ff(o.pyBuf(), ",facecolors=%s", sc)
+	} else if a.Fc != "" {
+		io.Ff(o.pyBuf(), ",facecolors='%s'", a.Fc)
+	}
+	if a.Ec != "" {
+		io.Ff(o.pyBuf(), ",edgecolors='%s'", a.Ec)
+	}
+	if a.Lw > 0 {
+		io.Ff(o.pyBuf(), ",linewidth=%g", a.Lw)
+	}
+	if a.Alpha > 0 {
+		io.Ff(o.pyBuf(), ",alpha=%g", a.Alpha)
+	}
+	io.Ff(o.pyBuf(), ")\n")
+	return
+}
+
+// genBoolMat3 generates 3D NumPy array of booleans
+func genBoolMat3(buf *bytes.Buffer, name string, a [][][]bool) {
+	io.Ff(buf, "%s=np.array([", name)
+	for i := range a {
+		io.Ff(buf, "[")
+		for j := range a[i] {
+			io.Ff(buf, "[")
+			for k := range a[i][j] {
+				io.Ff(buf, "%d,", pyBool(a[i][j][k]))
+			}
+			io.Ff(buf, "],")
+		}
+		io.Ff(buf, "],")
+	}
+	io.Ff(buf, "],dtype=bool)\n")
+}
+
+// genStrMat3 generates 3D NumPy array of strings
+func genStrMat3(buf *bytes.Buffer, name string, a [][][]string) {
+	io.Ff(buf, "%s=np.array([", name)
+	for i := range a {
+		io.Ff(buf, "[")
+		for j := range a[i] {
+			io.Ff(buf, "[")
+			for k := range a[i][j] {
+				io.Ff(buf, "%q,", a[i][j][k])
+			}
+			io.Ff(buf, "],")
+		}
+		io.Ff(buf, "],")
+	}
+	io.Ff(buf, "],dtype=object)\n")
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Waterfall draws the curves z = ys[k](x) as 3D lines placed at y = offsets[k]. The colors are
+// given by args.Colors (cycled) or by the colormap args.UcmapIdx indexed by the offset
+//  Input:
+//   offsets -- y-offsets of curves; nil => 0, 1, 2, ...
+func (o *Plotter) Waterfall(x []float64, ys [][]float64, offsets []float64, doInit bool, args *A) (err error) {
+
+	// check
+	nc := len(ys)
+	if nc < 1 {
+		return chk.Err("at least one curve must be given")
+	}
+	if offsets == nil {
+		offsets = utl.LinSpace(0, float64(nc-1), nc)
+	}
+	if len(offsets) != nc {
+		return chk.Err("number of offsets must be equal to the number of curves. %d != %d", len(offsets), nc)
+	}
+	for k, y := range ys {
+		if len(y) != len(x) {
+			return chk.Err("curve %d must have the same length as x. %d != %d", k, len(y), len(x))
+		}
+	}
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	colors := a.Colors
+	a.C, a.Colors = "", nil
+
+	// curves
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	genArray(o.pyBuf(), sx, x)
+	omin, omax := utl.DblMinMax(offsets)
+	for k, y := range ys {
+		sy := io.Sf("y%d_%d", n, k)
+		sz := io.Sf("z%d_%d", n, k)
+		genArray(o.pyBuf(), sy, utl.DblVals(len(x), offsets[k]))
+		genArray(o.pyBuf(), sz, y)
+		io.Ff(o.pyBuf(), "ax%d.plot(%s,%s,%s", n, sx, sy, sz)
+		if len(colors) > 0 {
+			io.Ff(o.pyBuf(), ",color='%s'", colors[k%len(colors)])
+		} else {
+			t := 0.0
+			if omax > omin {
+				t = (offsets[k] - omin) / (omax - omin)
+			}
+			io.Ff(o.pyBuf(), ",color=getCmap(%d)(%g)", a.UcmapIdx, t)
+		}
+		updateBufferAndClose(o.pyBuf(), a, false)
+	}
+	return
+}
+
+// Ribbon draws each series z = ys[k](x) as a 3D strip of the given width centred at y = k+1.
+// The colors are given by args.Colors (cycled) or by the colormap args.UcmapIdx indexed by the
+// series index or, if args.SbyZ is true, by the z-value
+func (o *Plotter) Ribbon(x []float64, ys [][]float64, width float64, doInit bool, args *A) (err error) {
+
+	// check
+	nc := len(ys)
+	if nc < 1 {
+		return chk.Err("at least one series must be given")
+	}
+	for k, y := range ys {
+		if len(y) != len(x) {
+			return chk.Err("series %d must have the same length as x. %d != %d", k, len(y), len(x))
+		}
+	}
+	a := new(A)
+	if args != nil {
+		*a = *args
+	}
+	colors, byZ := a.Colors, a.SbyZ && len(a.Colors) == 0
+	a.C, a.Colors = "", nil
+	zmin, zmax := matMinMax(ys)
+	if len(a.VminVmax) == 2 {
+		zmin, zmax = a.VminVmax[0], a.VminVmax[1]
+	}
+
+	// strips
+	n := o.get3daxes(doInit)
+	sx := io.Sf("x%d", n)
+	genMat(o.pyBuf(), sx, [][]float64{x, x})
+	for k, y := range ys {
+		yc := float64(k + 1)
+		sy := io.Sf("y%d_%d", n, k)
+		sz := io.Sf("z%d_%d", n, k)
+		genMat(o.pyBuf(), sy, [][]float64{utl.DblVals(len(x), yc-width/2), utl.DblVals(len(x), yc+width/2)})
+		genMat(o.pyBuf(), sz, [][]float64{y, y})
+		io.Ff(o.pyBuf(), "p%d_%d = ax%d.plot_surface(%s,%s,%s", n, k, n, sx, sy, sz)
+		switch {
+		case len(colors) > 0:
+			io.Ff(o.pyBuf(), ",color='%s'", colors[k%len(colors)])
+		case byZ:
+			io.Ff(o.pyBuf(), ",cmap=getCmap(%d),vmin=%g,vmax=%g", a.UcmapIdx, zmin, zmax)
+		default:
+			t := 0.0
+			if nc > 1 {
+				t = float64(k) / float64(nc-1)
+			}
+			io.Ff(o.pyBuf(), ",color=getCmap(%d)(%g)", a.UcmapIdx, t)
+		}
+		updateBufferAndClose(o.pyBuf(), a, false)
+	}
+	return
+}
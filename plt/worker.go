@@ -0,0 +1,214 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	goio "io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// workerEnd is the line written after each script sent to the worker
+const workerEnd = "<<<GOSL-WORKER-END>>>"
+
+// workerDone starts the line (followed by a JSON object) written by the worker after each script
+const workerDone = "<<<GOSL-WORKER-DONE>>>"
+
+// workerFile is the file name of the scripts in the tracebacks of the worker
+const workerFile = "<gosl>"
+
+// pyWorker holds a long-lived Python process started by StartWorker
+type pyWorker struct {
+	cmd    *exec.Cmd        // Python process
+	stdin  goio.WriteCloser // scripts are written here
+	stdout *bufio.Reader    // replies are read from here
+	stderr bytes.Buffer     // messages written by the process outside the scripts
+}
+
+// workerReply holds the results of running one script in the worker
+type workerReply struct {
+	Ok  bool   `json:"ok"`  // the script ran without errors
+	Out string `json:"out"` // output of the script
+	Err string `json:"err"` // messages (e.g. traceback) written to stderr by the script
+}
+
+// worker is the Python process started by StartWorker; nil => one Python process per script
+var worker *pyWorker
+
+// workerMu guards worker and serialises the scripts sent to it
+var workerMu sync.Mutex
+
+// StartWorker starts a long-lived Python process that runs the scripts of Save, Show, etc.;
+// thus numpy and matplotlib are imported only once; e.g. when saving many figures in a loop.
+// The Python command and environment (see SetPythonCmd and SetPythonEnv) are read now. Scripts
+// of all Plotters are run by the same process one at a time. If the process fails, it is stopped
+// and the scripts are run by new Python processes, as without StartWorker. Call StopWorker to stop
+// the process
+func StartWorker() (err error) {
+	workerMu.Lock()
+	defer workerMu.Unlock()
+	if worker != nil {
+		return
+	}
+	info, err := CheckBackend()
+	if err != nil {
+		return
+	}
+	python, env, _ := pythonSettings()
+	args := []string{"-u", "-c", pythonWorker}
+	if info.UseAgg {
+		args = append(args, "agg")
+	}
+	w := &pyWorker{cmd: exec.Command(python, args...)}
+	w.cmd.Env = env
+	w.cmd.Stderr = &w.stderr
+	w.stdin, err = w.cmd.StdinPipe()
+	if err != nil {
+		return chk.Err("cannot start Python worker:\n%v", err)
+	}
+	stdout, err := w.cmd.StdoutPipe()
+	if err != nil {
+		return chk.Err("cannot start Python worker:\n%v", err)
+	}
+	w.stdout = bufio.NewReader(stdout)
+	err = w.cmd.Start()
+	if err != nil {
+		return chk.Err("cannot start Python worker with %q:\n%v", python, err)
+	}
+	r, err := w.read() // the worker replies when ready
+	if err != nil || !r.Ok {
+		w.kill()
+		return chk.Err("cannot start Python worker:\n%v\n%v%v", err, r.Err, w.stderr.String())
+	}
+	worker = w
+	return
+}
+
+// StopWorker stops the Python process started by StartWorker. Afterwards, each script is run by a
+// new Python process
+func StopWorker() {
+	workerMu.Lock()
+	defer workerMu.Unlock()
+	if worker == nil {
+		return
+	}
+	worker.stdin.Close() // the worker quits at the end of input
+	worker.cmd.Wait()
+	worker = nil
+}
+
+// runWorker runs script in the worker. It returns used == false if the worker was not started or
+// has failed; then, the script must be run by a new Python process. The worker is killed if ctx is
+// done before the script finishes
+func runWorker(ctx context.Context, script string) (r workerReply, used bool, err error) {
+	workerMu.Lock()
+	defer workerMu.Unlock()
+	if worker == nil {
+		return
+	}
+	type result struct {
+		r   workerReply
+		err error
+	}
+	w := worker
+	res := make(chan result, 1)
+	go func() {
+		r, err := w.send(script)
+		res <- result{r, err}
+	}()
+	select {
+	case x := <-res:
+		if x.err != nil {
+			w.kill()
+			worker = nil
+			return
+		}
+		return x.r, true, nil
+	case <-ctx.Done():
+		w.kill()
+		<-res
+		worker = nil
+		return r, true, chk.Err("call to Python worker was stopped (%v); e.g. by timeout. The worker has been stopped\n", ctx.Err())
+	}
+}
+
+// send writes script to the worker and reads the reply
+func (o *pyWorker) send(script string) (r workerReply, err error) {
+	if !strings.HasSuffix(script, "\n") {
+		script += "\n"
+	}
+	_, err = goio.WriteString(o.stdin, script+workerEnd+"\n")
+	if err != nil {
+		return
+	}
+	return o.read()
+}
+
+// read reads the reply of the worker. Lines printed before the reply (e.g. by subprocesses) are
+// added to the output
+func (o *pyWorker) read() (r workerReply, err error) {
+	var extra string
+	for {
+		var line string
+		line, err = o.stdout.ReadString('\n')
+		if err != nil {
+			return
+		}
+		if strings.HasPrefix(line, workerDone) {
+			err = json.Unmarshal([]byte(line[len(workerDone):]), &r)
+			if err != nil {
+				return r, chk.Err("cannot parse reply of Python worker: %q\n%v", line, err)
+			}
+			r.Out = extra + r.Out
+			return
+		}
+		extra += line
+	}
+}
+
+// kill stops the worker immediately
+func (o *pyWorker) kill() {
+	o.cmd.Process.Kill()
+	o.cmd.Wait()
+}
+
+// pythonWorker is the script of the worker. It runs the scripts read from stdin (each one ends with
+// workerEnd) in new namespaces, restoring rcParams and closing all figures afterwards
+const pythonWorker = `import sys, io, json, traceback, contextlib, linecache
+import matplotlib
+if len(sys.argv) > 1 and sys.argv[1] == 'agg': matplotlib.use('Agg')
+import numpy, matplotlib.pyplot as plt, mpl_toolkits.mplot3d
+END, DONE, FILE = '` + workerEnd + `', '` + workerDone + `', '` + workerFile + `'
+stdout = sys.stdout
+def reply(ok, out, err):
+    stdout.write(DONE + json.dumps({'ok': ok, 'out': out, 'err': err}) + '\n')
+    stdout.flush()
+reply(True, '', '')
+lines = []
+for line in sys.stdin:
+    if line.rstrip('\r\n') != END:
+        lines.append(line)
+        continue
+    src, lines = ''.join(lines), []
+    linecache.cache[FILE] = (len(src), None, src.splitlines(True), FILE) # source lines in traceback
+    out, err, ok = io.StringIO(), io.StringIO(), True
+    with contextlib.redirect_stdout(out), contextlib.redirect_stderr(err), matplotlib.rc_context():
+        try:
+            exec(compile(src, FILE, 'exec'), {'__name__': '__main__'})
+        except BaseException:
+            ok = False
+            t, v, tb = sys.exc_info()
+            err.write(''.join(traceback.format_exception(t, v, tb.tb_next)))
+        finally:
+            plt.close('all')
+    reply(ok, out.getvalue(), err.getvalue())
+`
@@ -5,6 +5,10 @@
 package rnd
 
 import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/plt"
 	"github.com/cpmech/gosl/utl"
 )
@@ -22,3 +26,65 @@ func (o VarData) PlotPdf(np int, args *plt.A) {
 	plt.Plot(X, Y, args)
 	plt.Gll("$x$", "$f(x)$", nil)
 }
+
+// TornadoPlot draws horizontal bars with the responses obtained when each variable is set to a
+// low and a high value (e.g. from OatSweep) relative to the base response. The bars are sorted
+// such that the widest is on top. args.C and args.Fc give the colors of the low and high sides
+func TornadoPlot(varNames []string, lows, highs []float64, base float64, args *plt.A) {
+	nv := len(varNames)
+	if len(lows) != nv || len(highs) != nv {
+		chk.Panic("the lengths of varNames, lows and highs must be the same. %d, %d, %d", nv, len(lows), len(highs))
+	}
+	clo, chi := "#5a9bd4", "#f15a60"
+	if args != nil {
+		if args.C != "" {
+			clo = args.C
+		}
+		if args.Fc != "" {
+			chi = args.Fc
+		}
+	}
+
+	// sort by width
+	idx := utl.IntRange(nv)
+	width := make([]float64, nv)
+	for i := 0; i < nv; i++ {
+		width[i] = math.Abs(highs[i] - lows[i])
+	}
+	idx, width, _, _, err := utl.SortQuadruples(idx, width, width, width, "x")
+	if err != nil {
+		chk.Panic("%v", err)
+	}
+
+	// bars
+	xmin, xmax := base, base
+	h := 0.35
+	for k, i := range idx {
+		y := float64(k)
+		for j, x := range []float64{lows[i], highs[i]} {
+			clr := clo
+			if j == 1 {
+				clr = chi
+			}
+			plt.Polyline([][]float64{{base, y - h}, {x, y - h}, {x, y + h}, {base, y + h}}, &plt.A{Fc: clr, Ec: "k", Lw: 0.5, Closed: true})
+			xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+		}
+	}
+	plt.Plot([]float64{base, base}, []float64{-0.5, float64(nv) - 0.5}, &plt.A{C: "k", Ls: "--", Lw: 1})
+	dx := 0.05 * (xmax - xmin)
+	if dx == 0 {
+		dx = 1
+	}
+	plt.AxisRange(xmin-dx, xmax+dx, -0.5, float64(nv)-0.5)
+
+	// labels
+	l := "["
+	for k, i := range idx {
+		if k > 0 {
+			l += ","
+		}
+		l += io.Sf("%q", varNames[i])
+	}
+	plt.PyCmds(io.Sf("plt.yticks(range(%d),%s)\n", nv, l+"]"))
+	plt.Gll("response", "", nil)
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import "github.com/cpmech/gosl/chk"
+
+// SobolIndices computes the first-order (S1) and total (ST) variance-based sensitivity indices of
+// the output of g with respect to each variable using Saltelli's sampling scheme
+//  Input:
+//   vars -- random variables (Gumbel and Frechet variables must be initialised)
+//   g    -- model: y = g(x) with len(x) == len(vars)
+//   n    -- number of base samples; the model is evaluated n * (len(vars) + 2) times
+//  Output:
+//   S1 -- [nvars] first-order indices; computed with Saltelli's (2010) estimator
+//   ST -- [nvars] total effect indices; computed with Jansen's estimator
+//  Notes:
+//   1) there is no Rng type in this package; thus, the samples are generated with the default
+//      (global) pseudo random numbers generator (see Init)
+//   2) there is no ParallelFor utility; thus, the model is evaluated in a serial loop
+//   3) bootstrap confidence intervals are not computed
+func SobolIndices(vars Variables, g func(x []float64) float64, n int) (S1, ST []float64, err error) {
+
+	// check
+	nv := len(vars)
+	if nv < 1 || n < 2 {
+		return nil, nil, chk.Err("at least one variable and two samples are required. nvars=%d, n=%d", nv, n)
+	}
+
+	// sample matrices A and B
+	A := make([][]float64, n)
+	B := make([][]float64, n)
+	for k := 0; k < n; k++ {
+		A[k] = make([]float64, nv)
+		B[k] = make([]float64, nv)
+		for i, v := range vars {
+			A[k][i], err = v.Sample()
+			if err != nil {
+				return
+			}
+			B[k][i], err = v.Sample()
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// model evaluations and total variance
+	fA := make([]float64, n)
+	fB := make([]float64, n)
+	for k := 0; k < n; k++ {
+		fA[k] = g(A[k])
+		fB[k] = g(B[k])
+	}
+	V := StatDev(append(fA, fB...), true)
+	V *= V
+	if V < ZERO {
+		return nil, nil, chk.Err("variance of model output is zero")
+	}
+
+	// indices
+	S1 = make([]float64, nv)
+	ST = make([]float64, nv)
+	x := make([]float64, nv)
+	for i := 0; i < nv; i++ {
+		var s1, st float64
+		for k := 0; k < n; k++ {
+			copy(x, A[k])
+			x[i] = B[k][i] // AB_i: A with i-th column from B
+			fABi := g(x)
+			s1 += fB[k] * (fABi - fA[k])
+			st += (fA[k] - fABi) * (fA[k] - fABi)
+		}
+		S1[i] = s1 / float64(n) / V
+		ST[i] = st / float64(2*n) / V
+	}
+	return
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// TrimmedStat computes the mean and standard deviation after discarding the trimFrac fraction of
+// the smallest and the trimFrac fraction of the largest values
+//  Input:
+//   data     -- sample
+//   trimFrac -- fraction to be trimmed from each end; 0 <= trimFrac < 0.5
+//  Output:
+//   mean -- trimmed mean
+//   sdev -- standard deviation of the trimmed sample
+func TrimmedStat(data []float64, trimFrac float64) (mean, sdev float64) {
+	if trimFrac < 0 || trimFrac >= 0.5 {
+		chk.Panic("trimFrac must be within [0, 0.5). trimFrac=%g is incorrect", trimFrac)
+	}
+	sorted := utl.DblGetSorted(data)
+	k := int(trimFrac * float64(len(data)))
+	trimmed := sorted[k : len(sorted)-k]
+	if len(trimmed) < 1 {
+		return
+	}
+	mean = StatAve(trimmed)
+	sdev = StatDevFirst(trimmed, mean, true)
+	return
+}
+
+// Mad computes the median absolute deviation; i.e. median(|xi - median(x)|)
+func Mad(data []float64) float64 {
+	med := utl.DblQuantile(utl.DblGetSorted(data), 0.5)
+	dev := make([]float64, len(data))
+	for i, x := range data {
+		dev[i] = math.Abs(x - med)
+	}
+	return utl.DblQuantile(utl.DblGetSorted(dev), 0.5)
+}
+
+// DetectOutliers returns the indices of points flagged as outliers
+//  Input:
+//   data   -- sample
+//   method -- "iqr": interquartile range rule; flags x < Q1 - k IQR or x > Q3 + k IQR (e.g. k=1.5)
+//             "mzscore": modified z-score; flags |0.6745 (x - median) / MAD| > k (e.g. k=3.5)
+//   k      -- multiplier or threshold
+func DetectOutliers(data []float64, method string, k float64) (idx []int) {
+	sorted := utl.DblGetSorted(data)
+	switch method {
+	case "iqr":
+		q1 := utl.DblQuantile(sorted, 0.25)
+		q3 := utl.DblQuantile(sorted, 0.75)
+		lo, hi := q1-k*(q3-q1), q3+k*(q3-q1)
+		for i, x := range data {
+			if x < lo || x > hi {
+				idx = append(idx, i)
+			}
+		}
+	case "mzscore":
+		med := utl.DblQuantile(sorted, 0.5)
+		mad := Mad(data)
+		if mad < ZERO {
+			return
+		}
+		for i, x := range data {
+			if math.Abs(0.6745*(x-med)/mad) > k {
+				idx = append(idx, i)
+			}
+		}
+	default:
+		chk.Panic("cannot detect outliers with method %q. options are \"iqr\" and \"mzscore\"", method)
+	}
+	return
+}
+
+// RemoveOutliers returns a copy of data without the outliers flagged by DetectOutliers; e.g. to be
+// used before computing moments or fitting distributions. Note: this package has no fitting API
+// to which an "exclude outliers" option could be added; thus, this standalone function should be
+// called on the data before fitting
+//  Output:
+//   clean    -- sample without outliers
+//   ndropped -- number of dropped points
+func RemoveOutliers(data []float64, method string, k float64) (clean []float64, ndropped int) {
+	idx := DetectOutliers(data, method, k)
+	ndropped = len(idx)
+	clean = make([]float64, 0, len(data)-ndropped)
+	j := 0
+	for i, x := range data {
+		if j < len(idx) && idx[j] == i {
+			j++
+			continue
+		}
+		clean = append(clean, x)
+	}
+	return
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/plt"
+)
+
+func Test_oat01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("oat01. one-at-a-time sweep and tornado plot")
+
+	vars := Variables{
+		&VarData{D: D_Normal, M: 10, S: 2},
+		&VarData{D: D_Uniform, Min: 0, Max: 4},
+		&VarData{D: D_Lognormal, M: 1, S: 0.5},
+		&VarData{D: D_Gumbel, M: 5, S: 1},
+	}
+	err := vars.Init()
+	if err != nil {
+		tst.Errorf("Init failed:\n%v", err)
+		return
+	}
+
+	// inverse CDF
+	for i, v := range vars {
+		for _, p := range []float64{0.05, 0.5, 0.95} {
+			x, err := v.InvCdf(p)
+			if err != nil {
+				tst.Errorf("InvCdf failed:\n%v", err)
+				return
+			}
+			chk.Scalar(tst, io.Sf("var %d: F(InvCdf(%g))", i, p), 1e-8, v.Distr.Cdf(x), p)
+		}
+	}
+	_, err = vars[0].InvCdf(1)
+	if err == nil {
+		tst.Errorf("InvCdf(1) should have failed\n")
+		return
+	}
+
+	// design
+	nv, nl := len(vars), 3
+	design, varIndex := OatSweep(vars, nl)
+	chk.Int(tst, "number of rows", len(design), 1+nv*nl)
+	chk.Int(tst, "number of columns", len(design[0]), nv)
+	chk.Ints(tst, "varIndex", varIndex, []int{-1, 0, 0, 0, 1, 1, 1, 2, 2, 2, 3, 3, 3})
+
+	// base row
+	v := math.Log(1.0 + 0.5*0.5)
+	mdLog := math.Exp(-v / 2.0)
+	mdGum, _ := vars[3].InvCdf(0.5)
+	base := []float64{10, 2, mdLog, mdGum}
+	chk.Vector(tst, "base", 1e-15, design[0], base)
+
+	// quantiles
+	z95 := 1.6448536269514722
+	chk.Vector(tst, "row 1", 1e-8, design[1], []float64{10 - 2*z95, 2, mdLog, mdGum})
+	chk.Vector(tst, "row 2", 1e-15, design[2], base)
+	chk.Vector(tst, "row 3", 1e-8, design[3], []float64{10 + 2*z95, 2, mdLog, mdGum})
+	chk.Vector(tst, "row 4", 1e-15, design[4], []float64{10, 0.2, mdLog, mdGum})
+	chk.Vector(tst, "row 6", 1e-15, design[6], []float64{10, 3.8, mdLog, mdGum})
+
+	// tornado plot
+	if chk.Verbose {
+		g := func(x []float64) float64 { return x[0] + 2*x[1] - 3*x[2] + 0.5*x[3] }
+		lows := make([]float64, nv)
+		highs := make([]float64, nv)
+		for r := 1; r < len(design); r++ {
+			i := varIndex[r]
+			if (r-1)%nl == 0 {
+				lows[i] = g(design[r])
+			}
+			if (r-1)%nl == nl-1 {
+				highs[i] = g(design[r])
+			}
+		}
+		plt.SetForPng(0.75, 400, 150, nil)
+		TornadoPlot([]string{"normal", "uniform", "lognormal", "gumbel"}, lows, highs, g(design[0]), nil)
+		err = plt.SaveD("/tmp/gosl", "rnd_oat01.png")
+		if err != nil {
+			tst.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_sobol01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("sobol01. Ishigami function")
+
+	// Ishigami function
+	a, b := 7.0, 0.1
+	g := func(x []float64) float64 {
+		return math.Sin(x[0]) + a*math.Pow(math.Sin(x[1]), 2) + b*math.Pow(x[2], 4)*math.Sin(x[0])
+	}
+
+	// variables
+	π := math.Pi
+	vars := Variables{
+		&VarData{D: D_Uniform, Min: -π, Max: π},
+		&VarData{D: D_Uniform, Min: -π, Max: π},
+		&VarData{D: D_Uniform, Min: -π, Max: π},
+	}
+	err := vars.Init()
+	if err != nil {
+		tst.Errorf("Init failed:\n%v", err)
+		return
+	}
+
+	// analytical indices
+	π4, π8 := math.Pow(π, 4), math.Pow(π, 8)
+	V := a*a/8 + b*π4/5 + b*b*π8/18 + 0.5
+	V1 := 0.5 * math.Pow(1+b*π4/5, 2)
+	V2 := a * a / 8
+	V13 := 8 * b * b * π8 / 225
+	S1ana := []float64{V1 / V, V2 / V, 0}
+	STana := []float64{(V1 + V13) / V, V2 / V, V13 / V}
+
+	// numerical indices
+	Init(1234)
+	S1, ST, err := SobolIndices(vars, g, 20000)
+	if err != nil {
+		tst.Errorf("SobolIndices failed:\n%v", err)
+		return
+	}
+	io.Pforan("S1 = %.4f  (%.4f)\n", S1, S1ana)
+	io.Pforan("ST = %.4f  (%.4f)\n", ST, STana)
+	chk.Vector(tst, "S1", 0.05, S1, S1ana)
+	chk.Vector(tst, "ST", 0.05, ST, STana)
+}
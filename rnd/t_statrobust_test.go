@@ -0,0 +1,82 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_robust01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("robust01. median absolute deviation and trimmed statistics")
+
+	x := []float64{1, 1, 2, 2, 4, 6, 9}
+	chk.Scalar(tst, "mad", 1e-15, Mad(x), 1)
+
+	mean, sdev := TrimmedStat([]float64{100, 1, 2, 3, -100}, 0.2)
+	chk.Scalar(tst, "trimmed mean", 1e-15, mean, 2)
+	chk.Scalar(tst, "trimmed sdev", 1e-15, sdev, 1)
+}
+
+func Test_robust02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("robust02. outliers")
+
+	// normal sample with planted outliers
+	Init(1234)
+	μ, σ := 10.0, 2.0
+	n, nout := 2000, 20
+	data := make([]float64, n)
+	for i := 0; i < n; i++ {
+		data[i] = Normal(μ, σ)
+	}
+	planted := make(map[int]bool)
+	for k := 0; k < nout; k++ {
+		i := k * (n / nout)
+		data[i] = μ + 20*σ + float64(k)
+		planted[i] = true
+	}
+
+	// detection
+	for _, method := range []string{"iqr", "mzscore"} {
+		k := 3.0
+		if method == "mzscore" {
+			k = 3.5
+		}
+		idx := DetectOutliers(data, method, k)
+		nfound := 0
+		for _, i := range idx {
+			if planted[i] {
+				nfound++
+			}
+		}
+		io.Pforan("%s: flagged=%d found=%d\n", method, len(idx), nfound)
+		chk.Int(tst, method+": planted found", nfound, nout)
+		if len(idx)-nfound > n/100 {
+			tst.Errorf("%s: too many false positives: %d\n", method, len(idx)-nfound)
+			return
+		}
+	}
+
+	// trimmed statistics recover parameters
+	mean, sdev := StatAve(data), StatDev(data, true)
+	tmean, _ := TrimmedStat(data, 0.05)
+	io.Pforan("mean=%g sdev=%g trimmed mean=%g\n", mean, sdev, tmean)
+	chk.Scalar(tst, "trimmed mean", 0.2, tmean, μ)
+
+	// removal
+	clean, ndropped := RemoveOutliers(data, "mzscore", 3.5)
+	chk.Int(tst, "len(clean)", len(clean), n-ndropped)
+	cmean := StatAve(clean)
+	csdev := StatDev(clean, true)
+	io.Pforan("clean: mean=%g sdev=%g ndropped=%d\n", cmean, csdev, ndropped)
+	chk.Scalar(tst, "clean mean", 0.2, cmean, μ)
+	chk.Scalar(tst, "clean sdev", 0.2, csdev, σ)
+}
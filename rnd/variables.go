@@ -5,6 +5,9 @@
 package rnd
 
 import (
+	"math"
+	"math/rand"
+
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fun"
 )
@@ -60,6 +63,102 @@ func (o *VarData) Transform(x float64) (y float64, invalid bool) {
 	return
 }
 
+// Sample generates a pseudo random number belonging to the distribution of this variable
+//  Note: Gumbel and Frechet variables must be initialised first (see Variables.Init)
+func (o *VarData) Sample() (x float64, err error) {
+	switch o.D {
+	case D_Normal:
+		return Normal(o.M, o.S), nil
+	case D_Lognormal:
+		return Lognormal(o.M, o.S), nil
+	case D_Uniform:
+		return Uniform(o.Min, o.Max), nil
+	case D_Gumbel:
+		d, ok := o.Distr.(*DistGumbel)
+		if !ok {
+			return 0, chk.Err("Gumbel variable must be initialised before sampling")
+		}
+		return d.U - d.B*math.Log(-math.Log(1.0-rand.Float64())), nil
+	case D_Frechet:
+		d, ok := o.Distr.(*DistFrechet)
+		if !ok {
+			return 0, chk.Err("Frechet variable must be initialised before sampling")
+		}
+		return d.L + d.C*math.Pow(-math.Log(1.0-rand.Float64()), -1.0/d.A), nil
+	}
+	return 0, chk.Err("cannot sample variable with distribution %v", o.D)
+}
+
+// InvCdf computes the inverse of the cumulative distribution function; i.e. the value x such that
+// F(x) = p (0 < p < 1)
+//  Note: Gumbel and Frechet variables must be initialised first (see Variables.Init)
+func (o *VarData) InvCdf(p float64) (x float64, err error) {
+	if p <= 0 || p >= 1 {
+		return 0, chk.Err("probability must be within (0, 1). p=%g is invalid", p)
+	}
+	switch o.D {
+	case D_Normal:
+		return o.M + o.S*StdInvPhi(p), nil
+	case D_Lognormal:
+		δ := o.S / o.M
+		v := math.Log(1.0 + δ*δ)
+		return math.Exp(math.Log(o.M) - v/2.0 + math.Sqrt(v)*StdInvPhi(p)), nil
+	case D_Uniform:
+		return o.Min + p*(o.Max-o.Min), nil
+	case D_Gumbel:
+		d, ok := o.Distr.(*DistGumbel)
+		if !ok {
+			return 0, chk.Err("Gumbel variable must be initialised before computing InvCdf")
+		}
+		return d.U - d.B*math.Log(-math.Log(p)), nil
+	case D_Frechet:
+		d, ok := o.Distr.(*DistFrechet)
+		if !ok {
+			return 0, chk.Err("Frechet variable must be initialised before computing InvCdf")
+		}
+		return d.L + d.C*math.Pow(-math.Log(p), -1.0/d.A), nil
+	}
+	return 0, chk.Err("cannot compute InvCdf of variable with distribution %v", o.D)
+}
+
+// OatSweep generates a one-at-a-time design; e.g. for tornado plots. The first row of design
+// holds all variables at their medians. The next rows sweep each variable, one at a time,
+// across nLevels (>= 2) equally spaced quantiles from P5 to P95 while the other variables are
+// kept at their medians. varIndex[r] gives the variable perturbed by row r (-1 for the first row)
+//  Note: Gumbel and Frechet variables must be initialised first (see Variables.Init)
+func OatSweep(vars []*VarData, nLevels int) (design [][]float64, varIndex []int) {
+	if nLevels < 2 {
+		chk.Panic("number of levels must be at least 2. nLevels=%d is invalid", nLevels)
+	}
+	nv := len(vars)
+	base := make([]float64, nv)
+	for i, v := range vars {
+		x, err := v.InvCdf(0.5)
+		if err != nil {
+			chk.Panic("cannot compute median of variable %d:\n%v", i, err)
+		}
+		base[i] = x
+	}
+	design = make([][]float64, 1+nv*nLevels)
+	varIndex = make([]int, 1+nv*nLevels)
+	design[0], varIndex[0] = base, -1
+	r := 1
+	for i, v := range vars {
+		for k := 0; k < nLevels; k++ {
+			p := 0.05 + 0.9*float64(k)/float64(nLevels-1)
+			x, err := v.InvCdf(p)
+			if err != nil {
+				chk.Panic("cannot compute quantile %g of variable %d:\n%v", p, i, err)
+			}
+			design[r] = make([]float64, nv)
+			copy(design[r], base)
+			design[r][i], varIndex[r] = x, i
+			r++
+		}
+	}
+	return
+}
+
 // Variables implements a set of random variables
 type Variables []*VarData
 
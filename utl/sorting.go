@@ -102,6 +102,28 @@ func DblGetSorted(A []float64) (Asorted []float64) {
 	return
 }
 
+// DblQuantile returns the p-quantile (0 <= p <= 1) of sorted (increasing) values by linear
+// interpolation between the closest ranks; e.g. p=0.5 gives the median
+//  Note: returns 0 if Asorted is empty
+func DblQuantile(Asorted []float64, p float64) float64 {
+	n := len(Asorted)
+	if n == 0 {
+		return 0
+	}
+	if p <= 0 {
+		return Asorted[0]
+	}
+	if p >= 1 {
+		return Asorted[n-1]
+	}
+	h := p * float64(n-1)
+	i := int(h)
+	if i+1 >= n {
+		return Asorted[n-1]
+	}
+	return Asorted[i] + (h-float64(i))*(Asorted[i+1]-Asorted[i])
+}
+
 // Quadruple helps to sort a quadruple of 1 int and 3 float64s
 type Quadruple struct {
 	I int
@@ -229,3 +229,18 @@ func Test_sort07(tst *testing.T) {
 	IntSort4(&x[0], &x[1], &x[2], &x[3])
 	chk.Ints(tst, "sort4(x)", x, []int{0, 1, 3, 10})
 }
+
+func Test_sort08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("sort08. quantiles")
+
+	a := DblGetSorted([]float64{7, 1, 3, 5, 9})
+	chk.Scalar(tst, "q(0)", 1e-15, DblQuantile(a, 0), 1)
+	chk.Scalar(tst, "q(0.25)", 1e-15, DblQuantile(a, 0.25), 3)
+	chk.Scalar(tst, "q(0.5)", 1e-15, DblQuantile(a, 0.5), 5)
+	chk.Scalar(tst, "q(0.6)", 1e-15, DblQuantile(a, 0.6), 5.8)
+	chk.Scalar(tst, "q(1)", 1e-15, DblQuantile(a, 1), 9)
+	chk.Scalar(tst, "median", 1e-15, DblQuantile([]float64{1, 2, 3, 4}, 0.5), 2.5)
+	chk.Scalar(tst, "empty", 1e-15, DblQuantile(nil, 0.5), 0)
+}